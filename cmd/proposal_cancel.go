@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+
+	"github.com/fox-one/pkg/qrcode"
+	"github.com/spf13/cobra"
+)
+
+// governing command for canceling the queued proposal
+var cancelProposalCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "cancel the proposal queued by the timelock",
+	Long: `flags->
+	trace: trace id of the queued proposal`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		system := provideSystem()
+		dapp := provideDapp()
+
+		trace, _ := cmd.Flags().GetString("trace")
+		if trace == "" {
+			panic("invalid trace")
+		}
+
+		req := proposal.CancelReq{
+			TraceID: trace,
+		}
+
+		url, err := buildProposalTransferURL(ctx, system, dapp.Client, core.ActionTypeProposalCancel, req)
+		if err != nil {
+			cmd.PrintErr(err)
+			return
+		}
+
+		cmd.Println(url)
+		qrcode.Fprint(cmd.OutOrStdout(), url)
+	},
+}
+
+func init() {
+	proposalCmd.AddCommand(cancelProposalCmd)
+
+	cancelProposalCmd.Flags().String("trace", "", "trace id of the queued proposal")
+}
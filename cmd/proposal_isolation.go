@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"compound/pkg/number"
+
+	"github.com/fox-one/pkg/qrcode"
+	"github.com/spf13/cobra"
+)
+
+var collateralModes = map[string]core.CollateralMode{
+	"cross":    core.CollateralModeCross,
+	"isolated": core.CollateralModeIsolated,
+}
+
+// governing command for isolation mode
+var isolationCmd = &cobra.Command{
+	Use:   "isolation",
+	Short: "set the collateral mode of market",
+	Long: `flags->
+	asset: asset id of market
+	mode: cross or isolated
+	debt_ceiling: the max borrow value backed by the isolated collateral
+	borrow_assets: the borrow assets the isolated collateral can back`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		system := provideSystem()
+		dapp := provideDapp()
+
+		asset, _ := cmd.Flags().GetString("asset")
+		if asset == "" {
+			panic("invalid asset")
+		}
+
+		name, _ := cmd.Flags().GetString("mode")
+		mode, ok := collateralModes[name]
+		if !ok {
+			panic("invalid mode")
+		}
+
+		debtCeiling, _ := cmd.Flags().GetString("debt_ceiling")
+		borrowAssets, _ := cmd.Flags().GetStringSlice("borrow_assets")
+
+		req := proposal.IsolationReq{
+			AssetID:      asset,
+			Mode:         mode,
+			DebtCeiling:  number.Decimal(debtCeiling),
+			BorrowAssets: borrowAssets,
+		}
+
+		url, err := buildProposalTransferURL(ctx, system, dapp.Client, core.ActionTypeProposalSetIsolation, req)
+		if err != nil {
+			cmd.PrintErr(err)
+			return
+		}
+
+		cmd.Println(url)
+		qrcode.Fprint(cmd.OutOrStdout(), url)
+	},
+}
+
+func init() {
+	proposalCmd.AddCommand(isolationCmd)
+
+	isolationCmd.Flags().String("asset", "", "asset id of market")
+	isolationCmd.Flags().String("mode", "isolated", "cross or isolated")
+	isolationCmd.Flags().String("debt_ceiling", "0", "max borrow value backed by the isolated collateral")
+	isolationCmd.Flags().StringSlice("borrow_assets", nil, "borrow assets the isolated collateral can back")
+}
@@ -29,7 +29,8 @@ var upsertMarketCmd = &cobra.Command{
 	jump_multi: jump multiplier
 	kink: kink
 	price_threshold: int
-	max_pledge: max pledge`,
+	max_pledge: max pledge
+	supply_cap: supply cap`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 		system := provideSystem()
@@ -163,6 +164,16 @@ var upsertMarketCmd = &cobra.Command{
 		}
 		req.MaxPledge = maxPledge
 
+		flag, e = cmd.Flags().GetString("supply_cap")
+		if e != nil {
+			panic("invalid flag supply_cap")
+		}
+		supplyCap, e := decimal.NewFromString(flag)
+		if e != nil {
+			panic(e)
+		}
+		req.SupplyCap = supplyCap
+
 		if pt, err := cmd.Flags().GetInt("price_threshold"); err != nil {
 			panic("invalid param: price_threshold")
 		} else {
@@ -209,4 +220,5 @@ func init() {
 	upsertMarketCmd.Flags().String("price", "0", "price")
 	upsertMarketCmd.Flags().Int("price_threshold", 0, "price threshold")
 	upsertMarketCmd.Flags().String("max_pledge", "0", "max_pledge")
+	upsertMarketCmd.Flags().String("supply_cap", "0", "supply_cap")
 }
@@ -18,7 +18,7 @@ import (
 var upsertMarketsCmd = &cobra.Command{
 	Use: "markets",
 	Long: "input csv file with the following format:\n" +
-		"Symbol,Asset,C Token,Init Exchange,Reserve Factor,Liquidation Incentive,Collateral Factor,Base Rate,Close Factor,Multiplier,Jump Multiplier,Kink,Price Threshold,Max Supply",
+		"Symbol,Asset,C Token,Init Exchange,Reserve Factor,Liquidation Incentive,Collateral Factor,Base Rate,Close Factor,Multiplier,Jump Multiplier,Kink,Price Threshold,Max Supply,Supply Cap",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := cmd.Context()
 		system := provideSystem()
@@ -74,6 +74,10 @@ var upsertMarketsCmd = &cobra.Command{
 				req.MaxPledge = number.Decimal(row[13])
 			}
 
+			if len(row) > 14 {
+				req.SupplyCap = number.Decimal(row[14])
+			}
+
 			asset, err := dapp.Client.ReadAsset(ctx, req.AssetID)
 			if err != nil {
 				cmd.PrintErr("read asset failed", err, req.AssetID)
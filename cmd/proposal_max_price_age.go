@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+
+	"github.com/fox-one/pkg/qrcode"
+	"github.com/spf13/cobra"
+)
+
+// governing command for the max price age
+var maxPriceAgeCmd = &cobra.Command{
+	Use:   "max-price-age",
+	Short: "set the max price age of market",
+	Long: `flags->
+	asset: asset id of market
+	max_age: duration the price keeps fresh, eg 10m, 0 disables the check`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		system := provideSystem()
+		dapp := provideDapp()
+
+		asset, _ := cmd.Flags().GetString("asset")
+		if asset == "" {
+			panic("invalid asset")
+		}
+
+		maxAge, _ := cmd.Flags().GetDuration("max_age")
+		if maxAge < 0 {
+			panic("invalid max_age")
+		}
+
+		req := proposal.MaxPriceAgeReq{
+			AssetID: asset,
+			MaxAge:  int64(maxAge.Seconds()),
+		}
+
+		url, err := buildProposalTransferURL(ctx, system, dapp.Client, core.ActionTypeProposalSetMaxPriceAge, req)
+		if err != nil {
+			cmd.PrintErr(err)
+			return
+		}
+
+		cmd.Println(url)
+		qrcode.Fprint(cmd.OutOrStdout(), url)
+	},
+}
+
+func init() {
+	proposalCmd.AddCommand(maxPriceAgeCmd)
+
+	maxPriceAgeCmd.Flags().String("asset", "", "asset id of market")
+	maxPriceAgeCmd.Flags().Duration("max_age", 0, "duration the price keeps fresh")
+}
@@ -84,12 +84,57 @@ var removeOracleSignerCmd = &cobra.Command{
 	},
 }
 
+var rotateOracleSignerCmd = &cobra.Command{
+	Use:     "rotate-oracle-signer",
+	Aliases: []string{"rtos"},
+	Short:   "rotate the public key of oracle signer",
+	Long: `flags->
+	user: oracle signer user id
+	key: new public key of signer`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		system := provideSystem()
+		dapp := provideDapp()
+
+		user, err := cmd.Flags().GetString("user")
+		if err != nil {
+			panic(err)
+		}
+		publicKey, err := cmd.Flags().GetString("key")
+		if err != nil {
+			panic(err)
+		}
+
+		if user == "" || publicKey == "" {
+			panic("no user or public key")
+		}
+
+		req := proposal.RotateOracleSignerReq{
+			UserID:    user,
+			PublicKey: publicKey,
+		}
+
+		url, err := buildProposalTransferURL(ctx, system, dapp.Client, core.ActionTypeProposalRotateOracleSigner, req)
+		if err != nil {
+			cmd.PrintErr(err)
+			return
+		}
+
+		cmd.Println(url)
+		qrcode.Fprint(cmd.OutOrStdout(), url)
+	},
+}
+
 func init() {
 	proposalCmd.AddCommand(addOracleSignerCmd)
 	proposalCmd.AddCommand(removeOracleSignerCmd)
+	proposalCmd.AddCommand(rotateOracleSignerCmd)
 
 	addOracleSignerCmd.Flags().String("user", "", "oracle signer user id")
 	addOracleSignerCmd.Flags().String("key", "", "publick key of signer")
 
 	removeOracleSignerCmd.Flags().String("user", "", "oracle signer user id")
+
+	rotateOracleSignerCmd.Flags().String("user", "", "oracle signer user id")
+	rotateOracleSignerCmd.Flags().String("key", "", "new public key of signer")
 }
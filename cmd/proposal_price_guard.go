@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"compound/pkg/number"
+
+	"github.com/fox-one/pkg/qrcode"
+	"github.com/spf13/cobra"
+)
+
+// governing command for price aggregation
+var priceGuardCmd = &cobra.Command{
+	Use:   "price-guard",
+	Short: "set the price aggregation of market",
+	Long: `flags->
+	asset: asset id of market
+	samples: count of the latest accepted prices the median is computed over
+	window: duration the samples are taken from, eg 30m
+	max_deviation: max deviation from the median, eg 0.1 for 10%, 0 for no limit`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		system := provideSystem()
+		dapp := provideDapp()
+
+		asset, _ := cmd.Flags().GetString("asset")
+		if asset == "" {
+			panic("invalid asset")
+		}
+
+		samples, _ := cmd.Flags().GetInt("samples")
+		if samples < 1 {
+			panic("invalid samples")
+		}
+
+		window, _ := cmd.Flags().GetDuration("window")
+		if window.Seconds() < 1 {
+			panic("invalid window")
+		}
+
+		maxDeviation, _ := cmd.Flags().GetString("max_deviation")
+
+		req := proposal.PriceGuardReq{
+			AssetID:      asset,
+			Samples:      samples,
+			Window:       int64(window.Seconds()),
+			MaxDeviation: number.Decimal(maxDeviation),
+		}
+
+		url, err := buildProposalTransferURL(ctx, system, dapp.Client, core.ActionTypeProposalSetPriceGuard, req)
+		if err != nil {
+			cmd.PrintErr(err)
+			return
+		}
+
+		cmd.Println(url)
+		qrcode.Fprint(cmd.OutOrStdout(), url)
+	},
+}
+
+func init() {
+	proposalCmd.AddCommand(priceGuardCmd)
+
+	priceGuardCmd.Flags().String("asset", "", "asset id of market")
+	priceGuardCmd.Flags().Int("samples", 1, "count of the latest accepted prices the median is computed over")
+	priceGuardCmd.Flags().Duration("window", 0, "duration the samples are taken from")
+	priceGuardCmd.Flags().String("max_deviation", "0", "max deviation from the median")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"compound/pkg/number"
+
+	"github.com/fox-one/pkg/qrcode"
+	"github.com/spf13/cobra"
+)
+
+var interestRateModels = map[string]core.InterestRateModelType{
+	"jump-rate":   core.InterestRateModelJumpRate,
+	"whitepaper":  core.InterestRateModelWhitePaper,
+	"stable-coin": core.InterestRateModelStableCoin,
+}
+
+// governing command for interest rate model
+var rateModelCmd = &cobra.Command{
+	Use:     "rate-model",
+	Aliases: []string{"rm"},
+	Short:   "set the interest rate model of market",
+	Long: `flags->
+	asset: asset id of market
+	model: jump-rate, whitepaper or stable-coin
+	floor_rate: the min borrow rate per year of the stable-coin model`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		system := provideSystem()
+		dapp := provideDapp()
+
+		asset, _ := cmd.Flags().GetString("asset")
+		if asset == "" {
+			panic("invalid asset")
+		}
+
+		name, _ := cmd.Flags().GetString("model")
+		model, ok := interestRateModels[name]
+		if !ok {
+			panic("invalid model")
+		}
+
+		floorRate, _ := cmd.Flags().GetString("floor_rate")
+
+		req := proposal.InterestRateModelReq{
+			AssetID:   asset,
+			Model:     model,
+			FloorRate: number.Decimal(floorRate),
+		}
+
+		url, err := buildProposalTransferURL(ctx, system, dapp.Client, core.ActionTypeProposalSetInterestRateModel, req)
+		if err != nil {
+			cmd.PrintErr(err)
+			return
+		}
+
+		cmd.Println(url)
+		qrcode.Fprint(cmd.OutOrStdout(), url)
+	},
+}
+
+func init() {
+	proposalCmd.AddCommand(rateModelCmd)
+
+	rateModelCmd.Flags().String("asset", "", "asset id of market")
+	rateModelCmd.Flags().String("model", "jump-rate", "jump-rate, whitepaper or stable-coin")
+	rateModelCmd.Flags().String("floor_rate", "0", "min borrow rate per year of the stable-coin model")
+}
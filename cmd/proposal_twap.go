@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+
+	"github.com/fox-one/pkg/qrcode"
+	"github.com/spf13/cobra"
+)
+
+var valuationModes = map[string]core.ValuationMode{
+	"spot": core.ValuationModeSpot,
+	"twap": core.ValuationModeTWAP,
+}
+
+// governing command for the collateral valuation
+var twapCmd = &cobra.Command{
+	Use:   "twap",
+	Short: "set the collateral valuation of market",
+	Long: `flags->
+	asset: asset id of market
+	mode: spot or twap
+	window: duration the twap averages over, eg 30m, required by the twap mode`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		system := provideSystem()
+		dapp := provideDapp()
+
+		asset, _ := cmd.Flags().GetString("asset")
+		if asset == "" {
+			panic("invalid asset")
+		}
+
+		name, _ := cmd.Flags().GetString("mode")
+		mode, ok := valuationModes[name]
+		if !ok {
+			panic("invalid mode")
+		}
+
+		window, _ := cmd.Flags().GetDuration("window")
+		if mode == core.ValuationModeTWAP && window.Seconds() < 1 {
+			panic("invalid window")
+		}
+
+		req := proposal.TWAPReq{
+			AssetID: asset,
+			Mode:    mode,
+			Window:  int64(window.Seconds()),
+		}
+
+		url, err := buildProposalTransferURL(ctx, system, dapp.Client, core.ActionTypeProposalSetTWAP, req)
+		if err != nil {
+			cmd.PrintErr(err)
+			return
+		}
+
+		cmd.Println(url)
+		qrcode.Fprint(cmd.OutOrStdout(), url)
+	},
+}
+
+func init() {
+	proposalCmd.AddCommand(twapCmd)
+
+	twapCmd.Flags().String("asset", "", "asset id of market")
+	twapCmd.Flags().String("mode", "twap", "spot or twap")
+	twapCmd.Flags().Duration("window", 0, "duration the twap averages over")
+}
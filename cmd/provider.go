@@ -9,16 +9,23 @@ import (
 	proposalservice "compound/service/proposal"
 	walletservice "compound/service/wallet"
 	"compound/store/borrow"
+	"compound/store/divergence"
+	"compound/store/liquidation"
 	"compound/store/market"
 	"compound/store/message"
 	"compound/store/oracle"
 	"compound/store/proposal"
+	"compound/store/reconciliation"
+	"compound/store/reserve"
+	"compound/store/subscriber"
 	"compound/store/supply"
 	"compound/store/transaction"
 	"compound/store/user"
 	"compound/store/wallet"
 	"compound/worker/cashier"
 	"compound/worker/datadog"
+	"compound/worker/notifier"
+	"compound/worker/reconciler"
 	"fmt"
 	_ "time/tzdata"
 
@@ -26,6 +33,7 @@ import (
 	"github.com/fox-one/pkg/property"
 	"github.com/fox-one/pkg/store/db"
 	propertystore "github.com/fox-one/pkg/store/property"
+	"github.com/shopspring/decimal"
 )
 
 // provide db instance
@@ -106,6 +114,21 @@ func provideDataDogConfig(cfg config.Config) datadog.Config {
 	}
 }
 
+func provideNotifierConfig() notifier.Config {
+	return notifier.Config{
+		Interval: _flag.notifier.interval,
+		Cooldown: _flag.notifier.cooldown,
+	}
+}
+
+func provideReconcilerConfig(cfg config.Config) reconciler.Config {
+	return reconciler.Config{
+		ConversationID: cfg.DataDog.ConversationID,
+		Interval:       _flag.reconciler.interval,
+		Tolerance:      decimal.NewFromFloat(_flag.reconciler.tolerance),
+	}
+}
+
 // ---------------store-----------------------------------------
 func providePropertyStore(db *db.DB) property.Store {
 	return propertystore.New(db)
@@ -147,6 +170,38 @@ func provideOracleSignerStore(db *db.DB) core.OracleSignerStore {
 	return oracle.NewSignerStore(db)
 }
 
+func provideLiquidationCandidateStore(db *db.DB) core.LiquidationCandidateStore {
+	return liquidation.NewCandidateStore(db)
+}
+
+func provideReserveStore(db *db.DB) core.ReserveStore {
+	return reserve.New(db)
+}
+
+func providePriceTickStore(db *db.DB) core.PriceTickStore {
+	return oracle.NewPriceTickStore(db)
+}
+
+func providePriceAccumulatorStore(db *db.DB) core.PriceAccumulatorStore {
+	return oracle.NewPriceAccumulatorStore(db)
+}
+
+func provideSubscriberStore(db *db.DB) core.SubscriberStore {
+	return subscriber.New(db)
+}
+
+func provideDivergenceStore(db *db.DB) core.DivergenceStore {
+	return divergence.New(db)
+}
+
+func provideReconciliationStore(db *db.DB) core.ReconciliationStore {
+	return reconciliation.New(db)
+}
+
+func provideMarketSnapshotStore(db *db.DB) core.MarketSnapshotStore {
+	return market.NewSnapshotStore(db)
+}
+
 // ------------------service------------------------------------
 func provideProposalService(client *mixin.Client, system *core.System, marketStore core.IMarketStore, messageStore core.MessageStore) core.ProposalService {
 	return proposalservice.New(
@@ -0,0 +1,424 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/worker/payee"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/fox-one/pkg/store/db"
+	"github.com/spf13/cobra"
+)
+
+// command for replaying the outputs
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "replay the outputs with a fresh database and diff the result with the live database",
+	Long: `flags->
+	dialect: dialect of the replay database, default to the live one
+	host: host of the replay database, the db file path for sqlite3
+	database: name of the replay database
+	resume: continue the last replay instead of requiring a fresh database`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		log := logger.FromContext(ctx)
+
+		replayCfg := cfg.DB
+		if dialect, _ := cmd.Flags().GetString("dialect"); dialect != "" {
+			replayCfg.Dialect = dialect
+		}
+		if host, _ := cmd.Flags().GetString("host"); host != "" {
+			replayCfg.Host = host
+		}
+		if database, _ := cmd.Flags().GetString("database"); database != "" {
+			replayCfg.Database = database
+		}
+
+		if replayCfg.Dialect == cfg.DB.Dialect && replayCfg.Host == cfg.DB.Host && replayCfg.Database == cfg.DB.Database {
+			cmd.PrintErrln("the replay database must not be the live database")
+			return
+		}
+
+		liveDB := provideDatabase()
+		defer liveDB.Close()
+
+		replayDB := db.MustOpen(replayCfg)
+		defer replayDB.Close()
+		if err := db.Migrate(replayDB); err != nil {
+			cmd.PrintErrln("migrate replay database", err)
+			return
+		}
+
+		dapp := provideDapp()
+		system := provideSystem()
+
+		livePropertyStore := providePropertyStore(liveDB)
+		liveWalletStore := provideWalletStore(liveDB)
+		propertyStore := providePropertyStore(replayDB)
+		marketStore := provideMarketStore(replayDB)
+		supplyStore := provideSupplyStore(replayDB)
+		borrowStore := provideBorrowStore(replayDB)
+		transactionStore := provideTransactionStore(replayDB)
+		userStore := provideUserStore(replayDB)
+
+		to, err := payee.ReadCheckpoint(ctx, livePropertyStore)
+		if err != nil {
+			cmd.PrintErrln("read live checkpoint", err)
+			return
+		}
+
+		from, err := payee.ReadCheckpoint(ctx, propertyStore)
+		if err != nil {
+			cmd.PrintErrln("read replay checkpoint", err)
+			return
+		}
+
+		if resume, _ := cmd.Flags().GetBool("resume"); from > 0 && !resume {
+			cmd.PrintErrf("the replay database is not fresh (checkpoint %d), use --resume to continue\n", from)
+			return
+		}
+
+		// the user addresses are random, replay with the live ones
+		if err := copyUsers(ctx, provideUserStore(liveDB), userStore); err != nil {
+			cmd.PrintErrln("copy users", err)
+			return
+		}
+
+		w := payee.NewPayee(
+			system,
+			dapp,
+			propertyStore,
+			userStore,
+			provideWalletStore(replayDB),
+			marketStore,
+			supplyStore,
+			borrowStore,
+			provideProposalStore(replayDB),
+			transactionStore,
+			provideOracleSignerStore(replayDB),
+			provideReserveStore(replayDB),
+			providePriceTickStore(replayDB),
+			providePriceAccumulatorStore(replayDB),
+			provideSubscriberStore(replayDB),
+			&replayWalletService{WalletService: provideWalletService(dapp.Client)},
+			replayProposalService{},
+			provideAccountService(marketStore, supplyStore, borrowStore),
+		)
+
+		log.Infof("replay outputs (%d, %d]", from, to)
+		if err := w.Replay(ctx, liveWalletStore, to); err != nil {
+			cmd.PrintErrln("replay", err)
+			return
+		}
+
+		diffs, err := diffReplay(ctx, newReplayStores(liveDB), replayStores{
+			markets:      marketStore,
+			supplies:     supplyStore,
+			borrows:      borrowStore,
+			transactions: transactionStore,
+		}, liveWalletStore, to)
+		if err != nil {
+			cmd.PrintErrln("diff", err)
+			return
+		}
+
+		for _, d := range diffs {
+			cmd.Printf("%s: %d live, %d replay, %d diffs\n", d.table, d.live, d.replay, len(d.lines))
+			for _, line := range d.lines {
+				cmd.Println("  " + line)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+
+	replayCmd.Flags().String("dialect", "", "dialect of the replay database")
+	replayCmd.Flags().String("host", "", "host of the replay database, the db file path for sqlite3")
+	replayCmd.Flags().String("database", "", "name of the replay database")
+	replayCmd.Flags().Bool("resume", false, "continue the last replay")
+}
+
+// replayWalletService never sends the proposal forwarding transfers
+type replayWalletService struct {
+	core.WalletService
+}
+
+func (s *replayWalletService) HandleTransfer(ctx context.Context, transfer *core.Transfer) error {
+	return nil
+}
+
+// replayProposalService never notifies the members
+type replayProposalService struct{}
+
+func (replayProposalService) ListItems(ctx context.Context, proposal *core.Proposal) ([]core.ProposalItem, error) {
+	return nil, nil
+}
+
+func (replayProposalService) ProposalCreated(ctx context.Context, proposal *core.Proposal, by string, sysver int64) error {
+	return nil
+}
+
+func (replayProposalService) ProposalApproved(ctx context.Context, proposal *core.Proposal, by string, sysver int64) error {
+	return nil
+}
+
+func (replayProposalService) ProposalPassed(ctx context.Context, proposal *core.Proposal, sysver int64) error {
+	return nil
+}
+
+func (replayProposalService) ProposalRejected(ctx context.Context, proposal *core.Proposal, by string, sysver int64) error {
+	return nil
+}
+
+func (replayProposalService) ProposalExpired(ctx context.Context, proposal *core.Proposal, sysver int64) error {
+	return nil
+}
+
+func copyUsers(ctx context.Context, from, to core.UserStore) error {
+	var id uint64
+	const limit = 500
+
+	for {
+		users, err := from.List(ctx, id, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			id = user.ID
+			if err := to.Create(ctx, user); err != nil {
+				return err
+			}
+		}
+
+		if len(users) < limit {
+			return nil
+		}
+	}
+}
+
+type replayDiff struct {
+	table  string
+	live   int
+	replay int
+	lines  []string
+}
+
+// replayStores the stores diffed between the live & the replay database
+type replayStores struct {
+	markets      core.IMarketStore
+	supplies     core.ISupplyStore
+	borrows      core.IBorrowStore
+	transactions core.TransactionStore
+}
+
+func newReplayStores(db *db.DB) replayStores {
+	return replayStores{
+		markets:      provideMarketStore(db),
+		supplies:     provideSupplyStore(db),
+		borrows:      provideBorrowStore(db),
+		transactions: provideTransactionStore(db),
+	}
+}
+
+func diffReplay(ctx context.Context, liveStores, replayStores replayStores, outputs core.WalletStore, to int64) ([]*replayDiff, error) {
+	var diffs []*replayDiff
+
+	{
+		live, err := liveStores.markets.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		replay, err := replayStores.markets.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		liveRows, replayRows := map[string]interface{}{}, map[string]interface{}{}
+		for _, m := range live {
+			liveRows[m.AssetID] = m
+		}
+		for _, m := range replay {
+			replayRows[m.AssetID] = m
+		}
+
+		d := &replayDiff{table: "markets", live: len(live), replay: len(replay)}
+		d.lines = diffRows(liveRows, replayRows)
+		diffs = append(diffs, d)
+	}
+
+	{
+		live, err := liveStores.supplies.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		replay, err := replayStores.supplies.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		liveRows, replayRows := map[string]interface{}{}, map[string]interface{}{}
+		for _, s := range live {
+			liveRows[s.UserID+":"+s.CTokenAssetID] = s
+		}
+		for _, s := range replay {
+			replayRows[s.UserID+":"+s.CTokenAssetID] = s
+		}
+
+		d := &replayDiff{table: "supplies", live: len(live), replay: len(replay)}
+		d.lines = diffRows(liveRows, replayRows)
+		diffs = append(diffs, d)
+	}
+
+	{
+		live, err := liveStores.borrows.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		replay, err := replayStores.borrows.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		liveRows, replayRows := map[string]interface{}{}, map[string]interface{}{}
+		for _, b := range live {
+			liveRows[b.UserID+":"+b.AssetID] = b
+		}
+		for _, b := range replay {
+			replayRows[b.UserID+":"+b.AssetID] = b
+		}
+
+		d := &replayDiff{table: "borrows", live: len(live), replay: len(replay)}
+		d.lines = diffRows(liveRows, replayRows)
+		diffs = append(diffs, d)
+	}
+
+	{
+		// only the transactions created by the payee can be replayed, they are keyed by the output trace id
+		live, replay := map[string]interface{}{}, map[string]interface{}{}
+
+		var from int64
+		for from < to {
+			list, err := outputs.List(ctx, from, 500)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(list) == 0 {
+				break
+			}
+
+			for _, output := range list {
+				if from = output.ID; from > to {
+					break
+				}
+
+				liveTx, err := liveStores.transactions.FindByTraceID(ctx, output.TraceID)
+				if err != nil {
+					return nil, err
+				}
+
+				if liveTx.ID > 0 {
+					live[output.TraceID] = liveTx
+				}
+
+				replayTx, err := replayStores.transactions.FindByTraceID(ctx, output.TraceID)
+				if err != nil {
+					return nil, err
+				}
+
+				if replayTx.ID > 0 {
+					replay[output.TraceID] = replayTx
+				}
+			}
+		}
+
+		d := &replayDiff{table: "transactions", live: len(live), replay: len(replay)}
+		d.lines = diffRows(live, replay)
+		diffs = append(diffs, d)
+	}
+
+	return diffs, nil
+}
+
+// the fields not produced by the payee
+var replayIgnoredFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+func diffRows(live, replay map[string]interface{}) []string {
+	keys := make([]string, 0, len(live)+len(replay))
+	for k := range live {
+		keys = append(keys, k)
+	}
+	for k := range replay {
+		if _, ok := live[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, k := range keys {
+		l, inLive := live[k]
+		r, inReplay := replay[k]
+		switch {
+		case !inReplay:
+			lines = append(lines, fmt.Sprintf("- %s missing in replay", k))
+		case !inLive:
+			lines = append(lines, fmt.Sprintf("+ %s only in replay", k))
+		default:
+			for _, field := range diffFields(l, r) {
+				lines = append(lines, fmt.Sprintf("~ %s %s", k, field))
+			}
+		}
+	}
+
+	return lines
+}
+
+func diffFields(live, replay interface{}) []string {
+	l, r := jsonFields(live), jsonFields(replay)
+
+	keys := make([]string, 0, len(l))
+	for k := range l {
+		keys = append(keys, k)
+	}
+	for k := range r {
+		if _, ok := l[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var fields []string
+	for _, k := range keys {
+		if replayIgnoredFields[k] || reflect.DeepEqual(l[k], r[k]) {
+			continue
+		}
+
+		lv, _ := json.Marshal(l[k])
+		rv, _ := json.Marshal(r[k])
+		fields = append(fields, fmt.Sprintf("%s: live=%s replay=%s", k, lv, rv))
+	}
+
+	return fields
+}
+
+func jsonFields(v interface{}) map[string]interface{} {
+	var fields map[string]interface{}
+	data, _ := json.Marshal(v)
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
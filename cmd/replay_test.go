@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/store/memory"
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMemoryReplayStores() replayStores {
+	return replayStores{
+		markets:      memory.NewMarketStore(),
+		supplies:     memory.NewSupplyStore(),
+		borrows:      memory.NewBorrowStore(),
+		transactions: memory.NewTransactionStore(),
+	}
+}
+
+func TestDiffReplay(t *testing.T) {
+	ctx := context.Background()
+	live, replay := newMemoryReplayStores(), newMemoryReplayStores()
+	outputs := memory.NewWalletStore()
+
+	btc, usdt := uuid.Must(uuid.NewV4()).String(), uuid.Must(uuid.NewV4()).String()
+	alice, bob := uuid.Must(uuid.NewV4()).String(), uuid.Must(uuid.NewV4()).String()
+
+	for _, assetID := range []string{btc, usdt} {
+		ctokenAssetID := uuid.Must(uuid.NewV4()).String()
+		for _, stores := range []replayStores{live, replay} {
+			require.Nil(t, stores.markets.Create(ctx, &core.Market{AssetID: assetID, CTokenAssetID: ctokenAssetID, TotalCash: decimal.NewFromInt(10)}))
+		}
+	}
+
+	// the cash differs
+	market, err := replay.markets.Find(ctx, btc)
+	require.Nil(t, err)
+	market.TotalCash = decimal.NewFromInt(9)
+	require.Nil(t, replay.markets.Update(ctx, market, 1))
+	market, err = live.markets.Find(ctx, btc)
+	require.Nil(t, err)
+	market.TotalCash = decimal.NewFromInt(10)
+	require.Nil(t, live.markets.Update(ctx, market, 1))
+
+	// the supply is missing in the replay, the borrow only in the replay
+	require.Nil(t, live.supplies.Create(ctx, &core.Supply{UserID: alice, CTokenAssetID: btc, Collaterals: decimal.New(1, 0)}))
+	require.Nil(t, replay.borrows.Create(ctx, &core.Borrow{UserID: bob, AssetID: usdt, Principal: decimal.New(1, 0)}))
+
+	var traces []string
+	for i := 0; i < 3; i++ {
+		traceID := uuid.Must(uuid.NewV4()).String()
+		traces = append(traces, traceID)
+		require.Nil(t, outputs.Save(ctx, []*core.Output{{TraceID: traceID, AssetID: btc, Amount: decimal.New(1, 0)}}, false))
+	}
+
+	// the same transaction with a different id
+	require.Nil(t, live.transactions.Create(ctx, &core.Transaction{TraceID: uuid.Must(uuid.NewV4()).String()}))
+	require.Nil(t, live.transactions.Create(ctx, &core.Transaction{TraceID: traces[0], UserID: alice, Action: core.ActionTypeSupply}))
+	require.Nil(t, replay.transactions.Create(ctx, &core.Transaction{TraceID: traces[0], UserID: alice, Action: core.ActionTypeSupply}))
+	// the action differs
+	require.Nil(t, live.transactions.Create(ctx, &core.Transaction{TraceID: traces[1], UserID: bob, Action: core.ActionTypeBorrow}))
+	require.Nil(t, replay.transactions.Create(ctx, &core.Transaction{TraceID: traces[1], UserID: bob, Action: core.ActionTypeRepay}))
+	// beyond the checkpoint
+	require.Nil(t, replay.transactions.Create(ctx, &core.Transaction{TraceID: traces[2], UserID: bob}))
+
+	diffs, err := diffReplay(ctx, live, replay, outputs, 2)
+	require.Nil(t, err)
+	require.Len(t, diffs, 4)
+
+	tables := map[string]*replayDiff{}
+	for _, d := range diffs {
+		tables[d.table] = d
+	}
+
+	if d := tables["markets"]; assert.NotNil(t, d) {
+		assert.Equal(t, 2, d.live)
+		assert.Equal(t, 2, d.replay)
+		assert.Equal(t, []string{`~ ` + btc + ` total_cash: live="10" replay="9"`}, d.lines)
+	}
+
+	if d := tables["supplies"]; assert.NotNil(t, d) {
+		assert.Equal(t, []string{"- " + alice + ":" + btc + " missing in replay"}, d.lines)
+	}
+
+	if d := tables["borrows"]; assert.NotNil(t, d) {
+		assert.Equal(t, []string{"+ " + bob + ":" + usdt + " only in replay"}, d.lines)
+	}
+
+	if d := tables["transactions"]; assert.NotNil(t, d) {
+		assert.Equal(t, 2, d.live)
+		assert.Equal(t, 2, d.replay)
+		if assert.Len(t, d.lines, 1) {
+			assert.Contains(t, d.lines[0], "~ "+traces[1]+" action:")
+		}
+	}
+}
@@ -25,6 +25,16 @@ var (
 		datadog struct {
 			interval time.Duration
 		}
+
+		notifier struct {
+			interval time.Duration
+			cooldown time.Duration
+		}
+
+		reconciler struct {
+			interval  time.Duration
+			tolerance float64
+		}
 	}
 
 	cfgFile     string
@@ -50,6 +60,14 @@ func init() {
 
 	// worker.datadog.Config
 	flag.DurationVar(&_flag.datadog.interval, "datadog.interval", 5*time.Minute, "custom datadog trigger interval")
+
+	// worker.notifier.Config
+	flag.DurationVar(&_flag.notifier.interval, "notifier.interval", 10*time.Minute, "custom health factor check interval")
+	flag.DurationVar(&_flag.notifier.cooldown, "notifier.cooldown", 6*time.Hour, "custom min interval of the health factor warnings")
+
+	// worker.reconciler.Config
+	flag.DurationVar(&_flag.reconciler.interval, "reconciler.interval", 10*time.Minute, "custom balance reconciliation interval")
+	flag.Float64Var(&_flag.reconciler.tolerance, "reconciler.tolerance", 0.0001, "custom max balance drift relative to the expected balance")
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
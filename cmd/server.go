@@ -4,6 +4,10 @@ import (
 	"compound/handler/hc"
 	"compound/handler/rest"
 	"compound/handler/rpc"
+	"compound/handler/stream"
+	"compound/metric"
+	"compound/worker/payee"
+	"context"
 	"fmt"
 	"net/http"
 
@@ -34,8 +38,24 @@ var serverCmd = &cobra.Command{
 		transactionStore := provideTransactionStore(db)
 		messageStore := provideMessageStore(db)
 		proposals := provideProposalStore(db)
+		candidates := provideLiquidationCandidateStore(db)
+		reserves := provideReserveStore(db)
+		snapshots := provideMarketSnapshotStore(db)
+		priceTicks := providePriceTickStore(db)
+		propertyStore := providePropertyStore(db)
+		walletStore := provideWalletStore(db)
 
 		proposalz := provideProposalService(dapp.Client, system, marketStore, messageStore)
+		accountz := provideAccountService(marketStore, supplyStore, borrowStore)
+
+		checkpoint := func(ctx context.Context) (int64, error) {
+			return payee.ReadCheckpoint(ctx, propertyStore)
+		}
+
+		hub := stream.New(transactionStore, marketStore, priceTicks, proposals, checkpoint)
+		go hub.Run(ctx)
+
+		metric.Register(metric.NewCollector(walletStore, propertyStore, marketStore, checkpoint))
 
 		mux := chi.NewMux()
 		mux.Use(middleware.Recoverer)
@@ -51,6 +71,11 @@ var serverCmd = &cobra.Command{
 			mux.Mount("/hc", hc.Handle(rootCmd.Version))
 		}
 
+		{
+			// prometheus metrics
+			mux.Handle("/metrics", metric.Handler())
+		}
+
 		{
 			//restful api
 			mux.Mount("/api/v1", rest.Handle(
@@ -63,6 +88,13 @@ var serverCmd = &cobra.Command{
 				oracleSignerStore,
 				proposals,
 				proposalz,
+				accountz,
+				candidates,
+				reserves,
+				snapshots,
+				priceTicks,
+				propertyStore,
+				hub,
 			))
 		}
 
@@ -75,6 +107,7 @@ var serverCmd = &cobra.Command{
 				oracleSignerStore,
 				supplyStore,
 				borrowStore,
+				accountz,
 			)
 			rpcHandler := rpc.NewCompoundServer(rpcService, nil)
 			mux.Mount("/", rpcHandler)
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/service/wallet"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fox-one/mixin-sdk-go"
+	"github.com/fox-one/pkg/store/db"
+	"github.com/spf13/cobra"
+)
+
+// transfersCmd inspect the transfers held by the multisig wallet
+var transfersCmd = &cobra.Command{
+	Use:     "transfers <command>",
+	Aliases: []string{"tf"},
+	Short:   "inspect & retry the transfers of the multisig wallet",
+}
+
+var listTransfersCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the transfers by status",
+	Long: `flags->
+	status: pending, assigned or handled, default to pending
+	limit: max transfers to list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		s, _ := cmd.Flags().GetString("status")
+		status, err := parseTransferStatus(s)
+		if err != nil {
+			cmd.PrintErrln(err)
+			return
+		}
+
+		database := provideDatabase()
+		defer database.Close()
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		transfers, err := provideWalletStore(database).ListTransfers(ctx, status, limit)
+		if err != nil {
+			cmd.PrintErrln("list transfers", err)
+			return
+		}
+
+		now := time.Now()
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "TRACE\tAGE\tASSET\tAMOUNT\tOPPONENTS")
+		for _, t := range transfers {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%s\n",
+				t.TraceID,
+				now.Sub(t.CreatedAt).Truncate(time.Second),
+				t.AssetID,
+				t.Amount,
+				t.Threshold,
+				strings.Join(t.Opponents, ","),
+			)
+		}
+		w.Flush()
+	},
+}
+
+var showTransferCmd = &cobra.Command{
+	Use:   "show <trace>",
+	Short: "show the transfer with the assigned outputs, the raw transaction & the signers",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		database := provideDatabase()
+		defer database.Close()
+
+		wallets := provideWalletStore(database)
+		transfer, err := wallets.FindTransfer(ctx, args[0])
+		if err != nil {
+			cmd.PrintErrln("find transfer", err)
+			return
+		}
+
+		cmd.Printf("trace:     %s\n", transfer.TraceID)
+		cmd.Printf("status:    %s\n", transferStatus(transfer))
+		cmd.Printf("created:   %s (%s ago)\n", transfer.CreatedAt.Format(time.RFC3339), time.Since(transfer.CreatedAt).Truncate(time.Second))
+		cmd.Printf("asset:     %s\n", transfer.AssetID)
+		cmd.Printf("amount:    %s\n", transfer.Amount)
+		cmd.Printf("opponents: %d/%s\n", transfer.Threshold, strings.Join(transfer.Opponents, ","))
+		cmd.Printf("memo:      %s\n", transfer.Memo)
+
+		if divergence, err := provideDivergenceStore(database).Find(ctx, transfer.TraceID); err == nil {
+			cmd.Printf("\ndivergence (%s, resolved %v):\n  %s: %s\n", divergence.UpdatedAt.Format(time.RFC3339), divergence.Resolved, divergence.Stage, divergence.Reason)
+		} else if !db.IsErrorNotFound(err) {
+			cmd.PrintErrln("find divergence", err)
+			return
+		}
+
+		outputs, err := wallets.ListSpentBy(ctx, transfer.AssetID, transfer.TraceID)
+		if err != nil {
+			cmd.PrintErrln("list outputs", err)
+			return
+		}
+
+		cmd.Printf("\noutputs (%d):\n", len(outputs))
+		for _, output := range outputs {
+			cmd.Printf("  %d %s %s %s\n", output.ID, output.TraceID, output.Amount, output.State)
+		}
+
+		raw, signedTx, err := findSignedTransaction(ctx, wallets, transfer.TraceID, outputs)
+		if err != nil {
+			cmd.PrintErrln("find raw transaction", err)
+			return
+		}
+
+		if raw != nil {
+			cmd.Printf("\nraw transaction (pending, created %s):\n  %s\n", raw.CreatedAt.Format(time.RFC3339), raw.Data)
+		} else {
+			cmd.Println("\nraw transaction: none, not signed enough or expired")
+		}
+
+		if signedTx == "" {
+			cmd.Println("\nsigners: none")
+			return
+		}
+
+		tx, err := mixin.TransactionFromRaw(signedTx)
+		if err != nil {
+			cmd.PrintErrln("decode signed transaction", err)
+			return
+		}
+
+		var members []string
+		if len(outputs) > 0 && outputs[0].UTXO != nil {
+			members = outputs[0].UTXO.Members
+		}
+
+		signers := wallet.Signers(tx, members)
+
+		cmd.Printf("\nsigners (%d):\n", len(signers))
+		for _, signer := range signers {
+			cmd.Printf("  %s\n", signer)
+		}
+	},
+}
+
+var requeueTransferCmd = &cobra.Command{
+	Use:   "requeue <trace>",
+	Short: "reset a handled transfer whose raw transaction was expired without passing, the cashier will spend it again",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		database := provideDatabase()
+		defer database.Close()
+
+		n, err := requeueTransfer(ctx, provideWalletStore(database), args[0])
+		if err != nil {
+			cmd.PrintErrln("requeue transfer", err)
+			return
+		}
+
+		cmd.Printf("transfer %s requeued with %d outputs\n", args[0], n)
+	},
+}
+
+var resolveTransferCmd = &cobra.Command{
+	Use:   "resolve <trace>",
+	Short: "mark the signing divergence of the transfer resolved, the cashier will spend it again",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		database := provideDatabase()
+		defer database.Close()
+
+		divergences := provideDivergenceStore(database)
+		divergence, err := divergences.Find(ctx, args[0])
+		if err != nil {
+			cmd.PrintErrln("find divergence", err)
+			return
+		}
+
+		if divergence.Resolved {
+			cmd.PrintErrln("divergence is resolved already")
+			return
+		}
+
+		if err := divergences.Resolve(ctx, divergence.TraceID); err != nil {
+			cmd.PrintErrln("resolve divergence", err)
+			return
+		}
+
+		cmd.Printf("divergence of transfer %s resolved\n", divergence.TraceID)
+	},
+}
+
+func init() {
+	listTransfersCmd.Flags().String("status", "pending", "pending, assigned or handled")
+	listTransfersCmd.Flags().Int("limit", 100, "max transfers to list")
+
+	transfersCmd.AddCommand(listTransfersCmd)
+	transfersCmd.AddCommand(showTransferCmd)
+	transfersCmd.AddCommand(requeueTransferCmd)
+	transfersCmd.AddCommand(resolveTransferCmd)
+	rootCmd.AddCommand(transfersCmd)
+}
+
+func parseTransferStatus(s string) (core.TransferStatus, error) {
+	for _, status := range []core.TransferStatus{
+		core.TransferStatusPending,
+		core.TransferStatusAssigned,
+		core.TransferStatusHandled,
+	} {
+		if s == status.String() {
+			return status, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid status %q, expect pending, assigned or handled", s)
+}
+
+func transferStatus(t *core.Transfer) string {
+	switch {
+	case bool(t.Passed):
+		return core.TransferStatusPassed.String()
+	case bool(t.Handled):
+		return core.TransferStatusHandled.String()
+	case bool(t.Assigned):
+		return core.TransferStatusAssigned.String()
+	default:
+		return core.TransferStatusPending.String()
+	}
+}
+
+// requeueTransfer reset the handled transfer without a pending raw transaction nor spent outputs, returns the outputs assigned
+func requeueTransfer(ctx context.Context, wallets core.WalletStore, traceID string) (int, error) {
+	transfer, err := wallets.FindTransfer(ctx, traceID)
+	if err != nil {
+		return 0, fmt.Errorf("find transfer: %w", err)
+	}
+
+	if status := transferStatus(transfer); status != core.TransferStatusHandled.String() {
+		return 0, fmt.Errorf("transfer is %s, only the handled ones can be requeued", status)
+	}
+
+	if _, err := wallets.FindRawTransaction(ctx, transfer.TraceID); err == nil {
+		return 0, errors.New("raw transaction is pending, wait for the txsender")
+	} else if !db.IsErrorNotFound(err) {
+		return 0, fmt.Errorf("find raw transaction: %w", err)
+	}
+
+	outputs, err := wallets.ListSpentBy(ctx, transfer.AssetID, transfer.TraceID)
+	if err != nil {
+		return 0, fmt.Errorf("list outputs: %w", err)
+	}
+
+	if len(outputs) == 0 {
+		return 0, errors.New("transfer has no assigned outputs")
+	}
+
+	// the raw transaction is expired after submitted too
+	for _, output := range outputs {
+		if output.State == mixin.UTXOStateSpent {
+			return 0, fmt.Errorf("output %s is spent, the transfer is on chain already", output.TraceID)
+		}
+	}
+
+	transfer.Handled = false
+	if err := wallets.UpdateTransfer(ctx, transfer); err != nil {
+		return 0, fmt.Errorf("update transfer: %w", err)
+	}
+
+	return len(outputs), nil
+}
+
+// findSignedTransaction the pending raw transaction of the transfer, or the transaction
+// signed so far recorded by the assigned outputs
+func findSignedTransaction(ctx context.Context, wallets core.WalletStore, traceID string, outputs []*core.Output) (*core.RawTransaction, string, error) {
+	raw, err := wallets.FindRawTransaction(ctx, traceID)
+	if err == nil {
+		return raw, raw.Data, nil
+	} else if !db.IsErrorNotFound(err) {
+		return nil, "", err
+	}
+
+	for _, output := range outputs {
+		if output.UTXO != nil && output.UTXO.SignedTx != "" {
+			return nil, output.UTXO.SignedTx, nil
+		}
+	}
+
+	return nil, "", nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"compound/core"
+	"compound/store/memory"
+	"context"
+	"testing"
+
+	"github.com/fox-one/mixin-sdk-go"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransferStatus(t *testing.T) {
+	for _, status := range []core.TransferStatus{
+		core.TransferStatusPending,
+		core.TransferStatusAssigned,
+		core.TransferStatusHandled,
+	} {
+		parsed, err := parseTransferStatus(status.String())
+		require.Nil(t, err)
+		assert.Equal(t, status, parsed)
+	}
+
+	for _, s := range []string{"", "passed", "Pending", "unknown"} {
+		_, err := parseTransferStatus(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestRequeueTransfer(t *testing.T) {
+	ctx := context.Background()
+	wallets := memory.NewWalletStore()
+
+	newTransfer := func(assigned, handled, passed bool) *core.Transfer {
+		transfer := &core.Transfer{
+			TraceID: uuid.Must(uuid.NewV4()).String(),
+			AssetID: uuid.Must(uuid.NewV4()).String(),
+			Amount:  decimal.New(1, 0),
+		}
+		require.Nil(t, wallets.CreateTransfers(ctx, []*core.Transfer{transfer}))
+
+		if assigned {
+			output := &core.Output{TraceID: uuid.Must(uuid.NewV4()).String(), AssetID: transfer.AssetID, Amount: transfer.Amount}
+			require.Nil(t, wallets.Save(ctx, []*core.Output{output}, true))
+			outputs, err := wallets.ListUnspent(ctx, transfer.AssetID, 0)
+			require.Nil(t, err)
+			require.Nil(t, wallets.Assign(ctx, outputs, transfer))
+		}
+
+		transfer.Handled, transfer.Passed = types.BitBool(handled), types.BitBool(passed)
+		require.Nil(t, wallets.UpdateTransfer(ctx, transfer))
+		return transfer
+	}
+
+	// not found
+	_, err := requeueTransfer(ctx, wallets, uuid.Must(uuid.NewV4()).String())
+	assert.NotNil(t, err)
+
+	// only the handled transfers
+	for _, transfer := range []*core.Transfer{
+		newTransfer(false, false, false),
+		newTransfer(true, false, false),
+		newTransfer(true, true, true),
+	} {
+		_, err := requeueTransfer(ctx, wallets, transfer.TraceID)
+		assert.NotNil(t, err, transferStatus(transfer))
+	}
+
+	// the raw transaction is pending
+	pending := newTransfer(true, true, false)
+	require.Nil(t, wallets.CreateRawTransaction(ctx, &core.RawTransaction{TraceID: pending.TraceID, Data: "raw"}))
+	_, err = requeueTransfer(ctx, wallets, pending.TraceID)
+	assert.NotNil(t, err)
+
+	// no outputs assigned
+	empty := newTransfer(false, true, false)
+	_, err = requeueTransfer(ctx, wallets, empty.TraceID)
+	assert.NotNil(t, err)
+
+	// submitted, the outputs are spent
+	submitted := newTransfer(true, true, false)
+	outputs, err := wallets.ListSpentBy(ctx, submitted.AssetID, submitted.TraceID)
+	require.Nil(t, err)
+	outputs[0].State = mixin.UTXOStateSpent
+	require.Nil(t, wallets.Save(ctx, outputs, false))
+	_, err = requeueTransfer(ctx, wallets, submitted.TraceID)
+	assert.NotNil(t, err)
+
+	expired := newTransfer(true, true, false)
+	n, err := requeueTransfer(ctx, wallets, expired.TraceID)
+	require.Nil(t, err)
+	assert.Equal(t, 1, n)
+
+	transfer, err := wallets.FindTransfer(ctx, expired.TraceID)
+	require.Nil(t, err)
+	assert.Equal(t, core.TransferStatusAssigned.String(), transferStatus(transfer))
+
+	// handled once only
+	_, err = requeueTransfer(ctx, wallets, expired.TraceID)
+	assert.NotNil(t, err)
+}
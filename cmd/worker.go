@@ -1,20 +1,28 @@
 package cmd
 
 import (
+	"compound/handler/admin"
 	"compound/handler/hc"
+	"compound/metric"
 	"compound/pkg/sysversion"
 	"compound/worker"
 	"compound/worker/assigner"
 	"compound/worker/cashier"
 	"compound/worker/datadog"
+	"compound/worker/liquidator"
 	"compound/worker/messenger"
+	"compound/worker/notifier"
 	"compound/worker/payee"
+	"compound/worker/reconciler"
 	"compound/worker/spentsync"
 	"compound/worker/syncer"
+	"compound/worker/timelock"
 	"compound/worker/txsender"
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/fox-one/pkg/logger"
 	"github.com/go-chi/chi"
@@ -49,6 +57,13 @@ var workerCmd = &cobra.Command{
 		userStore := provideUserStore(db)
 		transactionStore := provideTransactionStore(db)
 		oracleSignerStore := provideOracleSignerStore(db)
+		candidateStore := provideLiquidationCandidateStore(db)
+		reserveStore := provideReserveStore(db)
+		priceTickStore := providePriceTickStore(db)
+		accumulatorStore := providePriceAccumulatorStore(db)
+		subscriberStore := provideSubscriberStore(db)
+		reconciliationStore := provideReconciliationStore(db)
+		divergenceStore := provideDivergenceStore(db)
 
 		walletService := provideWalletService(dapp.Client)
 		accountService := provideAccountService(marketStore, supplyStore, borrowStore)
@@ -63,7 +78,11 @@ var workerCmd = &cobra.Command{
 
 		ctx = sysversion.WithContext(ctx, sysver)
 
-		//hc api
+		metric.Register(metric.NewCollector(walletStore, propertyStore, marketStore, func(ctx context.Context) (int64, error) {
+			return payee.ReadCheckpoint(ctx, propertyStore)
+		}))
+
+		//hc, metrics & admin api
 		{
 			mux := chi.NewMux()
 			mux.Use(middleware.Recoverer)
@@ -73,6 +92,8 @@ var workerCmd = &cobra.Command{
 			mux.Use(middleware.Logger)
 
 			mux.Mount("/hc", hc.Handle(rootCmd.Version))
+			mux.Handle("/metrics", metric.Handler())
+			mux.Mount("/admin", admin.Handle(divergenceStore))
 
 			port, err := cmd.Flags().GetInt("port")
 			if err != nil {
@@ -85,12 +106,15 @@ var workerCmd = &cobra.Command{
 
 		workers := []worker.Worker{
 			messenger.New(messageStore, messageService),
-			cashier.New(walletStore, walletService, system, provideCashierConfig()),
+			cashier.New(walletStore, walletService, divergenceStore, system, provideCashierConfig()),
 			assigner.New(walletStore, system),
 			txsender.New(walletStore),
+			timelock.New(proposalStore, propertyStore, walletService, system),
 			spentsync.New(walletStore, transactionStore),
 			syncer.New(walletStore, walletService, propertyStore),
-			datadog.New(walletStore, propertyStore, messageService, provideDataDogConfig(cfg)),
+			datadog.New(walletStore, propertyStore, divergenceStore, messageService, provideDataDogConfig(cfg)),
+			liquidator.New(marketStore, supplyStore, borrowStore, candidateStore, accountService, time.Minute),
+			reconciler.New(walletStore, propertyStore, marketStore, supplyStore, reconciliationStore, messageService, provideReconcilerConfig(cfg)),
 			payee.NewPayee(
 				system,
 				dapp,
@@ -103,12 +127,30 @@ var workerCmd = &cobra.Command{
 				proposalStore,
 				transactionStore,
 				oracleSignerStore,
+				reserveStore,
+				priceTickStore,
+				accumulatorStore,
+				subscriberStore,
 				walletService,
 				proposalService,
 				accountService,
 			),
 		}
 
+		if cfg.Notifier.Enabled {
+			workers = append(workers, notifier.New(
+				system,
+				dapp.Client,
+				propertyStore,
+				transactionStore,
+				subscriberStore,
+				marketStore,
+				messageStore,
+				accountService,
+				provideNotifierConfig(),
+			))
+		}
+
 		logrus.Infof("rings worker (version: %s, sysver: %d) launched", rootCmd.Version, sysver)
 
 		wg := sync.WaitGroup{}
@@ -14,6 +14,8 @@ type (
 		Dapp    Dapp      `json:"dapp"`
 		Group   Group     `json:"group"`
 		DataDog DataDog   `json:"data_dog"`
+		// Notifier the user notifications, enable it on one node only
+		Notifier Notifier `json:"notifier"`
 	}
 
 	// Group group config
@@ -47,6 +49,10 @@ type (
 	DataDog struct {
 		ConversationID string `json:"conversation_id,omitempty"`
 	}
+
+	Notifier struct {
+		Enabled bool `json:"enabled,omitempty"`
+	}
 )
 
 func defaultVote(cfg *Config) {
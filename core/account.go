@@ -6,9 +6,56 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+type (
+	// AccountSupply the collateral position of an account in one market
+	AccountSupply struct {
+		AssetID       string          `json:"asset_id"`
+		CTokenAssetID string          `json:"ctoken_asset_id"`
+		Symbol        string          `json:"symbol"`
+		Collaterals   decimal.Decimal `json:"collaterals"`
+		// Underlying collaterals * exchange_rate
+		Underlying decimal.Decimal `json:"underlying"`
+		Price      decimal.Decimal `json:"price"`
+		// Value underlying * price
+		Value decimal.Decimal `json:"value"`
+		// CollateralValue value * collateral_factor
+		CollateralValue decimal.Decimal `json:"collateral_value"`
+		// LiquidationPrice the price at which the account liquidity falls to zero,
+		// assuming the prices of other markets stay the same
+		LiquidationPrice decimal.Decimal `json:"liquidation_price"`
+	}
+
+	// AccountBorrow the borrow position of an account in one market
+	AccountBorrow struct {
+		AssetID       string          `json:"asset_id"`
+		Symbol        string          `json:"symbol"`
+		Principal     decimal.Decimal `json:"principal"`
+		InterestIndex decimal.Decimal `json:"interest_index"`
+		// Balance the current borrow balance with interest
+		Balance decimal.Decimal `json:"balance"`
+		Price   decimal.Decimal `json:"price"`
+		// Value balance * price
+		Value decimal.Decimal `json:"value"`
+	}
+
+	// AccountSnapshot the positions and liquidity of an account
+	AccountSnapshot struct {
+		UserID          string           `json:"user_id"`
+		Supplies        []*AccountSupply `json:"supplies"`
+		Borrows         []*AccountBorrow `json:"borrows"`
+		CollateralValue decimal.Decimal  `json:"collateral_value"`
+		BorrowValue     decimal.Decimal  `json:"borrow_value"`
+		Liquidity       decimal.Decimal  `json:"liquidity"`
+		// HealthFactor collateral_value / borrow_value, zero if no borrows
+		HealthFactor decimal.Decimal `json:"health_factor"`
+	}
+)
+
 // IAccountService account service interface
 type IAccountService interface {
 	// calculate account liquidity
 	CalculateAccountLiquidity(ctx context.Context, userID string, newMarkets ...*Market) (decimal.Decimal, error)
 	SeizeTokenAllowed(ctx context.Context, supply *Supply, borrow *Borrow, liquidity decimal.Decimal) bool
+	// Snapshot positions, liquidity and health factor of the account
+	Snapshot(ctx context.Context, userID string) (*AccountSnapshot, error)
 }
@@ -2,6 +2,7 @@ package core
 
 import (
 	"compound/pkg/mtg/types"
+	"strings"
 
 	"github.com/fox-one/msgpack"
 )
@@ -88,6 +89,32 @@ const (
 	ActionTypeProposalSetProperty
 	ActionTypeProposalMake
 	ActionTypeProposalShout
+	// ActionTypeProposalSetInterestRateModel proposal to set the interest rate model of market
+	ActionTypeProposalSetInterestRateModel
+	// ActionTypeQuickRepayRedeem repay -> unpledge -> redeem
+	ActionTypeQuickRepayRedeem
+	// ActionTypeQuickRepayRedeemTransfer quick repay redeem transfer
+	ActionTypeQuickRepayRedeemTransfer
+	// ActionTypeProposalSetIsolation proposal to set the collateral mode of market
+	ActionTypeProposalSetIsolation
+	// ActionTypeProposalSetPriceGuard proposal to set the price aggregation of market
+	ActionTypeProposalSetPriceGuard
+	// ActionTypeProposalSetMaxPriceAge proposal to set the max price age of market
+	ActionTypeProposalSetMaxPriceAge
+	// ActionTypeProposalSetTWAP proposal to set the collateral valuation of market
+	ActionTypeProposalSetTWAP
+	// ActionTypeProposalRotateOracleSigner proposal to rotate the public key of oracle signer
+	ActionTypeProposalRotateOracleSigner
+	// ActionTypeProposalCancel proposal to cancel the queued proposal
+	ActionTypeProposalCancel
+	// ActionTypeProposalExecute execute the queued proposal after the timelock delay
+	ActionTypeProposalExecute
+	// ActionTypeProposalReject vote against the proposal
+	ActionTypeProposalReject
+	// ActionTypeSubscribe opt in the notifications with the health factor to warn below
+	ActionTypeSubscribe
+	// ActionTypeUnsubscribe opt out the notifications
+	ActionTypeUnsubscribe
 )
 
 func (a ActionType) IsProposalAction() bool {
@@ -104,7 +131,27 @@ func (a ActionType) IsProposalAction() bool {
 		a == ActionTypeProposalOpenMarket ||
 		a == ActionTypeProposalAddOracleSigner ||
 		a == ActionTypeProposalRemoveOracleSigner ||
-		a == ActionTypeProposalSetProperty
+		a == ActionTypeProposalSetProperty ||
+		a == ActionTypeProposalSetInterestRateModel ||
+		a == ActionTypeProposalSetIsolation ||
+		a == ActionTypeProposalSetPriceGuard ||
+		a == ActionTypeProposalSetMaxPriceAge ||
+		a == ActionTypeProposalSetTWAP ||
+		a == ActionTypeProposalRotateOracleSigner ||
+		a == ActionTypeProposalCancel ||
+		a == ActionTypeProposalExecute ||
+		a == ActionTypeProposalReject
+}
+
+// ParseActionType parse the action name, case insensitive
+func ParseActionType(name string) ActionType {
+	for a := ActionTypeSupply; a <= ActionTypeUnsubscribe; a++ {
+		if strings.EqualFold(a.String(), name) {
+			return a
+		}
+	}
+
+	return ActionTypeDefault
 }
 
 func (i ActionType) MarshalBinary() (data []byte, err error) {
@@ -45,23 +45,36 @@ func _() {
 	_ = x[ActionTypeProposalSetProperty-38]
 	_ = x[ActionTypeProposalMake-39]
 	_ = x[ActionTypeProposalShout-40]
+	_ = x[ActionTypeProposalSetInterestRateModel-41]
+	_ = x[ActionTypeQuickRepayRedeem-42]
+	_ = x[ActionTypeQuickRepayRedeemTransfer-43]
+	_ = x[ActionTypeProposalSetIsolation-44]
+	_ = x[ActionTypeProposalSetPriceGuard-45]
+	_ = x[ActionTypeProposalSetMaxPriceAge-46]
+	_ = x[ActionTypeProposalSetTWAP-47]
+	_ = x[ActionTypeProposalRotateOracleSigner-48]
+	_ = x[ActionTypeProposalCancel-49]
+	_ = x[ActionTypeProposalExecute-50]
+	_ = x[ActionTypeProposalReject-51]
+	_ = x[ActionTypeSubscribe-52]
+	_ = x[ActionTypeUnsubscribe-53]
 }
 
 const (
 	_ActionType_name_0 = "DefaultSupplyBorrowRedeemRepayMintPledgeUnpledgeLiquidateRedeemTransferUnpledgeTransferBorrowTransferLiquidateTransferRefundTransferRepayRefundTransferLiquidateRefundTransferProposalUpsertMarketProposalUpdateMarketProposalWithdrawReservesProposalProvidePriceProposalVoteProposalInjectCTokenForMintProposalUpdateMarketAdvanceProposalTransferProposalCloseMarketProposalOpenMarket"
-	_ActionType_name_1 = "UpdateMarketQuickPledgeQuickBorrowQuickBorrowTransferQuickRedeemQuickRedeemTransferProposalAddOracleSignerProposalRemoveOracleSignerProposalSetPropertyProposalMakeProposalShout"
+	_ActionType_name_1 = "UpdateMarketQuickPledgeQuickBorrowQuickBorrowTransferQuickRedeemQuickRedeemTransferProposalAddOracleSignerProposalRemoveOracleSignerProposalSetPropertyProposalMakeProposalShoutProposalSetInterestRateModelQuickRepayRedeemQuickRepayRedeemTransferProposalSetIsolationProposalSetPriceGuardProposalSetMaxPriceAgeProposalSetTWAPProposalRotateOracleSignerProposalCancelProposalExecuteProposalRejectSubscribeUnsubscribe"
 )
 
 var (
 	_ActionType_index_0 = [...]uint16{0, 7, 13, 19, 25, 30, 34, 40, 48, 57, 71, 87, 101, 118, 132, 151, 174, 194, 214, 238, 258, 270, 297, 324, 340, 359, 377}
-	_ActionType_index_1 = [...]uint8{0, 12, 23, 34, 53, 64, 83, 106, 132, 151, 163, 176}
+	_ActionType_index_1 = [...]uint16{0, 12, 23, 34, 53, 64, 83, 106, 132, 151, 163, 176, 204, 220, 244, 264, 285, 307, 322, 348, 362, 377, 391, 400, 411}
 )
 
 func (i ActionType) String() string {
 	switch {
 	case 0 <= i && i <= 25:
 		return _ActionType_name_0[_ActionType_index_0[i]:_ActionType_index_0[i+1]]
-	case 30 <= i && i <= 40:
+	case 30 <= i && i <= 53:
 		i -= 30
 		return _ActionType_name_1[_ActionType_index_1[i]:_ActionType_index_1[i+1]]
 	default:
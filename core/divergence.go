@@ -0,0 +1,65 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DivergenceStageMultisig the multisig request signed by the other members disagrees
+	DivergenceStageMultisig = "multisig"
+	// DivergenceStageTransaction the transaction spent the outputs disagrees
+	DivergenceStageTransaction = "transaction"
+)
+
+type (
+	// Divergence the transaction signed by the other members disagrees with the local transfer,
+	// the members have different states and it needs a human to resolve
+	Divergence struct {
+		ID        int64     `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+		// TraceID the trace id of the local transfer
+		TraceID string `sql:"size:36" json:"trace_id"`
+		AssetID string `sql:"size:36" json:"asset_id"`
+		Stage   string `sql:"size:16" json:"stage"`
+		Reason  string `sql:"size:255" json:"reason"`
+		// Signers the members signed the observed transaction
+		Signers           pq.StringArray  `sql:"type:varchar(1024)" json:"signers"`
+		ExpectedMemo      string          `sql:"size:200" json:"expected_memo"`
+		ObservedMemo      string          `sql:"size:200" json:"observed_memo"`
+		ExpectedAmount    decimal.Decimal `sql:"type:decimal(64,8)" json:"expected_amount"`
+		ObservedAmount    decimal.Decimal `sql:"type:decimal(64,8)" json:"observed_amount"`
+		ExpectedReceivers pq.StringArray  `sql:"type:varchar(1024)" json:"expected_receivers"`
+		// ObservedReceivers the ghost keys of the first output in hex at the transaction stage,
+		// they can't be mapped back to the user ids
+		ObservedReceivers pq.StringArray `sql:"type:varchar(1024)" json:"observed_receivers"`
+		// Transfer the local transfer
+		Transfer types.JSONText `sql:"type:TEXT" json:"transfer"`
+		Resolved bool           `json:"resolved"`
+	}
+
+	// DivergenceError the error returned by the wallet service when a divergence is detected
+	DivergenceError struct {
+		Divergence *Divergence
+	}
+
+	// DivergenceStore divergence store interface
+	DivergenceStore interface {
+		// Save create the divergence, or update the one of the same transfer and mark it unresolved
+		Save(ctx context.Context, divergence *Divergence) error
+		Find(ctx context.Context, traceID string) (*Divergence, error)
+		// List list the divergences newest first, the ones before the cursor id if not zero
+		List(ctx context.Context, cursor int64, limit int) ([]*Divergence, error)
+		ListUnresolved(ctx context.Context) ([]*Divergence, error)
+		Resolve(ctx context.Context, traceID string) error
+	}
+)
+
+func (e *DivergenceError) Error() string {
+	return e.Divergence.Stage + " divergence: " + e.Divergence.Reason
+}
@@ -37,6 +37,12 @@ const (
 	ErrPledgeNotAllowed ErrorCode = 100110
 	// ErrMarketClosed market closed
 	ErrMarketClosed ErrorCode = 100111
+	// ErrIsolationNotAllowed isolated collateral not allowed to back the borrow
+	ErrIsolationNotAllowed ErrorCode = 100112
+	// ErrSupplyCapExceeded supply cap exceeded
+	ErrSupplyCapExceeded ErrorCode = 100113
+	// ErrPriceStale the price of the dependent market is stale
+	ErrPriceStale ErrorCode = 100114
 )
 
 func (e ErrorCode) String() string {
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// LiquidationRepay the max amount of a borrow a liquidator could repay
+	LiquidationRepay struct {
+		AssetID  string          `json:"asset_id"`
+		Symbol   string          `json:"symbol"`
+		Balance  decimal.Decimal `json:"balance"`
+		Price    decimal.Decimal `json:"price"`
+		MaxRepay decimal.Decimal `json:"max_repay"`
+	}
+
+	// LiquidationSeize the max ctokens of a collateral a liquidator could seize
+	LiquidationSeize struct {
+		AssetID       string          `json:"asset_id"`
+		CTokenAssetID string          `json:"ctoken_asset_id"`
+		Symbol        string          `json:"symbol"`
+		Collaterals   decimal.Decimal `json:"collaterals"`
+		// SeizedPrice price * (1 - liquidation_incentive)
+		SeizedPrice decimal.Decimal `json:"seized_price"`
+		MaxSeize    decimal.Decimal `json:"max_seize"`
+	}
+
+	// LiquidationRepays repay list
+	LiquidationRepays []LiquidationRepay
+
+	// LiquidationSeizes seize list
+	LiquidationSeizes []LiquidationSeize
+
+	// LiquidationCandidate account with shortfall found by the liquidator worker
+	LiquidationCandidate struct {
+		ID        int64             `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"-"`
+		UserID    string            `sql:"size:36;unique_index:liquidation_candidate_user_idx" json:"user_id"`
+		Shortfall decimal.Decimal   `sql:"type:decimal(32,16)" json:"shortfall"`
+		Repays    LiquidationRepays `sql:"type:TEXT" json:"repays"`
+		Seizes    LiquidationSeizes `sql:"type:TEXT" json:"seizes"`
+		ScannedAt time.Time         `json:"scanned_at"`
+		CreatedAt time.Time         `sql:"default:CURRENT_TIMESTAMP" json:"created_at"`
+		UpdatedAt time.Time         `sql:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	}
+
+	// LiquidationCandidateStore liquidation candidate store interface
+	LiquidationCandidateStore interface {
+		// Save upsert the candidates by user, and delete the ones scanned before the scan time,
+		// the accounts no longer short or skipped by the scan
+		Save(ctx context.Context, candidates []*LiquidationCandidate, scannedAt time.Time) error
+		// List candidates ordered by shortfall desc
+		List(ctx context.Context) ([]*LiquidationCandidate, error)
+	}
+)
+
+// Scan implements the sql.Scanner interface for database deserialization.
+func (r *LiquidationRepays) Scan(value interface{}) error {
+	return scanJSON(value, r)
+}
+
+// Value implements the driver.Valuer interface for database serialization.
+func (r LiquidationRepays) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface for database deserialization.
+func (s *LiquidationSeizes) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
+
+// Value implements the driver.Valuer interface for database serialization.
+func (s LiquidationSeizes) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func scanJSON(value, v interface{}) error {
+	var d []byte
+	switch s := value.(type) {
+	case string:
+		d = []byte(s)
+	case []byte:
+		d = s
+	}
+
+	if len(d) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(d, v)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/shopspring/decimal"
 )
 
@@ -16,10 +17,46 @@ const (
 	MarketStatusClose
 )
 
+const (
+	_ CollateralMode = iota
+	// CollateralModeCross the collateral backs all the borrows of the account
+	CollateralModeCross
+	// CollateralModeIsolated the collateral only backs the allowlisted borrows, up to the debt ceiling
+	CollateralModeIsolated
+)
+
+const (
+	_ ValuationMode = iota
+	// ValuationModeSpot the collateral is valued by the spot price
+	ValuationModeSpot
+	// ValuationModeTWAP the collateral is valued by the time-weighted average price
+	ValuationModeTWAP
+)
+
+const (
+	// InterestRateModelDefault the jump rate model used before the model can be chosen
+	InterestRateModelDefault InterestRateModelType = iota
+	// InterestRateModelJumpRate borrow rate jumps after the utilization rate hits the kink
+	InterestRateModelJumpRate
+	// InterestRateModelWhitePaper borrow rate grows linearly with the utilization rate
+	InterestRateModelWhitePaper
+	// InterestRateModelStableCoin jump rate model with a floor borrow rate
+	InterestRateModelStableCoin
+)
+
 type (
 	// MarketStatus market status
 	MarketStatus int
 
+	// InterestRateModelType interest rate model of market
+	InterestRateModelType int
+
+	// CollateralMode collateral mode of market
+	CollateralMode int
+
+	// ValuationMode the price to value the collateral of market
+	ValuationMode int
+
 	// Market market info
 	Market struct {
 		ID            uint64          `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"id"`
@@ -29,6 +66,8 @@ type (
 		TotalCash     decimal.Decimal `sql:"type:decimal(32,16)" json:"total_cash"`
 		TotalBorrows  decimal.Decimal `sql:"type:decimal(32,16)" json:"total_borrows"`
 		MaxPledge     decimal.Decimal `sql:"type:decimal(32,16)" json:"max_pledge"`
+		// 最大供应量, 0 为不限制
+		SupplyCap decimal.Decimal `sql:"type:decimal(32,16);default:0" json:"supply_cap"`
 		// 保留金
 		Reserves decimal.Decimal `sql:"type:decimal(32,16)" json:"reserves"`
 		// CToken 累计铸造出来的币的数量
@@ -53,6 +92,18 @@ type (
 		JumpMultiplier decimal.Decimal `sql:"type:decimal(32,16)" json:"jump_multiplier"`
 		// Kink
 		Kink decimal.Decimal `sql:"type:decimal(32,16)" json:"kink"`
+		// 利率模型
+		InterestRateModel InterestRateModelType `sql:"default:0" json:"interest_rate_model"`
+		// The min borrow rate of the stable coin model, per year
+		FloorRate decimal.Decimal `sql:"type:decimal(32,16);default:0" json:"floor_rate"`
+		// 抵押模式, cross or isolated
+		CollateralMode CollateralMode `sql:"default:1" json:"collateral_mode"`
+		// The borrow assets the isolated collateral can back
+		IsolatedBorrowAssets pq.StringArray `sql:"type:varchar(1024)" json:"isolated_borrow_assets,omitempty"`
+		// The max borrow value backed by the isolated collateral
+		DebtCeiling decimal.Decimal `sql:"type:decimal(32,16);default:0" json:"debt_ceiling"`
+		// The borrow value backed by the isolated collateral, kept by the payee at the prices when changed
+		IsolatedDebt decimal.Decimal `sql:"type:decimal(32,16);default:0" json:"isolated_debt"`
 		//当前区块高度
 		BlockNumber        int64           `json:"block_number"`
 		UtilizationRate    decimal.Decimal `sql:"type:decimal(32,16)" json:"utilization_rate"`
@@ -62,11 +113,25 @@ type (
 		Price              decimal.Decimal `sql:"type:decimal(32,16)" json:"price"`
 		PriceThreshold     int             `json:"price_threshold"`
 		PriceUpdatedAt     time.Time       `json:"price_updated_at"`
-		BorrowIndex        decimal.Decimal `sql:"type:decimal(28,16)" json:"borrow_index"`
-		Version            int64           `sql:"default:0" json:"version"`
-		Status             MarketStatus    `sql:"default:1" json:"status"`
-		CreatedAt          time.Time       `sql:"default:CURRENT_TIMESTAMP" json:"created_at"`
-		UpdatedAt          time.Time       `sql:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+		// 价格中位数的样本数量, 0 或 1 为不聚合
+		PriceSamples int `sql:"default:0" json:"price_samples"`
+		// 价格样本的时间窗口 (秒), 0 为不限制
+		PriceWindow int64 `sql:"default:0" json:"price_window"`
+		// 价格相对中位数的最大偏离比例, 0 为不限制
+		MaxPriceDeviation decimal.Decimal `sql:"type:decimal(32,16);default:0" json:"max_price_deviation"`
+		// 价格的最长有效时间 (秒), 0 为不限制
+		MaxPriceAge int64 `sql:"default:0" json:"max_price_age"`
+		// 抵押品估值价格, spot or twap
+		ValuationMode ValuationMode `sql:"default:1" json:"valuation_mode"`
+		// 时间加权平均价格的时间窗口 (秒)
+		TWAPWindow int64 `sql:"default:0" json:"twap_window"`
+		// 最近一次价格更新时的时间加权平均价格
+		TWAPPrice   decimal.Decimal `sql:"type:decimal(32,16);default:0" json:"twap_price"`
+		BorrowIndex decimal.Decimal `sql:"type:decimal(28,16)" json:"borrow_index"`
+		Version     int64           `sql:"default:0" json:"version"`
+		Status      MarketStatus    `sql:"default:1" json:"status"`
+		CreatedAt   time.Time       `sql:"default:CURRENT_TIMESTAMP" json:"created_at"`
+		UpdatedAt   time.Time       `sql:"default:CURRENT_TIMESTAMP" json:"updated_at"`
 	}
 
 	// IMarketStore asset store interface
@@ -87,6 +152,60 @@ func (s MarketStatus) IsValid() bool {
 		s == MarketStatusOpen
 }
 
+// IsValid is valid interest rate model
+func (t InterestRateModelType) IsValid() bool {
+	return t == InterestRateModelJumpRate ||
+		t == InterestRateModelWhitePaper ||
+		t == InterestRateModelStableCoin
+}
+
+func (t InterestRateModelType) String() string {
+	switch t {
+	case InterestRateModelDefault, InterestRateModelJumpRate:
+		return "jump-rate"
+	case InterestRateModelWhitePaper:
+		return "whitepaper"
+	case InterestRateModelStableCoin:
+		return "stable-coin"
+	default:
+		return "unknown"
+	}
+}
+
+// IsValid is valid collateral mode
+func (c CollateralMode) IsValid() bool {
+	return c == CollateralModeCross ||
+		c == CollateralModeIsolated
+}
+
+func (c CollateralMode) String() string {
+	switch c {
+	case CollateralModeCross:
+		return "cross"
+	case CollateralModeIsolated:
+		return "isolated"
+	default:
+		return "unknown"
+	}
+}
+
+// IsValid is valid valuation mode
+func (v ValuationMode) IsValid() bool {
+	return v == ValuationModeSpot ||
+		v == ValuationModeTWAP
+}
+
+func (v ValuationMode) String() string {
+	switch v {
+	case ValuationModeSpot:
+		return "spot"
+	case ValuationModeTWAP:
+		return "twap"
+	default:
+		return "unknown"
+	}
+}
+
 func (m Market) Format() []byte {
 	bytes, err := json.Marshal(m)
 	if err != nil {
@@ -100,6 +219,34 @@ func (m Market) IsMarketClosed() bool {
 	return m.Status == MarketStatusClose
 }
 
+// IsIsolated the collateral of market only backs the allowlisted borrows
+func (m Market) IsIsolated() bool {
+	return m.CollateralMode == CollateralModeIsolated
+}
+
+// IsolatedBorrowAllowed the isolated collateral of market can back the borrow asset
+func (m Market) IsolatedBorrowAllowed(assetID string) bool {
+	for _, id := range m.IsolatedBorrowAssets {
+		if id == assetID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SupplyAllowed check the total supplies after supply won't exceed the supply cap
+//
+//	total_supplies = total_cash + total_borrows - reserves
+func (m Market) SupplyAllowed(amount decimal.Decimal) bool {
+	if !m.SupplyCap.IsPositive() {
+		return true
+	}
+
+	supplies := m.TotalCash.Add(m.TotalBorrows).Sub(m.Reserves)
+	return supplies.Add(amount).LessThanOrEqual(m.SupplyCap)
+}
+
 // BorrowAllowed check borrow capacity, check account liquidity
 func (m Market) BorrowAllowed(borrowAmount decimal.Decimal) bool {
 	if !borrowAmount.IsPositive() {
@@ -132,3 +279,23 @@ func (m Market) CurExchangeRate() decimal.Decimal {
 		return m.InitExchangeRate
 	}
 }
+
+// PriceGuardEnabled the oracle prices of market are aggregated or guarded by the deviation
+func (m Market) PriceGuardEnabled() bool {
+	return m.PriceSamples > 1 || m.MaxPriceDeviation.IsPositive()
+}
+
+// PriceStale the price of market is older than the max price age at the time
+func (m Market) PriceStale(at time.Time) bool {
+	return m.MaxPriceAge > 0 && at.Sub(m.PriceUpdatedAt) > time.Duration(m.MaxPriceAge)*time.Second
+}
+
+// CollateralPrice the price to value the collateral of market,
+// fallback to the spot price if the twap is not available yet
+func (m Market) CollateralPrice() decimal.Decimal {
+	if m.ValuationMode == ValuationModeTWAP && m.TWAPPrice.IsPositive() {
+		return m.TWAPPrice
+	}
+
+	return m.Price
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// MarketSnapshot the market state after every update
+	MarketSnapshot struct {
+		ID                 uint64          `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"id"`
+		AssetID            string          `sql:"size:36;unique_index:market_snapshot_idx" json:"asset_id"`
+		Version            int64           `sql:"unique_index:market_snapshot_idx" json:"version"`
+		BlockNumber        int64           `json:"block_number"`
+		TotalCash          decimal.Decimal `sql:"type:decimal(32,16)" json:"total_cash"`
+		TotalBorrows       decimal.Decimal `sql:"type:decimal(32,16)" json:"total_borrows"`
+		Reserves           decimal.Decimal `sql:"type:decimal(32,16)" json:"reserves"`
+		CTokens            decimal.Decimal `sql:"type:decimal(32,16)" json:"ctokens"`
+		UtilizationRate    decimal.Decimal `sql:"type:decimal(32,16)" json:"utilization_rate"`
+		ExchangeRate       decimal.Decimal `sql:"type:decimal(32,16)" json:"exchange_rate"`
+		SupplyRatePerBlock decimal.Decimal `sql:"type:decimal(32,16)" json:"supply_rate_per_block"`
+		BorrowRatePerBlock decimal.Decimal `sql:"type:decimal(32,16)" json:"borrow_rate_per_block"`
+		Price              decimal.Decimal `sql:"type:decimal(32,16)" json:"price"`
+		BorrowIndex        decimal.Decimal `sql:"type:decimal(28,16)" json:"borrow_index"`
+		// the time of the block, not the time of the update
+		CreatedAt time.Time `sql:"index" json:"created_at"`
+	}
+
+	// MarketSnapshotStore market snapshot store interface,
+	// the snapshots are written by IMarketStore.Update
+	MarketSnapshotStore interface {
+		// List snapshots of market in [from, to) ordered by version
+		List(ctx context.Context, assetID string, from, to time.Time) ([]*MarketSnapshot, error)
+	}
+)
+
+// NewMarketSnapshot snapshot the market
+func NewMarketSnapshot(market *Market, createdAt time.Time) *MarketSnapshot {
+	return &MarketSnapshot{
+		AssetID:            market.AssetID,
+		Version:            market.Version,
+		BlockNumber:        market.BlockNumber,
+		TotalCash:          market.TotalCash,
+		TotalBorrows:       market.TotalBorrows,
+		Reserves:           market.Reserves,
+		CTokens:            market.CTokens,
+		UtilizationRate:    market.UtilizationRate,
+		ExchangeRate:       market.ExchangeRate,
+		SupplyRatePerBlock: market.SupplyRatePerBlock,
+		BorrowRatePerBlock: market.BorrowRatePerBlock,
+		Price:              market.Price,
+		BorrowIndex:        market.BorrowIndex,
+		CreatedAt:          createdAt,
+	}
+}
@@ -5,16 +5,51 @@ import (
 	"time"
 )
 
+// MaxOracleSigners the signer index is the bit of the cosi signature mask, 1 ~ 63
+const MaxOracleSigners = 63
+
 type OracleSigner struct {
-	ID        int64     `sql:"PRIMARY_KEY" json:"id,omitempty"`
-	UserID    string    `sql:"size:36;unique_index:idx_oracle_signers_user_id" json:"user_id,omitempty"`
-	PublicKey string    `sql:"size:256" json:"public_key,omitempty"`
-	CreatedAt time.Time `json:"created_at,omitempty"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	ID        int64  `sql:"PRIMARY_KEY" json:"id,omitempty"`
+	UserID    string `sql:"size:36;unique_index:idx_oracle_signers_user_id" json:"user_id,omitempty"`
+	PublicKey string `sql:"size:256" json:"public_key,omitempty"`
+	// SignerIndex the bit of the signer in the cosi signature mask, kept when the key is rotated
+	SignerIndex int64 `sql:"default:0" json:"index,omitempty"`
+	// Version the output id of the last price counted in the stats
+	Version int64 `sql:"default:0" json:"version,omitempty"`
+	// Signed the verified prices signed by the signer
+	Signed int64 `sql:"default:0" json:"signed"`
+	// Missed the verified prices not signed by the signer
+	Missed       int64     `sql:"default:0" json:"missed"`
+	LastSignedAt time.Time `json:"last_signed_at,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
 }
 
 type OracleSignerStore interface {
+	// Save add the signer with the smallest free index, or rotate the key of the signer keeping the index
 	Save(ctx context.Context, userID, publicKey string) error
 	Delete(ctx context.Context, userID string) error
+	// Reindex assign the indexes by the positions of the signers ordered by id, as they were before sysversion 6
+	Reindex(ctx context.Context) error
+	Find(ctx context.Context, userID string) (*OracleSigner, error)
+	// FindAll all the signers ordered by index
 	FindAll(ctx context.Context) ([]*OracleSigner, error)
+	// Participate count the price of the version signed by the signers of the indexes, the others missed it
+	Participate(ctx context.Context, version int64, indexes []int64, at time.Time) error
+}
+
+// FreeSignerIndex the smallest index not taken by the signers, 0 if all taken
+func FreeSignerIndex(signers []*OracleSigner) int64 {
+	taken := make(map[int64]bool, len(signers))
+	for _, s := range signers {
+		taken[s.SignerIndex] = true
+	}
+
+	for idx := int64(1); idx <= MaxOracleSigners; idx++ {
+		if !taken[idx] {
+			return idx
+		}
+	}
+
+	return 0
 }
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PriceTickStatus the status of the oracle price
+type PriceTickStatus int
+
+const (
+	_ PriceTickStatus = iota
+	// PriceTickStatusAccepted the price is taken into the median
+	PriceTickStatusAccepted
+	// PriceTickStatusRejected the price is rejected by the deviation guard
+	PriceTickStatusRejected
+)
+
+func (s PriceTickStatus) String() string {
+	switch s {
+	case PriceTickStatusAccepted:
+		return "accepted"
+	case PriceTickStatusRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	// PriceTick the verified price provided by the oracle
+	PriceTick struct {
+		ID      int64  `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"id"`
+		AssetID string `sql:"size:36;unique_index:price_tick_idx" json:"asset_id"`
+		// Version the output id of the price
+		Version int64           `sql:"unique_index:price_tick_idx;index:idx_price_ticks_version" json:"version"`
+		TraceID string          `sql:"size:36" json:"trace_id"`
+		Price   decimal.Decimal `sql:"type:decimal(32,16)" json:"price"`
+		// Median the median of the accepted prices in the window before this one
+		Median decimal.Decimal `sql:"type:decimal(32,16)" json:"median"`
+		Status PriceTickStatus `json:"status"`
+		// Reason why the price is rejected
+		Reason string `sql:"size:128" json:"reason,omitempty"`
+		// PricedAt the timestamp signed by the oracle
+		PricedAt  time.Time `json:"priced_at"`
+		CreatedAt time.Time `sql:"index:idx_price_ticks_created_at" json:"created_at"`
+	}
+
+	// PriceTickStore oracle price store interface
+	PriceTickStore interface {
+		Create(ctx context.Context, tick *PriceTick) error
+		// ListAccepted list the latest accepted prices of the asset before the version, newest first
+		ListAccepted(ctx context.Context, assetID string, before int64, limit int) ([]*PriceTick, error)
+		// List prices of the asset in [from, to) ordered by version, all status if status is 0
+		List(ctx context.Context, assetID string, status PriceTickStatus, from, to time.Time) ([]*PriceTick, error)
+		// ListFrom list the prices of all the assets with version > from ordered by version
+		ListFrom(ctx context.Context, from int64, limit int) ([]*PriceTick, error)
+	}
+)
@@ -10,14 +10,31 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const (
+	_ ProposalStatus = iota
+	// ProposalStatusPending voting
+	ProposalStatusPending
+	// ProposalStatusPassed passed and queued by the timelock
+	ProposalStatusPassed
+	// ProposalStatusRejected rejected by the votes or canceled by a cancel proposal
+	ProposalStatusRejected
+	// ProposalStatusExpired not passed before expired
+	ProposalStatusExpired
+	// ProposalStatusExecuted passed and executed
+	ProposalStatusExecuted
+)
+
 type (
+	// ProposalStatus proposal status
+	ProposalStatus int
+
 	// Proposal proposal info
 	Proposal struct {
 		ID        int64           `sql:"PRIMARY_KEY" json:"id,omitempty"`
 		CreatedAt time.Time       `json:"created_at,omitempty"`
 		UpdatedAt time.Time       `json:"updated_at,omitempty"`
 		PassedAt  sql.NullTime    `json:"passed_at,omitempty"`
-		Version   int64           `json:"version,omitempty"`
+		Version   int64           `sql:"index" json:"version,omitempty"`
 		TraceID   string          `sql:"size:36" json:"trace_id,omitempty"`
 		Creator   string          `sql:"size:36" json:"creator,omitempty"`
 		AssetID   string          `sql:"size:36" json:"asset_id,omitempty"`
@@ -25,6 +42,14 @@ type (
 		Action    ActionType      `json:"action,omitempty"`
 		Content   types.JSONText  `sql:"type:varchar(1024)" json:"content,omitempty"`
 		Votes     pq.StringArray  `sql:"type:varchar(1024)" json:"votes,omitempty"`
+		Status    ProposalStatus  `sql:"default:1;index" json:"status,omitempty"`
+		// Rejections the members voted against the proposal
+		Rejections pq.StringArray `sql:"type:varchar(1024)" json:"rejections,omitempty"`
+		// ExecutableAt the passed proposal is queued until the time if the action has a timelock delay
+		ExecutableAt sql.NullTime `sql:"index" json:"executable_at,omitempty"`
+		ExecutedAt   sql.NullTime `json:"executed_at,omitempty"`
+		// CanceledAt the queued proposal is canceled by a passed cancel proposal
+		CanceledAt sql.NullTime `json:"canceled_at,omitempty"`
 	}
 
 	ProposalItem struct {
@@ -44,6 +69,13 @@ type (
 		Find(ctx context.Context, trace string) (*Proposal, error)
 		Update(ctx context.Context, proposal *Proposal, version int64) error
 		List(ctx context.Context, fromID int64, limit int) ([]*Proposal, error)
+		// ListQueued list the queued proposals executable at the time, ordered by executable_at
+		ListQueued(ctx context.Context, at time.Time) ([]*Proposal, error)
+		// ListPending list the pending proposals created before the time
+		ListPending(ctx context.Context, before time.Time) ([]*Proposal, error)
+		ListByStatus(ctx context.Context, status ProposalStatus, fromID int64, limit int) ([]*Proposal, error)
+		// ListUpdated list the proposals updated by the outputs with id > from, ordered by version
+		ListUpdated(ctx context.Context, from int64, limit int) ([]*Proposal, error)
 	}
 
 	// ProposalService proposal service interface
@@ -52,5 +84,57 @@ type (
 		ProposalCreated(ctx context.Context, proposal *Proposal, by string, sysver int64) error
 		ProposalApproved(ctx context.Context, proposal *Proposal, by string, sysver int64) error
 		ProposalPassed(ctx context.Context, proposal *Proposal, sysver int64) error
+		ProposalRejected(ctx context.Context, proposal *Proposal, by string, sysver int64) error
+		ProposalExpired(ctx context.Context, proposal *Proposal, sysver int64) error
 	}
 )
+
+const (
+	// ProposalDelayKeyPrefix the prefix of the timelock delay properties
+	ProposalDelayKeyPrefix = "proposal_delay."
+	// ProposalTTLKey the property key of the duration the pending proposals expire after, a go duration like 72h,
+	// the proposals never expire if not set
+	ProposalTTLKey = "proposal_ttl"
+)
+
+// ProposalDelayKey the property key of the timelock delay of the proposal action, a go duration like 24h
+func ProposalDelayKey(action ActionType) string {
+	return ProposalDelayKeyPrefix + action.String()
+}
+
+// Queued passed but waiting for the timelock delay
+func (p *Proposal) Queued() bool {
+	return p.PassedAt.Valid && p.ExecutableAt.Valid && !p.ExecutedAt.Valid && !p.CanceledAt.Valid
+}
+
+func (s ProposalStatus) IsValid() bool {
+	return s >= ProposalStatusPending && s <= ProposalStatusExecuted
+}
+
+func (s ProposalStatus) String() string {
+	switch s {
+	case ProposalStatusPending:
+		return "pending"
+	case ProposalStatusPassed:
+		return "passed"
+	case ProposalStatusRejected:
+		return "rejected"
+	case ProposalStatusExpired:
+		return "expired"
+	case ProposalStatusExecuted:
+		return "executed"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseProposalStatus parse the status name
+func ParseProposalStatus(name string) ProposalStatus {
+	for s := ProposalStatusPending; s <= ProposalStatusExecuted; s++ {
+		if s.String() == name {
+			return s
+		}
+	}
+
+	return 0
+}
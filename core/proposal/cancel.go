@@ -0,0 +1,33 @@
+package proposal
+
+import (
+	"compound/pkg/mtg"
+
+	"github.com/gofrs/uuid"
+)
+
+// CancelReq cancel the queued proposal before it's executed
+type CancelReq struct {
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// MarshalBinary marshal req to binary
+func (w CancelReq) MarshalBinary() (data []byte, err error) {
+	trace, err := uuid.FromString(w.TraceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mtg.Encode(trace)
+}
+
+// UnmarshalBinary unmarshal bytes to cancel req
+func (w *CancelReq) UnmarshalBinary(data []byte) error {
+	var trace uuid.UUID
+	if _, err := mtg.Scan(data, &trace); err != nil {
+		return err
+	}
+
+	w.TraceID = trace.String()
+	return nil
+}
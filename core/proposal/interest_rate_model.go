@@ -0,0 +1,51 @@
+package proposal
+
+import (
+	"compound/core"
+	"compound/pkg/mtg"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// InterestRateModelReq set the interest rate model of market
+type InterestRateModelReq struct {
+	AssetID   string                     `json:"asset_id,omitempty"`
+	Model     core.InterestRateModelType `json:"model,omitempty"`
+	FloorRate decimal.Decimal            `json:"floor_rate,omitempty"`
+}
+
+// MarshalBinary marshal req to binary
+func (w InterestRateModelReq) MarshalBinary() (data []byte, err error) {
+	asset, err := uuid.FromString(w.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mtg.Encode(asset, w.Model, w.FloorRate)
+}
+
+// UnmarshalBinary unmarshal bytes to interest rate model req
+func (w *InterestRateModelReq) UnmarshalBinary(data []byte) error {
+	var (
+		asset     uuid.UUID
+		model     int
+		floorRate decimal.Decimal
+	)
+
+	if _, err := mtg.Scan(data, &asset, &model, &floorRate); err != nil {
+		return err
+	}
+
+	m := core.InterestRateModelType(model)
+	if !m.IsValid() {
+		return errors.New("invalid interest rate model")
+	}
+
+	w.AssetID = asset.String()
+	w.Model = m
+	w.FloorRate = floorRate
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package proposal
+
+import (
+	"compound/core"
+	"compound/pkg/mtg"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// IsolationReq set the collateral mode of market
+type IsolationReq struct {
+	AssetID      string              `json:"asset_id,omitempty"`
+	Mode         core.CollateralMode `json:"mode,omitempty"`
+	DebtCeiling  decimal.Decimal     `json:"debt_ceiling,omitempty"`
+	BorrowAssets []string            `json:"borrow_assets,omitempty"`
+}
+
+// MarshalBinary marshal req to binary
+func (w IsolationReq) MarshalBinary() (data []byte, err error) {
+	asset, err := uuid.FromString(w.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the borrow assets are packed into one field, 16 bytes each
+	var assets []byte
+	for _, id := range w.BorrowAssets {
+		borrowAsset, err := uuid.FromString(id)
+		if err != nil {
+			return nil, err
+		}
+
+		assets = append(assets, borrowAsset.Bytes()...)
+	}
+
+	return mtg.Encode(asset, w.Mode, w.DebtCeiling, mtg.RawMessage(assets))
+}
+
+// UnmarshalBinary unmarshal bytes to isolation req
+func (w *IsolationReq) UnmarshalBinary(data []byte) error {
+	var (
+		asset       uuid.UUID
+		mode        int
+		debtCeiling decimal.Decimal
+		assets      mtg.RawMessage
+	)
+
+	if _, err := mtg.Scan(data, &asset, &mode, &debtCeiling, &assets); err != nil {
+		return err
+	}
+
+	m := core.CollateralMode(mode)
+	if !m.IsValid() {
+		return errors.New("invalid collateral mode")
+	}
+
+	if len(assets)%uuid.Size != 0 {
+		return errors.New("invalid borrow assets")
+	}
+
+	borrowAssets := make([]string, 0, len(assets)/uuid.Size)
+	for idx := 0; idx < len(assets); idx += uuid.Size {
+		borrowAsset, err := uuid.FromBytes(assets[idx : idx+uuid.Size])
+		if err != nil {
+			return err
+		}
+
+		borrowAssets = append(borrowAssets, borrowAsset.String())
+	}
+
+	w.AssetID = asset.String()
+	w.Mode = m
+	w.DebtCeiling = debtCeiling
+	w.BorrowAssets = borrowAssets
+
+	return nil
+}
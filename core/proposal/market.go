@@ -1,7 +1,11 @@
 package proposal
 
 import (
+	"compound/core"
+	"compound/pkg/compound"
 	"compound/pkg/mtg"
+	"encoding/json"
+	"strings"
 
 	"github.com/gofrs/uuid"
 	"github.com/shopspring/decimal"
@@ -25,6 +29,8 @@ type MarketReq struct {
 	JumpMultiplier       decimal.Decimal `json:"jump_multiplier,omitempty"`
 	Kink                 decimal.Decimal `json:"kink,omitempty"`
 	MaxPledge            decimal.Decimal `json:"max_pledge,omitempty"`
+	// SupplyCap negative if absent from the encoded request, the cap of the existing market is kept then
+	SupplyCap decimal.Decimal `json:"supply_cap,omitempty"`
 }
 
 // MarshalBinary marshal req to binary
@@ -56,6 +62,7 @@ func (w MarketReq) MarshalBinary() (data []byte, err error) {
 		w.PriceThreshold,
 		w.Price,
 		w.MaxPledge,
+		w.SupplyCap,
 	)
 }
 
@@ -92,11 +99,123 @@ func (w *MarketReq) UnmarshalBinary(data []byte) error {
 	req.CTokenAssetID = ctokenAssetID.String()
 	if len(data) > 0 {
 		var maxPledge decimal.Decimal
-		if _, err := mtg.Scan(data, &maxPledge); err == nil {
+		if data, err = mtg.Scan(data, &maxPledge); err == nil {
 			req.MaxPledge = maxPledge
 		}
 	}
 
+	req.SupplyCap = decimal.NewFromInt(-1)
+	if len(data) > 0 {
+		var supplyCap decimal.Decimal
+		if _, err := mtg.Scan(data, &supplyCap); err == nil {
+			req.SupplyCap = supplyCap
+		}
+	}
+
 	*w = req
 	return nil
 }
+
+// UnmarshalJSON unmarshal the req persisted in the proposal, the supply cap is negative if absent
+func (w *MarketReq) UnmarshalJSON(data []byte) error {
+	type marketReq MarketReq
+	req := marketReq{SupplyCap: decimal.NewFromInt(-1)}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+
+	*w = MarketReq(req)
+	return nil
+}
+
+// Market the new market created by the req, it's closed until opened by proposal
+func (w MarketReq) Market() *core.Market {
+	return &core.Market{
+		Symbol:               strings.ToUpper(w.Symbol),
+		AssetID:              w.AssetID,
+		CTokenAssetID:        w.CTokenAssetID,
+		InitExchangeRate:     w.InitExchange,
+		ExchangeRate:         w.InitExchange,
+		ReserveFactor:        w.ReserveFactor,
+		LiquidationIncentive: w.LiquidationIncentive,
+		BorrowCap:            w.BorrowCap,
+		BorrowIndex:          decimal.New(1, 0),
+		CollateralFactor:     w.CollateralFactor,
+		CloseFactor:          w.CloseFactor,
+		BaseRate:             w.BaseRate,
+		Multiplier:           w.Multiplier,
+		JumpMultiplier:       w.JumpMultiplier,
+		Kink:                 w.Kink,
+		Price:                w.Price,
+		PriceThreshold:       w.PriceThreshold,
+		MaxPledge:            w.MaxPledge,
+		SupplyCap:            decimal.Max(w.SupplyCap, decimal.Zero),
+		Status:               core.MarketStatusClose,
+	}
+}
+
+// Apply update the parameters of the existing market, the values out of range are ignored
+func (w MarketReq) Apply(market *core.Market) {
+	one := decimal.New(1, 0)
+
+	if w.InitExchange.IsPositive() {
+		market.InitExchangeRate = w.InitExchange
+	}
+
+	if w.ReserveFactor.IsPositive() && w.ReserveFactor.LessThan(one) {
+		market.ReserveFactor = w.ReserveFactor
+	}
+
+	if w.LiquidationIncentive.GreaterThanOrEqual(compound.LiquidationIncentiveMin) &&
+		w.LiquidationIncentive.LessThanOrEqual(compound.LiquidationIncentiveMax) {
+		market.LiquidationIncentive = w.LiquidationIncentive
+	}
+
+	if !w.CollateralFactor.IsNegative() &&
+		w.CollateralFactor.LessThanOrEqual(compound.CollateralFactorMax) {
+		market.CollateralFactor = w.CollateralFactor
+	}
+
+	if w.BaseRate.IsPositive() && w.BaseRate.LessThan(one) {
+		market.BaseRate = w.BaseRate
+	}
+
+	if !w.BorrowCap.IsNegative() {
+		market.BorrowCap = w.BorrowCap
+	}
+
+	if w.CloseFactor.GreaterThanOrEqual(compound.CloseFactorMin) &&
+		w.CloseFactor.LessThanOrEqual(compound.CloseFactorMax) {
+		market.CloseFactor = w.CloseFactor
+	}
+
+	if w.Multiplier.IsPositive() && w.Multiplier.LessThan(one) {
+		market.Multiplier = w.Multiplier
+	}
+
+	if !w.JumpMultiplier.IsNegative() && w.JumpMultiplier.LessThan(one) {
+		market.JumpMultiplier = w.JumpMultiplier
+	}
+
+	if !w.Kink.IsNegative() && w.Kink.LessThan(one) {
+		market.Kink = w.Kink
+	}
+
+	if w.PriceThreshold > 0 {
+		market.PriceThreshold = w.PriceThreshold
+	} else if w.PriceThreshold < 0 {
+		market.PriceThreshold = 0
+	}
+
+	if w.Price.IsPositive() {
+		market.Price = w.Price
+	}
+
+	if !w.MaxPledge.IsNegative() {
+		market.MaxPledge = w.MaxPledge
+	}
+
+	if !w.SupplyCap.IsNegative() {
+		market.SupplyCap = w.SupplyCap
+	}
+}
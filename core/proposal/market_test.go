@@ -0,0 +1,174 @@
+package proposal
+
+import (
+	"compound/core"
+	"compound/pkg/mtg"
+	"encoding/json"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketReqBinary(t *testing.T) {
+	req := MarketReq{
+		Symbol:           "BTC",
+		AssetID:          uuid.Must(uuid.NewV4()).String(),
+		CTokenAssetID:    uuid.Must(uuid.NewV4()).String(),
+		InitExchange:     decimal.New(1, 0),
+		CollateralFactor: decimal.NewFromFloat(0.75),
+		PriceThreshold:   10,
+		MaxPledge:        decimal.NewFromInt(100),
+		SupplyCap:        decimal.NewFromInt(1000),
+	}
+
+	data, err := req.MarshalBinary()
+	require.Nil(t, err)
+
+	var decoded MarketReq
+	require.Nil(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, req.AssetID, decoded.AssetID)
+	assert.Equal(t, req.CTokenAssetID, decoded.CTokenAssetID)
+	assert.Equal(t, req.PriceThreshold, decoded.PriceThreshold)
+	assert.True(t, req.CollateralFactor.Equal(decoded.CollateralFactor))
+	assert.True(t, req.MaxPledge.Equal(decoded.MaxPledge))
+	assert.True(t, req.SupplyCap.Equal(decoded.SupplyCap))
+}
+
+// the requests encoded before the supply cap keep the cap of the market
+func TestMarketReqWithoutSupplyCap(t *testing.T) {
+	assetID, ctokenAssetID := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	zero := decimal.Zero
+	data, err := mtg.Encode(
+		"BTC",
+		assetID,
+		ctokenAssetID,
+		zero,                       // init exchange
+		zero,                       // reserve factor
+		zero,                       // liquidation incentive
+		decimal.NewFromFloat(0.75), // collateral factor
+		zero,                       // base rate
+		zero,                       // borrow cap
+		zero,                       // close factor
+		zero,                       // multiplier
+		zero,                       // jump multiplier
+		zero,                       // kink
+		0,                          // price threshold
+		zero,                       // price
+		decimal.NewFromInt(100),    // max pledge
+	)
+	require.Nil(t, err)
+
+	var req MarketReq
+	require.Nil(t, req.UnmarshalBinary(data))
+	assert.True(t, req.MaxPledge.Equal(decimal.NewFromInt(100)))
+	assert.True(t, req.SupplyCap.IsNegative())
+
+	market := &core.Market{SupplyCap: decimal.NewFromInt(1000)}
+	req.Apply(market)
+	assert.Equal(t, "1000", market.SupplyCap.String())
+	assert.Equal(t, "0.75", market.CollateralFactor.String())
+
+	// a new market is uncapped
+	assert.True(t, req.Market().SupplyCap.IsZero())
+
+	// the proposal persisted before the supply cap
+	content, err := json.Marshal(map[string]interface{}{
+		"asset_id":          assetID.String(),
+		"collateral_factor": "0.75",
+	})
+	require.Nil(t, err)
+
+	req = MarketReq{}
+	require.Nil(t, json.Unmarshal(content, &req))
+	assert.True(t, req.SupplyCap.IsNegative())
+
+	// persisted with the cap
+	req.SupplyCap = decimal.NewFromInt(2000)
+	content, err = json.Marshal(req)
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(content, &req))
+	assert.Equal(t, "2000", req.SupplyCap.String())
+
+	req.Apply(market)
+	assert.Equal(t, "2000", market.SupplyCap.String())
+}
+
+func TestMarketReqApply(t *testing.T) {
+	newMarket := func() *core.Market {
+		return &core.Market{
+			InitExchangeRate:     decimal.New(1, 0),
+			ReserveFactor:        decimal.NewFromFloat(0.1),
+			LiquidationIncentive: decimal.NewFromFloat(0.05),
+			CollateralFactor:     decimal.NewFromFloat(0.75),
+			BaseRate:             decimal.NewFromFloat(0.025),
+			BorrowCap:            decimal.NewFromInt(100),
+			CloseFactor:          decimal.NewFromFloat(0.5),
+			Multiplier:           decimal.NewFromFloat(0.1),
+			JumpMultiplier:       decimal.NewFromFloat(0.5),
+			Kink:                 decimal.NewFromFloat(0.8),
+			PriceThreshold:       10,
+			Price:                decimal.NewFromInt(30000),
+			MaxPledge:            decimal.NewFromInt(10),
+			SupplyCap:            decimal.NewFromInt(1000),
+		}
+	}
+
+	t.Run("in range", func(t *testing.T) {
+		market := newMarket()
+		MarketReq{
+			InitExchange:         decimal.NewFromInt(2),
+			ReserveFactor:        decimal.NewFromFloat(0.2),
+			LiquidationIncentive: decimal.NewFromFloat(0.1),
+			CollateralFactor:     decimal.Zero,
+			BaseRate:             decimal.NewFromFloat(0.05),
+			BorrowCap:            decimal.Zero,
+			CloseFactor:          decimal.NewFromFloat(0.6),
+			Multiplier:           decimal.NewFromFloat(0.2),
+			JumpMultiplier:       decimal.Zero,
+			Kink:                 decimal.NewFromFloat(0.9),
+			PriceThreshold:       -1,
+			Price:                decimal.NewFromInt(31000),
+			MaxPledge:            decimal.Zero,
+			SupplyCap:            decimal.NewFromInt(2000),
+		}.Apply(market)
+
+		assert.Equal(t, "2", market.InitExchangeRate.String())
+		assert.Equal(t, "0.2", market.ReserveFactor.String())
+		assert.Equal(t, "0.1", market.LiquidationIncentive.String())
+		assert.True(t, market.CollateralFactor.IsZero())
+		assert.Equal(t, "0.05", market.BaseRate.String())
+		assert.True(t, market.BorrowCap.IsZero())
+		assert.Equal(t, "0.6", market.CloseFactor.String())
+		assert.Equal(t, "0.2", market.Multiplier.String())
+		assert.True(t, market.JumpMultiplier.IsZero())
+		assert.Equal(t, "0.9", market.Kink.String())
+		assert.Zero(t, market.PriceThreshold)
+		assert.Equal(t, "31000", market.Price.String())
+		assert.True(t, market.MaxPledge.IsZero())
+		assert.Equal(t, "2000", market.SupplyCap.String())
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		market := newMarket()
+		MarketReq{
+			InitExchange:         decimal.Zero,
+			ReserveFactor:        decimal.New(1, 0),
+			LiquidationIncentive: decimal.New(1, 0),
+			CollateralFactor:     decimal.NewFromFloat(0.95),
+			BaseRate:             decimal.New(1, 0),
+			BorrowCap:            decimal.NewFromInt(-1),
+			CloseFactor:          decimal.NewFromFloat(0.01),
+			Multiplier:           decimal.Zero,
+			JumpMultiplier:       decimal.New(1, 0),
+			Kink:                 decimal.NewFromInt(-1),
+			Price:                decimal.Zero,
+			MaxPledge:            decimal.NewFromInt(-1),
+			SupplyCap:            decimal.NewFromInt(-1),
+		}.Apply(market)
+
+		assert.Equal(t, newMarket(), market)
+	})
+}
@@ -0,0 +1,41 @@
+package proposal
+
+import (
+	"compound/pkg/mtg"
+
+	"github.com/gofrs/uuid"
+)
+
+// MaxPriceAgeReq set the max price age of market
+type MaxPriceAgeReq struct {
+	AssetID string `json:"asset_id,omitempty"`
+	// MaxAge the seconds the price keeps fresh, 0 disables the check
+	MaxAge int64 `json:"max_age,omitempty"`
+}
+
+// MarshalBinary marshal req to binary
+func (w MaxPriceAgeReq) MarshalBinary() (data []byte, err error) {
+	asset, err := uuid.FromString(w.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mtg.Encode(asset, w.MaxAge)
+}
+
+// UnmarshalBinary unmarshal bytes to max price age req
+func (w *MaxPriceAgeReq) UnmarshalBinary(data []byte) error {
+	var (
+		asset  uuid.UUID
+		maxAge int64
+	)
+
+	if _, err := mtg.Scan(data, &asset, &maxAge); err != nil {
+		return err
+	}
+
+	w.AssetID = asset.String()
+	w.MaxAge = maxAge
+
+	return nil
+}
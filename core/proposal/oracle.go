@@ -16,6 +16,12 @@ type RemoveOracleSignerReq struct {
 	UserID string `json:"user_id,omitempty"`
 }
 
+// RotateOracleSignerReq replace the public key of the signer, the signer index is kept
+type RotateOracleSignerReq struct {
+	UserID    string `json:"user_id,omitempty"`
+	PublicKey string `json:"publick_key,omitempty"`
+}
+
 // MarshalBinary marshal req to binary
 func (r AddOracleSignerReq) MarshalBinary() (data []byte, err error) {
 	user, err := uuid.FromString(r.UserID)
@@ -68,3 +74,13 @@ func (r *RemoveOracleSignerReq) UnmarshalBinary(data []byte) error {
 
 	return nil
 }
+
+// MarshalBinary marshal req to binary
+func (r RotateOracleSignerReq) MarshalBinary() (data []byte, err error) {
+	return AddOracleSignerReq(r).MarshalBinary()
+}
+
+// UnmarshalBinary unmarshal bytes
+func (r *RotateOracleSignerReq) UnmarshalBinary(data []byte) error {
+	return (*AddOracleSignerReq)(r).UnmarshalBinary(data)
+}
@@ -0,0 +1,50 @@
+package proposal
+
+import (
+	"compound/pkg/mtg"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PriceGuardReq set the price aggregation of market
+type PriceGuardReq struct {
+	AssetID string `json:"asset_id,omitempty"`
+	// Samples the count of the latest accepted prices the median is computed over
+	Samples int `json:"samples,omitempty"`
+	// Window the seconds the samples are taken from
+	Window int64 `json:"window,omitempty"`
+	// MaxDeviation the max relative deviation from the median, 0 for no limit
+	MaxDeviation decimal.Decimal `json:"max_deviation,omitempty"`
+}
+
+// MarshalBinary marshal req to binary
+func (w PriceGuardReq) MarshalBinary() (data []byte, err error) {
+	asset, err := uuid.FromString(w.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mtg.Encode(asset, w.Samples, w.Window, w.MaxDeviation)
+}
+
+// UnmarshalBinary unmarshal bytes to price guard req
+func (w *PriceGuardReq) UnmarshalBinary(data []byte) error {
+	var (
+		asset        uuid.UUID
+		samples      int
+		window       int64
+		maxDeviation decimal.Decimal
+	)
+
+	if _, err := mtg.Scan(data, &asset, &samples, &window, &maxDeviation); err != nil {
+		return err
+	}
+
+	w.AssetID = asset.String()
+	w.Samples = samples
+	w.Window = window
+	w.MaxDeviation = maxDeviation
+
+	return nil
+}
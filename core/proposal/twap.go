@@ -0,0 +1,51 @@
+package proposal
+
+import (
+	"compound/core"
+	"compound/pkg/mtg"
+	"errors"
+
+	"github.com/gofrs/uuid"
+)
+
+// TWAPReq set the collateral valuation of market
+type TWAPReq struct {
+	AssetID string             `json:"asset_id,omitempty"`
+	Mode    core.ValuationMode `json:"mode,omitempty"`
+	// Window the seconds the twap averages over, required by the twap mode
+	Window int64 `json:"window,omitempty"`
+}
+
+// MarshalBinary marshal req to binary
+func (w TWAPReq) MarshalBinary() (data []byte, err error) {
+	asset, err := uuid.FromString(w.AssetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mtg.Encode(asset, w.Mode, w.Window)
+}
+
+// UnmarshalBinary unmarshal bytes to twap req
+func (w *TWAPReq) UnmarshalBinary(data []byte) error {
+	var (
+		asset  uuid.UUID
+		mode   int
+		window int64
+	)
+
+	if _, err := mtg.Scan(data, &asset, &mode, &window); err != nil {
+		return err
+	}
+
+	m := core.ValuationMode(mode)
+	if !m.IsValid() {
+		return errors.New("invalid valuation mode")
+	}
+
+	w.AssetID = asset.String()
+	w.Mode = m
+	w.Window = window
+
+	return nil
+}
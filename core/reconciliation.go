@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReconciliationType the balance reconciled
+type ReconciliationType int
+
+const (
+	_ ReconciliationType = iota
+	// ReconciliationTypeCash the underlying asset held against the total cash of the market
+	ReconciliationTypeCash
+	// ReconciliationTypeCollateral the ctokens held against the pledged collaterals
+	ReconciliationTypeCollateral
+)
+
+func (t ReconciliationType) String() string {
+	switch t {
+	case ReconciliationTypeCash:
+		return "cash"
+	case ReconciliationTypeCollateral:
+		return "collateral"
+	default:
+		return "unknown"
+	}
+}
+
+type (
+	// Reconciliation the balance of the asset held by the multisig wallet against what the protocol owes
+	Reconciliation struct {
+		ID int64 `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"id"`
+		// Checkpoint the last output handled by the payee when reconciled
+		Checkpoint int64              `json:"checkpoint"`
+		AssetID    string             `sql:"size:36" json:"asset_id"`
+		Symbol     string             `sql:"size:32" json:"symbol"`
+		Type       ReconciliationType `json:"type"`
+		// Expected the total cash of the market, or the ctokens pledged by the users
+		Expected decimal.Decimal `sql:"type:decimal(64,16)" json:"expected"`
+		// Actual the unspent outputs plus the change of the transfers on the way
+		Actual decimal.Decimal `sql:"type:decimal(64,16)" json:"actual"`
+		// Drift actual - expected
+		Drift decimal.Decimal `sql:"type:decimal(64,16)" json:"drift"`
+		// Exceeded the drift is out of the tolerance
+		Exceeded  bool      `json:"exceeded"`
+		CreatedAt time.Time `sql:"index:idx_reconciliations_created_at" json:"created_at"`
+	}
+
+	// ReconciliationStore reconciliation store interface
+	ReconciliationStore interface {
+		// Create save the reports of a round, they share the same created time
+		Create(ctx context.Context, reports []*Reconciliation) error
+		// ListLatest list the reports of the last round
+		ListLatest(ctx context.Context) ([]*Reconciliation, error)
+	}
+)
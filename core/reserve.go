@@ -0,0 +1,55 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	_ ReserveSource = iota
+	// ReserveSourceAccrual reserves accrued from the borrow interest
+	ReserveSourceAccrual
+	// ReserveSourceWithdraw reserves withdrawn by proposal
+	ReserveSourceWithdraw
+)
+
+type (
+	// ReserveSource source of the reserve entry
+	ReserveSource int
+
+	// ReserveEntry records a change of the market reserves
+	ReserveEntry struct {
+		ID      int64         `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"id"`
+		AssetID string        `sql:"size:36;unique_index:reserve_entry_idx" json:"asset_id"`
+		Source  ReserveSource `sql:"unique_index:reserve_entry_idx" json:"source"`
+		// Version the output id the entry recorded by
+		Version int64           `sql:"unique_index:reserve_entry_idx" json:"version"`
+		TraceID string          `sql:"size:36" json:"trace_id"`
+		Amount  decimal.Decimal `sql:"type:decimal(32,16)" json:"amount"`
+		// FromBlock & ToBlock the block range of the accrual
+		FromBlock int64 `json:"from_block,omitempty"`
+		ToBlock   int64 `json:"to_block,omitempty"`
+		// Recipient the opponent of the withdrawal
+		Recipient string    `sql:"size:36" json:"recipient,omitempty"`
+		CreatedAt time.Time `sql:"index:idx_reserve_entries_created_at" json:"created_at"`
+	}
+
+	// ReserveStore reserve entry store interface
+	ReserveStore interface {
+		Create(ctx context.Context, entry *ReserveEntry) error
+		List(ctx context.Context, assetID string, from, to time.Time) ([]*ReserveEntry, error)
+	}
+)
+
+func (s ReserveSource) String() string {
+	switch s {
+	case ReserveSourceAccrual:
+		return "accrual"
+	case ReserveSourceWithdraw:
+		return "withdraw"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// Subscriber the user opted in the notifications by the subscribe action
+	Subscriber struct {
+		ID        int64     `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+		UserID    string    `sql:"size:36;unique_index:subscriber_user_idx" json:"user_id"`
+		// HealthFactor warn the user once the health factor of the account falls below, zero disables the warnings
+		HealthFactor decimal.Decimal `sql:"type:decimal(32,8)" json:"health_factor"`
+		// Version the output id of the last subscribe action
+		Version int64 `json:"version"`
+		// WarnedAt the last health factor warning sent by the notifier
+		WarnedAt sql.NullTime `json:"warned_at"`
+	}
+
+	// SubscriberStore subscriber store interface
+	SubscriberStore interface {
+		// Save create or update the subscriber of the user, the warned time is kept
+		Save(ctx context.Context, subscriber *Subscriber) error
+		// Find return an empty subscriber if the user never subscribed
+		Find(ctx context.Context, userID string) (*Subscriber, error)
+		Delete(ctx context.Context, userID string) error
+		List(ctx context.Context, fromID int64, limit int) ([]*Subscriber, error)
+		// Warned set the warned time of the subscriber
+		Warned(ctx context.Context, userID string, at time.Time) error
+	}
+)
@@ -8,7 +8,7 @@ import (
 )
 
 const (
-	SysVersion int64 = 5
+	SysVersion int64 = 6
 )
 
 type (
@@ -60,6 +60,10 @@ const (
 	TransactionKeyBorrow = "borrow"
 	// TransactionKeyMarket market
 	TransactionKeyMarket = "market"
+	// TransactionKeyMessage message of the refund error
+	TransactionKeyMessage = "message"
+	// TransactionKeyIsolatedDebts isolated debt changes by ctoken asset id :map[string]decimal
+	TransactionKeyIsolatedDebts = "isolated_debts"
 )
 
 type ExtraDataFormatter interface {
@@ -157,12 +161,66 @@ func (t *Transaction) UnmarshalExtraData(obj interface{}) error {
 	return nil
 }
 
+// TransactionFilter filter the transactions of the user, the empty fields match all
+type TransactionFilter struct {
+	Actions  []ActionType
+	AssetID  string
+	FollowID string
+	// From & To the created time in [from, to)
+	From time.Time
+	To   time.Time
+	// Cursor list the transactions with id < cursor, 0 from the latest
+	Cursor int64
+	Limit  int
+}
+
+// Match the transaction is selected by the filter, the user & cursor are not checked
+func (f TransactionFilter) Match(tx *Transaction) bool {
+	if len(f.Actions) > 0 {
+		matched := false
+		for _, action := range f.Actions {
+			if tx.Action == action {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if f.AssetID != "" && tx.AssetID != f.AssetID {
+		return false
+	}
+
+	if f.FollowID != "" && tx.FollowID != f.FollowID {
+		return false
+	}
+
+	if !f.From.IsZero() && tx.CreatedAt.Before(f.From) {
+		return false
+	}
+
+	if !f.To.IsZero() && !tx.CreatedAt.Before(f.To) {
+		return false
+	}
+
+	return true
+}
+
 // TransactionStore transaction store interface
 type TransactionStore interface {
 	Create(ctx context.Context, transactions *Transaction) error
 	FindByTraceID(ctx context.Context, traceID string) (*Transaction, error)
 	Update(ctx context.Context, transaction *Transaction) error
 	List(ctx context.Context, offset time.Time, limit int) ([]*Transaction, error)
+	// ListFrom list the transactions with id > fromID ordered by id
+	ListFrom(ctx context.Context, fromID int64, limit int) ([]*Transaction, error)
+	// LastID the id of the latest transaction, 0 if none
+	LastID(ctx context.Context) (int64, error)
+	// ListByUser list the transactions of the user by the filter, newest first
+	ListByUser(ctx context.Context, userID string, filter TransactionFilter) ([]*Transaction, error)
 }
 
 // BuildTransactionFromOutput transaction from output
@@ -196,6 +254,9 @@ func BuildTransactionFromTransfer(ctx context.Context, transfer *Transfer, snaps
 	if transferAction.Code > 0 {
 		transactionExtra.Put(TransactionKeyErrorCode, transferAction.Code)
 	}
+	if transferAction.Message != "" {
+		transactionExtra.Put(TransactionKeyMessage, transferAction.Message)
+	}
 
 	action := transferAction.Source
 	if action == ActionTypeDefault {
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// PriceAccumulator the cumulative price of the market at the block
+	//
+	// 	cumulative(block) = cumulative + price * (block - accumulator.block)
+	PriceAccumulator struct {
+		ID      int64  `sql:"PRIMARY_KEY;AUTO_INCREMENT" json:"id"`
+		AssetID string `sql:"size:36;unique_index:price_accumulator_idx" json:"asset_id"`
+		Block   int64  `sql:"unique_index:price_accumulator_idx" json:"block"`
+		// Version the output id of the last price in the block
+		Version int64 `json:"version"`
+		// Price the price since the block
+		Price decimal.Decimal `sql:"type:decimal(32,16)" json:"price"`
+		// Cumulative the sum of price * blocks before the block
+		Cumulative decimal.Decimal `sql:"type:decimal(64,16)" json:"cumulative"`
+		CreatedAt  time.Time       `json:"created_at"`
+	}
+
+	// PriceAccumulatorStore price accumulator store interface
+	PriceAccumulatorStore interface {
+		// Save create the accumulator, or update the price of the block if the version is newer
+		Save(ctx context.Context, accumulator *PriceAccumulator) error
+		// Find the latest accumulator of the asset at or before the block
+		Find(ctx context.Context, assetID string, block int64) (*PriceAccumulator, error)
+	}
+)
+
+// CumulativeAt the cumulative price at the block after the accumulator
+func (a PriceAccumulator) CumulativeAt(block int64) decimal.Decimal {
+	return a.Cumulative.Add(a.Price.Mul(decimal.NewFromInt(block - a.Block)))
+}
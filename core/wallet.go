@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/fox-one/mixin-sdk-go"
@@ -48,6 +49,21 @@ const (
 	TransferStatusPassed
 )
 
+func (s TransferStatus) String() string {
+	switch s {
+	case TransferStatusPending:
+		return "pending"
+	case TransferStatusAssigned:
+		return "assigned"
+	case TransferStatusHandled:
+		return "handled"
+	case TransferStatusPassed:
+		return "passed"
+	default:
+		return "unknown"
+	}
+}
+
 // Transfer transfer struct
 type Transfer struct {
 	ID        int64           `sql:"PRIMARY_KEY" json:"id,omitempty"`
@@ -65,6 +81,43 @@ type Transfer struct {
 	Opponents pq.StringArray  `sql:"type:varchar(1024)" json:"opponents,omitempty"`
 }
 
+const (
+	// ConsolidationThresholdKey the property key of the unspent outputs count of an asset tolerated,
+	// the consolidation is disabled if it is not set or 0
+	ConsolidationThresholdKey = "consolidation_threshold"
+
+	// ConsolidationBatchKey the property key of the outputs count merged by a consolidation,
+	// DefaultConsolidationBatch if it is not set or out of range
+	ConsolidationBatchKey = "consolidation_batch"
+
+	// ConsolidationIdleKey the property key of the idle duration of an asset before a consolidation, like "10m".
+	// The consolidation is recorded by the first output of the asset at least so long after the previous one
+	ConsolidationIdleKey = "consolidation_idle"
+
+	DefaultConsolidationBatch = 32
+	// MaxConsolidationBatch the max inputs of a mixin transaction
+	MaxConsolidationBatch = 256
+
+	// TransferMemoConsolidation the memo prefix of the consolidation recorded by the payee, a transfer to the members
+	// without amount, the assigner merges the oldest unspent outputs into it
+	TransferMemoConsolidation = "consolidate"
+)
+
+// ConsolidationMemo the memo of the consolidation with the threshold & batch read by the payee
+func ConsolidationMemo(threshold, batch int64) string {
+	return fmt.Sprintf("%s %d %d", TransferMemoConsolidation, threshold, batch)
+}
+
+// Consolidation the threshold & batch of the consolidation not assigned yet
+func (t *Transfer) Consolidation() (threshold, batch int64, ok bool) {
+	if !t.Amount.IsZero() {
+		return 0, 0, false
+	}
+
+	n, _ := fmt.Sscanf(t.Memo, TransferMemoConsolidation+" %d %d", &threshold, &batch)
+	return threshold, batch, n == 2
+}
+
 // RawTransaction raw transaction
 type RawTransaction struct {
 	ID        int64     `sql:"PRIMARY_KEY" json:"id,omitempty"`
@@ -73,6 +126,13 @@ type RawTransaction struct {
 	Data      string    `sql:"type:MEDIUMTEXT" json:"data,omitempty"`
 }
 
+// AssetAmount the sum & count of the outputs or transfers of the asset
+type AssetAmount struct {
+	AssetID string          `json:"asset_id"`
+	Count   int64           `json:"count"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
 // WalletStore define wallet db operations
 type WalletStore interface {
 	// Save batch update multiple Output
@@ -87,6 +147,8 @@ type WalletStore interface {
 	CreateTransfers(ctx context.Context, transfers []*Transfer) error
 	UpdateTransfer(ctx context.Context, transfer *Transfer) error
 	ListTransfers(ctx context.Context, status TransferStatus, limit int) ([]*Transfer, error)
+	// FindTransfer find the transfer by trace id
+	FindTransfer(ctx context.Context, traceID string) (*Transfer, error)
 	Assign(ctx context.Context, outputs []*Output, transfer *Transfer) error
 	// ListPendingTransfers(ctx context.Context) ([]*Transfer, error)
 	// ListNotPassedTransfers(ctx context.Context) ([]*Transfer, error)
@@ -94,11 +156,17 @@ type WalletStore interface {
 	// mixin net transaction
 	CreateRawTransaction(ctx context.Context, tx *RawTransaction) error
 	ListPendingRawTransactions(ctx context.Context, limit int) ([]*RawTransaction, error)
+	// FindRawTransaction find the raw transaction not expired yet by trace id
+	FindRawTransaction(ctx context.Context, traceID string) (*RawTransaction, error)
 	ExpireRawTransaction(ctx context.Context, tx *RawTransaction) error
 	// CountOutputs return a count of outputs
 	CountOutputs(ctx context.Context) (int64, error)
 	// CountUnhandledTransfers return a count of pending transfers
 	CountUnhandledTransfers(ctx context.Context) (int64, error)
+	// SumUnspent sum the unspent outputs by asset
+	SumUnspent(ctx context.Context) ([]*AssetAmount, error)
+	// SumUnpassed sum the transfers not passed yet and the outputs assigned to them by asset
+	SumUnpassed(ctx context.Context) (transfers, outputs []*AssetAmount, err error)
 }
 
 type OutputSyncStore interface {
@@ -44,4 +44,8 @@ group:
   threshold: 2
   vote:
     asset: 965e5c6e-434c-3fa9-b780-c50f43cd955c
-    amount: 0.00000001
\ No newline at end of file
+    amount: 0.00000001
+
+# user notifications, enable it on one node only
+notifier:
+  enabled: false
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"compound/core"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+)
+
+// Handle handle the admin requests, served on the worker port only
+func Handle(divergences core.DivergenceStore) http.Handler {
+	r := chi.NewRouter()
+	r.Use(middleware.NoCache)
+	r.Get("/divergences", divergencesHandler(divergences))
+	return r
+}
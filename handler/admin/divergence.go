@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"compound/core"
+	"compound/handler/param"
+	"compound/handler/render"
+	"fmt"
+	"net/http"
+)
+
+// divergencesHandler list the multisig signing divergences newest first, for the operators
+func divergencesHandler(divergences core.DivergenceStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var params struct {
+			Cursor int64 `json:"cursor"`
+			Limit  int   `json:"limit"`
+		}
+
+		if e := param.Binding(r, &params); e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		if params.Limit <= 0 || params.Limit > 500 {
+			params.Limit = 50
+		}
+
+		items, err := divergences.List(ctx, params.Cursor, params.Limit)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		var nextCursor string
+		if len(items) == params.Limit {
+			nextCursor = fmt.Sprint(items[len(items)-1].ID)
+		}
+
+		render.JSON(w, render.H{
+			"data": render.H{
+				"divergences": items,
+				"pagination": render.H{
+					"next_cursor": nextCursor,
+					"has_next":    nextCursor != "",
+				},
+			},
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"compound/core"
+	"compound/handler/param"
+	"compound/handler/render"
+	"errors"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+)
+
+// response the positions and liquidity of the user
+func accountHandler(accountz core.IAccountService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID := param.String(r, "user_id")
+		if _, err := uuid.FromString(userID); err != nil {
+			render.BadRequest(w, errors.New("invalid user id"))
+			return
+		}
+
+		snapshot, err := accountz.Snapshot(ctx, userID)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		render.JSON(w, render.H{
+			"data": snapshot,
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package rest
+
+import (
+	"compound/core"
+	"compound/handler/render"
+	"net/http"
+)
+
+// response the accounts with shortfall, ordered by shortfall desc
+func liquidationCandidatesHandler(candidates core.LiquidationCandidateStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		list, err := candidates.List(ctx)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		render.JSON(w, render.H{
+			"data": list,
+		})
+	}
+}
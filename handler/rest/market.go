@@ -62,25 +62,12 @@ func getMarketView(ctx context.Context, market *core.Market, supplyStr core.ISup
 
 // CurBorrowRate current borrow APY
 func CurBorrowRate(market *core.Market) decimal.Decimal {
-	borrowRatePerBlock := compound.GetBorrowRatePerBlock(
-		compound.UtilizationRate(market.TotalCash, market.TotalBorrows, market.Reserves),
-		market.BaseRate,
-		market.Multiplier,
-		market.JumpMultiplier,
-		market.Kink,
-	)
+	borrowRatePerBlock := compound.BorrowRatePerBlock(market)
 	return borrowRatePerBlock.Mul(compound.BlocksPerYear).Truncate(compound.MaxPricision)
 }
 
 // CurSupplyRate current supply APY
 func CurSupplyRate(market *core.Market) decimal.Decimal {
-	supplyRatePerBlock := compound.GetSupplyRatePerBlock(
-		compound.UtilizationRate(market.TotalCash, market.TotalBorrows, market.Reserves),
-		market.BaseRate,
-		market.Multiplier,
-		market.JumpMultiplier,
-		market.Kink,
-		market.ReserveFactor,
-	)
+	supplyRatePerBlock := compound.SupplyRatePerBlock(market)
 	return supplyRatePerBlock.Mul(compound.BlocksPerYear).Truncate(compound.MaxPricision)
 }
@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"compound/core"
+	"compound/handler/param"
+	"compound/handler/render"
+	"compound/pkg/compound"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ohlc struct {
+	Open  decimal.Decimal `json:"open"`
+	High  decimal.Decimal `json:"high"`
+	Low   decimal.Decimal `json:"low"`
+	Close decimal.Decimal `json:"close"`
+}
+
+func newOHLC(v decimal.Decimal) *ohlc {
+	return &ohlc{Open: v, High: v, Low: v, Close: v}
+}
+
+func (o *ohlc) add(v decimal.Decimal) {
+	if v.GreaterThan(o.High) {
+		o.High = v
+	}
+
+	if v.LessThan(o.Low) {
+		o.Low = v
+	}
+
+	o.Close = v
+}
+
+type marketHistoryPeriod struct {
+	Time            time.Time `json:"time"`
+	Price           *ohlc     `json:"price"`
+	UtilizationRate *ohlc     `json:"utilization_rate"`
+	ExchangeRate    *ohlc     `json:"exchange_rate"`
+	SupplyAPY       *ohlc     `json:"supply_apy"`
+	BorrowAPY       *ohlc     `json:"borrow_apy"`
+	// the values at the end of the period
+	TotalCash    decimal.Decimal `json:"total_cash"`
+	TotalBorrows decimal.Decimal `json:"total_borrows"`
+	Reserves     decimal.Decimal `json:"reserves"`
+	CTokens      decimal.Decimal `json:"ctokens"`
+	Updates      int             `json:"updates"`
+}
+
+func newMarketHistoryPeriod(t time.Time, snapshot *core.MarketSnapshot) *marketHistoryPeriod {
+	return &marketHistoryPeriod{
+		Time:            t,
+		Price:           newOHLC(snapshot.Price),
+		UtilizationRate: newOHLC(snapshot.UtilizationRate),
+		ExchangeRate:    newOHLC(snapshot.ExchangeRate),
+		SupplyAPY:       newOHLC(snapshotAPY(snapshot.SupplyRatePerBlock)),
+		BorrowAPY:       newOHLC(snapshotAPY(snapshot.BorrowRatePerBlock)),
+	}
+}
+
+func (p *marketHistoryPeriod) add(snapshot *core.MarketSnapshot) {
+	p.Price.add(snapshot.Price)
+	p.UtilizationRate.add(snapshot.UtilizationRate)
+	p.ExchangeRate.add(snapshot.ExchangeRate)
+	p.SupplyAPY.add(snapshotAPY(snapshot.SupplyRatePerBlock))
+	p.BorrowAPY.add(snapshotAPY(snapshot.BorrowRatePerBlock))
+	p.TotalCash = snapshot.TotalCash
+	p.TotalBorrows = snapshot.TotalBorrows
+	p.Reserves = snapshot.Reserves
+	p.CTokens = snapshot.CTokens
+	p.Updates++
+}
+
+func snapshotAPY(ratePerBlock decimal.Decimal) decimal.Decimal {
+	return ratePerBlock.Mul(compound.BlocksPerYear).Truncate(compound.MaxPricision)
+}
+
+// response the market snapshots downsampled to OHLC by interval
+func marketHistoryHandler(marketStr core.IMarketStore, snapshotStr core.MarketSnapshotStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var params struct {
+			AssetID  string `json:"asset_id"`
+			From     string `json:"from"`
+			To       string `json:"to"`
+			Interval string `json:"interval"`
+		}
+
+		if e := param.Binding(r, &params); e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		market, e := marketStr.Find(ctx, params.AssetID)
+		if e != nil {
+			render.BadRequest(w, e)
+			return
+		} else if market.ID == 0 {
+			render.NotFoundRequest(w, errors.New("market not found"))
+			return
+		}
+
+		to, err := time.Parse(time.RFC3339Nano, params.To)
+		if err != nil {
+			to = time.Now()
+		}
+
+		from, err := time.Parse(time.RFC3339Nano, params.From)
+		if err != nil {
+			from = to.AddDate(0, 0, -7)
+		}
+
+		if params.Interval == "" {
+			params.Interval = "hour"
+		}
+
+		truncate, ok := periodTruncates[params.Interval]
+		if !ok {
+			render.BadRequest(w, errors.New("invalid interval, must be one of hour, day, week and month"))
+			return
+		}
+
+		snapshots, e := snapshotStr.List(ctx, market.AssetID, from, to)
+		if e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		periods := make([]*marketHistoryPeriod, 0)
+		for _, snapshot := range snapshots {
+			t := truncate(snapshot.CreatedAt.UTC())
+			if len(periods) == 0 || !periods[len(periods)-1].Time.Equal(t) {
+				periods = append(periods, newMarketHistoryPeriod(t, snapshot))
+			}
+
+			periods[len(periods)-1].add(snapshot)
+		}
+
+		render.JSON(w, render.H{
+			"data": render.H{
+				"asset_id": market.AssetID,
+				"symbol":   market.Symbol,
+				"interval": params.Interval,
+				"periods":  periods,
+			},
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"compound/core"
+	"compound/handler/render"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type oracleSignerView struct {
+	Index     int64  `json:"index"`
+	UserID    string `json:"user_id"`
+	PublicKey string `json:"public_key"`
+	Signed    int64  `json:"signed"`
+	Missed    int64  `json:"missed"`
+	// Participation signed / (signed + missed)
+	Participation decimal.Decimal `json:"participation"`
+	LastSignedAt  *time.Time      `json:"last_signed_at,omitempty"`
+}
+
+// response the oracle signers with the participation stats
+func oracleSignersHandler(oracleSignerStr core.OracleSignerStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		signers, e := oracleSignerStr.FindAll(ctx)
+		if e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		views := make([]oracleSignerView, 0, len(signers))
+		for _, s := range signers {
+			view := oracleSignerView{
+				Index:         s.SignerIndex,
+				UserID:        s.UserID,
+				PublicKey:     s.PublicKey,
+				Signed:        s.Signed,
+				Missed:        s.Missed,
+				Participation: decimal.Zero,
+			}
+
+			if total := s.Signed + s.Missed; total > 0 {
+				view.Participation = decimal.NewFromInt(s.Signed).Div(decimal.NewFromInt(total)).Truncate(4)
+			}
+
+			if !s.LastSignedAt.IsZero() {
+				at := s.LastSignedAt
+				view.LastSignedAt = &at
+			}
+
+			views = append(views, view)
+		}
+
+		render.JSON(w, render.H{
+			"data": views,
+		})
+	}
+}
@@ -50,7 +50,7 @@ func priceRequestsHandler(system *core.System, marketStr core.IMarketStore, orac
 			}
 
 			signers[idx] = &core.Signer{
-				Index:     uint64(idx) + 1,
+				Index:     uint64(s.SignerIndex),
 				VerifyKey: &pub,
 			}
 		}
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"compound/core"
+	"compound/handler/param"
+	"compound/handler/render"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var priceTickStatuses = map[string]core.PriceTickStatus{
+	"":         0,
+	"accepted": core.PriceTickStatusAccepted,
+	"rejected": core.PriceTickStatusRejected,
+}
+
+// response the oracle prices of the market, the rejected ones come with the reason
+func priceTicksHandler(marketStr core.IMarketStore, priceTickStr core.PriceTickStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var params struct {
+			AssetID string `json:"asset_id"`
+			Status  string `json:"status"`
+			From    string `json:"from"`
+			To      string `json:"to"`
+		}
+
+		if e := param.Binding(r, &params); e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		status, ok := priceTickStatuses[params.Status]
+		if !ok {
+			render.BadRequest(w, errors.New("invalid status, must be one of accepted and rejected"))
+			return
+		}
+
+		market, e := marketStr.Find(ctx, params.AssetID)
+		if e != nil {
+			render.BadRequest(w, e)
+			return
+		} else if market.ID == 0 {
+			render.NotFoundRequest(w, errors.New("market not found"))
+			return
+		}
+
+		to, err := time.Parse(time.RFC3339Nano, params.To)
+		if err != nil {
+			to = time.Now()
+		}
+
+		from, err := time.Parse(time.RFC3339Nano, params.From)
+		if err != nil {
+			from = to.AddDate(0, 0, -1)
+		}
+
+		ticks, e := priceTickStr.List(ctx, market.AssetID, status, from, to)
+		if e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		render.JSON(w, render.H{
+			"data": render.H{
+				"asset_id":            market.AssetID,
+				"symbol":              market.Symbol,
+				"price":               market.Price,
+				"price_samples":       market.PriceSamples,
+				"price_window":        market.PriceWindow,
+				"max_price_deviation": market.MaxPriceDeviation,
+				"prices":              ticks,
+			},
+		})
+	}
+}
@@ -14,8 +14,9 @@ func handleProposals(proposals core.ProposalStore, proposalz core.ProposalServic
 		ctx := r.Context()
 
 		var params struct {
-			Cursor int64 `json:"cursor"`
-			Offset int64 `json:"offset"`
+			Cursor int64  `json:"cursor"`
+			Offset int64  `json:"offset"`
+			Status string `json:"status"`
 		}
 		if e := param.Binding(r, &params); e != nil {
 			render.BadRequest(w, e)
@@ -27,16 +28,32 @@ func handleProposals(proposals core.ProposalStore, proposalz core.ProposalServic
 		}
 
 		const LIMIT = 50
-		proposals, err := proposals.List(ctx, params.Cursor, LIMIT)
+		var (
+			list []*core.Proposal
+			err  error
+		)
+
+		if params.Status != "" {
+			status := core.ParseProposalStatus(params.Status)
+			if !status.IsValid() {
+				render.BadRequest(w, fmt.Errorf("invalid status %q", params.Status))
+				return
+			}
+
+			list, err = proposals.ListByStatus(ctx, status, params.Cursor, LIMIT)
+		} else {
+			list, err = proposals.List(ctx, params.Cursor, LIMIT)
+		}
+
 		if err != nil {
 			render.BadRequest(w, err)
 			return
 		}
 
-		pviews := views.ProposalViews(proposals)
+		pviews := views.ProposalViews(list)
 		var nextCursor string
-		if len(proposals) == LIMIT {
-			nextCursor = fmt.Sprint(proposals[LIMIT-1].ID)
+		if len(list) == LIMIT {
+			nextCursor = fmt.Sprint(list[LIMIT-1].ID)
 		}
 
 		render.JSON(w, render.H{
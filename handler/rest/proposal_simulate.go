@@ -0,0 +1,293 @@
+package rest
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"compound/handler/param"
+	"compound/handler/render"
+	"compound/handler/views"
+	"compound/pkg/compound"
+	"compound/pkg/mtg"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/fox-one/pkg/property"
+	"github.com/shopspring/decimal"
+)
+
+// simulate the proposal with the current state, nothing is saved
+func simulateProposalHandler(
+	marketStore core.IMarketStore,
+	supplyStore core.ISupplyStore,
+	borrowStore core.IBorrowStore,
+	properties property.Store,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var params struct {
+			// Memo the memo of the proposal transfer, printed by the proposal commands
+			Memo string `json:"memo,omitempty"`
+		}
+
+		if err := param.Binding(r, &params); err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		action, content, err := decodeProposalMemo(params.Memo)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		simulation := views.ProposalSimulation{
+			Action:       action.String(),
+			Markets:      []views.MarketChange{},
+			Properties:   []views.PropertyChange{},
+			Liquidatable: []views.LiquidatableAccount{},
+		}
+
+		switch action {
+		case core.ActionTypeProposalUpsertMarket:
+			var req proposal.MarketReq
+			if err := req.UnmarshalBinary(content); err != nil {
+				render.BadRequest(w, err)
+				return
+			}
+
+			// compare with the markets accrued to now, as the payee accrues the market before applying the proposal
+			markets, err := marketStore.All(ctx)
+			if err != nil {
+				render.BadRequest(w, err)
+				return
+			}
+
+			var before *core.Market
+			for _, m := range markets {
+				compound.AccrueInterest(ctx, m, time.Now())
+				if m.AssetID == req.AssetID {
+					before = m
+				}
+			}
+
+			if before == nil {
+				after := req.Market()
+				simulation.Markets = append(simulation.Markets, views.MarketChange{
+					After: getMarketView(ctx, after, supplyStore, borrowStore),
+				})
+				break
+			}
+
+			after := *before
+			req.Apply(&after)
+			simulation.Markets = append(simulation.Markets, views.MarketChange{
+				Before: getMarketView(ctx, before, supplyStore, borrowStore),
+				After:  getMarketView(ctx, &after, supplyStore, borrowStore),
+			})
+
+			accounts, err := simulateLiquidatable(ctx, supplyStore, borrowStore, markets, &after)
+			if err != nil {
+				render.BadRequest(w, err)
+				return
+			}
+			simulation.Liquidatable = accounts
+		case core.ActionTypeProposalSetProperty:
+			var req proposal.SetProperty
+			if err := req.UnmarshalBinary(content); err != nil {
+				render.BadRequest(w, err)
+				return
+			}
+
+			v, err := properties.Get(ctx, req.Key)
+			if err != nil {
+				render.BadRequest(w, err)
+				return
+			}
+
+			simulation.Properties = append(simulation.Properties, views.PropertyChange{
+				Key:    req.Key,
+				Before: v.String(),
+				After:  req.Value,
+			})
+		default:
+			render.BadRequest(w, fmt.Errorf("simulating %s not supported", action))
+			return
+		}
+
+		render.JSON(w, render.H{
+			"data": simulation,
+		})
+	}
+}
+
+// decodeProposalMemo decode the proposal action and content from the transfer memo
+func decodeProposalMemo(memo string) (core.ActionType, []byte, error) {
+	data, err := base64.StdEncoding.DecodeString(memo)
+	if err != nil {
+		if data, err = base64.URLEncoding.DecodeString(memo); err != nil {
+			return 0, nil, errors.New("invalid memo")
+		}
+	}
+
+	payload, err := core.DecodeTransactionAction(data)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var kind, action core.ActionType
+	content, err := mtg.Scan(payload.Body, &kind, &action)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if kind != core.ActionTypeProposalMake {
+		return 0, nil, errors.New("not a proposal")
+	}
+
+	return action, content, nil
+}
+
+// simulateLiquidatable list the accounts healthy now but with shortfall after the market changed,
+// only the accounts pledging or borrowing the market are affected. The positions are loaded in bulk
+// and valued by the markets given, which are accrued to now
+func simulateLiquidatable(
+	ctx context.Context,
+	supplyStore core.ISupplyStore,
+	borrowStore core.IBorrowStore,
+	markets []*core.Market,
+	market *core.Market,
+) ([]views.LiquidatableAccount, error) {
+	supplies, err := supplyStore.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	borrows, err := borrowStore.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type position struct {
+		supplies []*core.Supply
+		borrows  []*core.Borrow
+	}
+
+	var (
+		positions = map[string]*position{}
+		users     = map[string]bool{}
+	)
+
+	positionOf := func(userID string) *position {
+		p, ok := positions[userID]
+		if !ok {
+			p = &position{}
+			positions[userID] = p
+		}
+
+		return p
+	}
+
+	for _, supply := range supplies {
+		if !supply.Collaterals.IsPositive() {
+			continue
+		}
+
+		p := positionOf(supply.UserID)
+		p.supplies = append(p.supplies, supply)
+		if supply.CTokenAssetID == market.CTokenAssetID {
+			users[supply.UserID] = true
+		}
+	}
+
+	for _, borrow := range borrows {
+		if !borrow.Principal.IsPositive() {
+			continue
+		}
+
+		p := positionOf(borrow.UserID)
+		p.borrows = append(p.borrows, borrow)
+		if borrow.AssetID == market.AssetID {
+			users[borrow.UserID] = true
+		}
+	}
+
+	byAsset := make(map[string]*core.Market, len(markets))
+	byCToken := make(map[string]*core.Market, len(markets))
+	for _, m := range markets {
+		byAsset[m.AssetID] = m
+		byCToken[m.CTokenAssetID] = m
+	}
+
+	// liquidity = total_collateral_values - total_borrow_values, the same as the account service
+	liquidity := func(p *position, changed *core.Market) (decimal.Decimal, error) {
+		value := decimal.Zero
+		for _, supply := range p.supplies {
+			m, ok := byCToken[supply.CTokenAssetID]
+			if !ok {
+				return decimal.Zero, errors.New("no market")
+			}
+
+			if changed != nil && changed.CTokenAssetID == m.CTokenAssetID {
+				m = changed
+			}
+
+			value = value.Add(supply.Collaterals.Mul(m.ExchangeRate).Mul(m.CollateralFactor).Mul(m.CollateralPrice()))
+		}
+
+		for _, borrow := range p.borrows {
+			m, ok := byAsset[borrow.AssetID]
+			if !ok {
+				return decimal.Zero, errors.New("no market")
+			}
+
+			if changed != nil && changed.AssetID == m.AssetID {
+				m = changed
+			}
+
+			value = value.Sub(compound.BorrowBalance(ctx, borrow, m).Mul(m.Price))
+		}
+
+		return value, nil
+	}
+
+	accounts := []views.LiquidatableAccount{}
+	for userID := range users {
+		before, err := liquidity(positions[userID], nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if before.IsNegative() {
+			continue
+		}
+
+		after, err := liquidity(positions[userID], market)
+		if err != nil {
+			return nil, err
+		}
+
+		if after.IsNegative() {
+			accounts = append(accounts, views.LiquidatableAccount{
+				UserID:          userID,
+				LiquidityBefore: before.Truncate(compound.MaxPricision),
+				LiquidityAfter:  after.Truncate(compound.MaxPricision),
+			})
+		}
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].LiquidityAfter.Equal(accounts[j].LiquidityAfter) {
+			return accounts[i].UserID < accounts[j].UserID
+		}
+
+		return accounts[i].LiquidityAfter.LessThan(accounts[j].LiquidityAfter)
+	})
+
+	return accounts, nil
+}
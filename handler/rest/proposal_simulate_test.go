@@ -0,0 +1,163 @@
+package rest
+
+import (
+	"bytes"
+	"compound/core"
+	"compound/core/proposal"
+	"compound/handler/views"
+	"compound/pkg/compound"
+	"compound/pkg/mtg"
+	"compound/store/memory"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateUpsertMarketProposal(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		markets    = memory.NewMarketStore()
+		supplies   = memory.NewSupplyStore()
+		borrows    = memory.NewBorrowStore()
+		properties = memory.NewPropertyStore()
+		handler    = simulateProposalHandler(markets, supplies, borrows, properties)
+	)
+
+	// accrued a day ago
+	block, err := compound.GetBlockByTime(ctx, time.Now().Add(-24*time.Hour))
+	require.Nil(t, err)
+
+	newMarket := func(symbol string, price decimal.Decimal) *core.Market {
+		market := &core.Market{
+			Symbol:               symbol,
+			AssetID:              uuid.Must(uuid.NewV4()).String(),
+			CTokenAssetID:        uuid.Must(uuid.NewV4()).String(),
+			TotalCash:            decimal.NewFromInt(100000),
+			TotalBorrows:         decimal.NewFromInt(50000),
+			CTokens:              decimal.NewFromInt(150000),
+			InitExchangeRate:     decimal.New(1, 0),
+			ExchangeRate:         decimal.New(1, 0),
+			ReserveFactor:        decimal.NewFromFloat(0.1),
+			LiquidationIncentive: decimal.NewFromFloat(0.05),
+			BorrowIndex:          decimal.New(1, 0),
+			CollateralFactor:     decimal.NewFromFloat(0.75),
+			CloseFactor:          decimal.NewFromFloat(0.5),
+			BaseRate:             decimal.NewFromFloat(0.025),
+			Multiplier:           decimal.NewFromFloat(0.1),
+			JumpMultiplier:       decimal.NewFromFloat(0.5),
+			Kink:                 decimal.NewFromFloat(0.8),
+			BlockNumber:          block,
+			Price:                price,
+			Status:               core.MarketStatusOpen,
+		}
+		require.Nil(t, markets.Create(ctx, market))
+		return market
+	}
+
+	btc := newMarket("BTC", decimal.NewFromInt(30000))
+	usdt := newMarket("USDT", decimal.New(1, 0))
+
+	open := func(collaterals, principal int64) string {
+		userID := uuid.Must(uuid.NewV4()).String()
+		require.Nil(t, supplies.Create(ctx, &core.Supply{UserID: userID, CTokenAssetID: btc.CTokenAssetID, Collaterals: decimal.NewFromInt(collaterals)}))
+		require.Nil(t, borrows.Create(ctx, &core.Borrow{UserID: userID, AssetID: usdt.AssetID, Principal: decimal.NewFromInt(principal), InterestIndex: decimal.New(1, 0)}))
+		return userID
+	}
+
+	var (
+		// 22500 - 20000 now, 18000 - 20000 after
+		alice = open(1, 20000)
+		// 12500 now, 8000 after
+		_ = open(1, 10000)
+		// short already
+		_ = open(1, 25000)
+	)
+
+	post := func(memo []byte) (int, *views.ProposalSimulation) {
+		data, _ := json.Marshal(map[string]string{"memo": base64.StdEncoding.EncodeToString(memo)})
+		r := httptest.NewRequest(http.MethodPost, "/proposals/simulate", bytes.NewReader(data))
+		w := httptest.NewRecorder()
+		handler(w, r)
+
+		var resp struct {
+			Data *views.ProposalSimulation `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp.Data
+	}
+
+	simulate := func(req proposal.MarketReq) (int, *views.ProposalSimulation) {
+		body, err := mtg.Encode(core.ActionTypeProposalMake, core.ActionTypeProposalUpsertMarket)
+		require.Nil(t, err)
+		content, err := req.MarshalBinary()
+		require.Nil(t, err)
+		memo, err := core.TransactionAction{Body: append(body, content...)}.Encode()
+		require.Nil(t, err)
+		return post(memo)
+	}
+
+	req := proposal.MarketReq{
+		Symbol:               btc.Symbol,
+		AssetID:              btc.AssetID,
+		CTokenAssetID:        btc.CTokenAssetID,
+		ReserveFactor:        btc.ReserveFactor,
+		LiquidationIncentive: btc.LiquidationIncentive,
+		CollateralFactor:     decimal.NewFromFloat(0.6),
+		BaseRate:             btc.BaseRate,
+		CloseFactor:          btc.CloseFactor,
+		Multiplier:           btc.Multiplier,
+		JumpMultiplier:       btc.JumpMultiplier,
+		Kink:                 btc.Kink,
+	}
+
+	code, simulation := simulate(req)
+	require.Equal(t, http.StatusOK, code)
+	require.NotNil(t, simulation)
+	assert.Equal(t, core.ActionTypeProposalUpsertMarket.String(), simulation.Action)
+
+	if assert.Len(t, simulation.Markets, 1) {
+		change := simulation.Markets[0]
+		require.NotNil(t, change.Before)
+		require.NotNil(t, change.After)
+		assert.Equal(t, "0.75", change.Before.CollateralFactor.String())
+		assert.Equal(t, "0.6", change.After.CollateralFactor.String())
+		// compared by the markets accrued to now
+		assert.Greater(t, change.Before.BlockNumber, block)
+		assert.True(t, change.Before.BorrowIndex.GreaterThan(decimal.New(1, 0)))
+	}
+
+	if assert.Len(t, simulation.Liquidatable, 1) {
+		account := simulation.Liquidatable[0]
+		assert.Equal(t, alice, account.UserID)
+		// the interest accrued since the block is counted
+		assert.True(t, account.LiquidityBefore.LessThan(decimal.NewFromInt(2500)), account.LiquidityBefore.String())
+		assert.True(t, account.LiquidityBefore.GreaterThan(decimal.NewFromInt(2490)), account.LiquidityBefore.String())
+		assert.True(t, account.LiquidityAfter.LessThan(decimal.NewFromInt(-2000)), account.LiquidityAfter.String())
+	}
+
+	// a new market
+	req.Symbol = "ETH"
+	req.AssetID = uuid.Must(uuid.NewV4()).String()
+	req.CTokenAssetID = uuid.Must(uuid.NewV4()).String()
+	code, simulation = simulate(req)
+	require.Equal(t, http.StatusOK, code)
+	if assert.Len(t, simulation.Markets, 1) {
+		assert.Nil(t, simulation.Markets[0].Before)
+		assert.Equal(t, core.MarketStatusClose, simulation.Markets[0].After.Status)
+	}
+	assert.Empty(t, simulation.Liquidatable)
+
+	// not a proposal memo
+	code, _ = post([]byte("not a proposal"))
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"compound/core"
+	"compound/handler/param"
+	"compound/handler/render"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type reservePeriod struct {
+	Time      time.Time       `json:"time"`
+	Accrued   decimal.Decimal `json:"accrued"`
+	Withdrawn decimal.Decimal `json:"withdrawn"`
+	Blocks    int64           `json:"blocks"`
+}
+
+// response the reserve entries of the market aggregated by period
+func reservesHandler(marketStr core.IMarketStore, reserveStr core.ReserveStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var params struct {
+			AssetID string `json:"asset_id"`
+			From    string `json:"from"`
+			To      string `json:"to"`
+			Period  string `json:"period"`
+		}
+
+		if e := param.Binding(r, &params); e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		market, e := marketStr.Find(ctx, params.AssetID)
+		if e != nil {
+			render.BadRequest(w, e)
+			return
+		} else if market.ID == 0 {
+			render.NotFoundRequest(w, errors.New("market not found"))
+			return
+		}
+
+		to, err := time.Parse(time.RFC3339Nano, params.To)
+		if err != nil {
+			to = time.Now()
+		}
+
+		from, err := time.Parse(time.RFC3339Nano, params.From)
+		if err != nil {
+			from = to.AddDate(0, 0, -30)
+		}
+
+		if params.Period == "" {
+			params.Period = "day"
+		}
+
+		truncate, ok := periodTruncates[params.Period]
+		if !ok {
+			render.BadRequest(w, errors.New("invalid period, must be one of hour, day, week and month"))
+			return
+		}
+
+		entries, e := reserveStr.List(ctx, market.AssetID, from, to)
+		if e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		periods := make([]*reservePeriod, 0)
+		for _, entry := range entries {
+			t := truncate(entry.CreatedAt.UTC())
+			if len(periods) == 0 || !periods[len(periods)-1].Time.Equal(t) {
+				periods = append(periods, &reservePeriod{Time: t})
+			}
+
+			period := periods[len(periods)-1]
+			switch entry.Source {
+			case core.ReserveSourceAccrual:
+				period.Accrued = period.Accrued.Add(entry.Amount)
+				period.Blocks += entry.ToBlock - entry.FromBlock
+			case core.ReserveSourceWithdraw:
+				period.Withdrawn = period.Withdrawn.Add(entry.Amount.Abs())
+			}
+		}
+
+		render.JSON(w, render.H{
+			"data": render.H{
+				"asset_id": market.AssetID,
+				"symbol":   market.Symbol,
+				"reserves": market.Reserves,
+				"period":   params.Period,
+				"periods":  periods,
+			},
+		})
+	}
+}
+
+var periodTruncates = map[string]func(t time.Time) time.Time{
+	"hour": func(t time.Time) time.Time {
+		return t.Truncate(time.Hour)
+	},
+	"day": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	},
+	"week": func(t time.Time) time.Time {
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		// weeks start on monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	},
+	"month": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	},
+}
@@ -3,10 +3,13 @@ package rest
 import (
 	"compound/core"
 	"compound/handler/render"
+	"compound/handler/stream"
 	"errors"
 	"net/http"
 
+	"github.com/fox-one/pkg/property"
 	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
 )
 
 // Handle handle rest api request
@@ -20,6 +23,13 @@ func Handle(
 	oracleSignerStore core.OracleSignerStore,
 	proposals core.ProposalStore,
 	proposalz core.ProposalService,
+	accountz core.IAccountService,
+	candidates core.LiquidationCandidateStore,
+	reserves core.ReserveStore,
+	snapshots core.MarketSnapshotStore,
+	priceTicks core.PriceTickStore,
+	properties property.Store,
+	hub *stream.Hub,
 ) http.Handler {
 
 	router := chi.NewRouter()
@@ -30,11 +40,22 @@ func Handle(
 
 	router.Get("/transactions", transactionsHandler(transactionStore))
 	router.Get("/price-requests", priceRequestsHandler(system, marketStore, oracleSignerStore))
+	router.Get("/oracle-signers", oracleSignersHandler(oracleSignerStore))
 	router.Get("/markets/all", allMarketsHandler(marketStore, supplyStore, borrowStore))
+	router.Get("/markets/{asset_id}/reserves", reservesHandler(marketStore, reserves))
+	router.Get("/markets/{asset_id}/history", marketHistoryHandler(marketStore, snapshots))
+	router.Get("/markets/{asset_id}/prices", priceTicksHandler(marketStore, priceTicks))
 	router.Post("/pay-requests", payRequestsHandler(system, dapp))
+	router.Get("/accounts/{user_id}", accountHandler(accountz))
+	router.Get("/users/{user_id}/transactions", userTransactionsHandler(transactionStore))
+	router.Get("/liquidations/candidates", liquidationCandidatesHandler(candidates))
 
 	router.Get("/proposals", handleProposals(proposals, proposalz))
 	router.Get("/proposals/{trace_id}", handleProposal(proposals, proposalz))
+	// the simulation loads all the positions, one at a time
+	router.With(middleware.Throttle(1)).Post("/proposals/simulate", simulateProposalHandler(marketStore, supplyStore, borrowStore, properties))
+
+	router.Get("/stream", stream.Handle(hub))
 
 	return router
 }
@@ -4,8 +4,13 @@ import (
 	"compound/core"
 	"compound/handler/param"
 	"compound/handler/render"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/gofrs/uuid"
 )
 
 // response user transactions
@@ -42,3 +47,92 @@ func transactionsHandler(transactionStr core.TransactionStore) http.HandlerFunc
 		render.JSON(w, transactions)
 	}
 }
+
+// response the transactions of the user, newest first
+func userTransactionsHandler(transactionStr core.TransactionStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID := param.String(r, "user_id")
+		if _, err := uuid.FromString(userID); err != nil {
+			render.BadRequest(w, errors.New("invalid user id"))
+			return
+		}
+
+		var params struct {
+			Action string `json:"action"`
+			Asset  string `json:"asset"`
+			Follow string `json:"follow"`
+			From   string `json:"from"`
+			To     string `json:"to"`
+			Cursor int64  `json:"cursor"`
+			Limit  int    `json:"limit"`
+		}
+
+		if e := param.Binding(r, &params); e != nil {
+			render.BadRequest(w, e)
+			return
+		}
+
+		filter := core.TransactionFilter{
+			AssetID:  params.Asset,
+			FollowID: params.Follow,
+			Cursor:   params.Cursor,
+			Limit:    params.Limit,
+		}
+
+		if filter.Limit <= 0 || filter.Limit > 500 {
+			filter.Limit = 50
+		}
+
+		for _, name := range strings.Split(params.Action, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+
+			action := core.ParseActionType(name)
+			if action == core.ActionTypeDefault {
+				render.BadRequest(w, fmt.Errorf("invalid action %q", name))
+				return
+			}
+
+			filter.Actions = append(filter.Actions, action)
+		}
+
+		var err error
+		if params.From != "" {
+			if filter.From, err = time.Parse(time.RFC3339Nano, params.From); err != nil {
+				render.BadRequest(w, fmt.Errorf("invalid from %q", params.From))
+				return
+			}
+		}
+
+		if params.To != "" {
+			if filter.To, err = time.Parse(time.RFC3339Nano, params.To); err != nil {
+				render.BadRequest(w, fmt.Errorf("invalid to %q", params.To))
+				return
+			}
+		}
+
+		transactions, err := transactionStr.ListByUser(ctx, userID, filter)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		var nextCursor string
+		if len(transactions) == filter.Limit {
+			nextCursor = fmt.Sprint(transactions[len(transactions)-1].ID)
+		}
+
+		render.JSON(w, render.H{
+			"data": render.H{
+				"transactions": transactions,
+				"pagination": render.H{
+					"next_cursor": nextCursor,
+					"has_next":    nextCursor != "",
+				},
+			},
+		})
+	}
+}
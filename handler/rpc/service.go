@@ -12,6 +12,7 @@ import (
 	uuidutil "github.com/fox-one/pkg/uuid"
 	"github.com/gofrs/uuid"
 	"github.com/shopspring/decimal"
+	"github.com/twitchtv/twirp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -23,6 +24,7 @@ type RPCService struct {
 	OracleSignerStore core.OracleSignerStore
 	SupplyStore       core.ISupplyStore
 	BorrowStore       core.IBorrowStore
+	AccountService    core.IAccountService
 }
 
 func NewServiceImpl(system *core.System,
@@ -32,6 +34,7 @@ func NewServiceImpl(system *core.System,
 	oracleSignerStr core.OracleSignerStore,
 	supplyStr core.ISupplyStore,
 	borrowStr core.IBorrowStore,
+	accountz core.IAccountService,
 ) *RPCService {
 	return &RPCService{
 		System:            system,
@@ -41,6 +44,7 @@ func NewServiceImpl(system *core.System,
 		OracleSignerStore: oracleSignerStr,
 		SupplyStore:       supplyStr,
 		BorrowStore:       borrowStr,
+		AccountService:    accountz,
 	}
 }
 
@@ -98,6 +102,7 @@ func (s *RPCService) AllMarkets(ctx context.Context, req *MarketReq) (*MarketLis
 			Borrowers:            countOfBorrows,
 			SupplyApy:            supplyRate.String(),
 			BorrowApy:            borrowRate.String(),
+			SupplyCap:            m.SupplyCap.String(),
 		}
 		marketViews = append(marketViews, &marketView)
 	}
@@ -130,7 +135,7 @@ func (s *RPCService) PriceRequest(ctx context.Context, req *PriceReq) (*PriceReq
 	signers := make([]*PriceSigner, len(ss))
 	for idx, s := range ss {
 		signers[idx] = &PriceSigner{
-			Index:     int32(idx) + 1,
+			Index:     int32(s.SignerIndex),
 			VerifyKey: s.PublicKey,
 		}
 	}
@@ -176,7 +181,59 @@ func (s *RPCService) Transactions(ctx context.Context, req *TransactionReq) (*Tr
 
 	fmt.Println("transactions.len:", len(transactions))
 
-	items := make([]*Transaction, 0)
+	resp := TransactionListResp{
+		Data: transactionViews(transactions),
+	}
+	return &resp, nil
+}
+
+func (s *RPCService) UserTransactions(ctx context.Context, req *UserTransactionReq) (*UserTransactionListResp, error) {
+	if _, e := uuid.FromString(req.UserId); e != nil {
+		return nil, twirp.InvalidArgumentError("user_id", "invalid user id")
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 500
+	}
+
+	filter := core.TransactionFilter{
+		AssetID:  req.AssetId,
+		FollowID: req.FollowId,
+		Cursor:   req.Cursor,
+		Limit:    int(limit),
+	}
+
+	for _, action := range req.Actions {
+		filter.Actions = append(filter.Actions, core.ActionType(action))
+	}
+
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+
+	transactions, e := s.TransactionStore.ListByUser(ctx, req.UserId, filter)
+	if e != nil {
+		return nil, e
+	}
+
+	resp := UserTransactionListResp{
+		Data: transactionViews(transactions),
+	}
+
+	if len(transactions) == int(limit) {
+		resp.NextCursor = transactions[len(transactions)-1].ID
+	}
+
+	return &resp, nil
+}
+
+func transactionViews(transactions []*core.Transaction) []*Transaction {
+	items := make([]*Transaction, 0, len(transactions))
 	for _, t := range transactions {
 		i := Transaction{
 			Id:              t.ID,
@@ -192,10 +249,8 @@ func (s *RPCService) Transactions(ctx context.Context, req *TransactionReq) (*Tr
 		}
 		items = append(items, &i)
 	}
-	resp := TransactionListResp{
-		Data: items,
-	}
-	return &resp, nil
+
+	return items
 }
 
 func (s *RPCService) PayRequest(ctx context.Context, req *PayReq) (*PayResp, error) {
@@ -264,25 +319,63 @@ func (s *RPCService) PayRequest(ctx context.Context, req *PayReq) (*PayResp, err
 
 // CurBorrowRate current borrow APY
 func CurBorrowRate(market *core.Market) decimal.Decimal {
-	borrowRatePerBlock := compound.GetBorrowRatePerBlock(
-		compound.UtilizationRate(market.TotalCash, market.TotalBorrows, market.Reserves),
-		market.BaseRate,
-		market.Multiplier,
-		market.JumpMultiplier,
-		market.Kink,
-	)
+	borrowRatePerBlock := compound.BorrowRatePerBlock(market)
 	return borrowRatePerBlock.Mul(compound.BlocksPerYear).Truncate(compound.MaxPricision)
 }
 
 // CurSupplyRate current supply APY
 func CurSupplyRate(market *core.Market) decimal.Decimal {
-	supplyRatePerBlock := compound.GetSupplyRatePerBlock(
-		compound.UtilizationRate(market.TotalCash, market.TotalBorrows, market.Reserves),
-		market.BaseRate,
-		market.Multiplier,
-		market.JumpMultiplier,
-		market.Kink,
-		market.ReserveFactor,
-	)
+	supplyRatePerBlock := compound.SupplyRatePerBlock(market)
 	return supplyRatePerBlock.Mul(compound.BlocksPerYear).Truncate(compound.MaxPricision)
 }
+
+func (s *RPCService) Account(ctx context.Context, req *AccountReq) (*AccountResp, error) {
+	if _, e := uuid.FromString(req.UserId); e != nil {
+		return nil, twirp.InvalidArgumentError("user_id", "invalid user id")
+	}
+
+	snapshot, e := s.AccountService.Snapshot(ctx, req.UserId)
+	if e != nil {
+		return nil, e
+	}
+
+	supplies := make([]*AccountSupply, 0)
+	for _, supply := range snapshot.Supplies {
+		supplies = append(supplies, &AccountSupply{
+			AssetId:          supply.AssetID,
+			CtokenAssetId:    supply.CTokenAssetID,
+			Symbol:           supply.Symbol,
+			Collaterals:      supply.Collaterals.String(),
+			Underlying:       supply.Underlying.String(),
+			Price:            supply.Price.String(),
+			Value:            supply.Value.String(),
+			CollateralValue:  supply.CollateralValue.String(),
+			LiquidationPrice: supply.LiquidationPrice.String(),
+		})
+	}
+
+	borrows := make([]*AccountBorrow, 0)
+	for _, borrow := range snapshot.Borrows {
+		borrows = append(borrows, &AccountBorrow{
+			AssetId:       borrow.AssetID,
+			Symbol:        borrow.Symbol,
+			Principal:     borrow.Principal.String(),
+			InterestIndex: borrow.InterestIndex.String(),
+			Balance:       borrow.Balance.String(),
+			Price:         borrow.Price.String(),
+			Value:         borrow.Value.String(),
+		})
+	}
+
+	resp := AccountResp{
+		UserId:          snapshot.UserID,
+		Supplies:        supplies,
+		Borrows:         borrows,
+		CollateralValue: snapshot.CollateralValue.String(),
+		BorrowValue:     snapshot.BorrowValue.String(),
+		Liquidity:       snapshot.Liquidity.String(),
+		HealthFactor:    snapshot.HealthFactor.String(),
+	}
+
+	return &resp, nil
+}
@@ -40,6 +40,10 @@ type Compound interface {
 	Transactions(context.Context, *TransactionReq) (*TransactionListResp, error)
 
 	PayRequest(context.Context, *PayReq) (*PayResp, error)
+
+	Account(context.Context, *AccountReq) (*AccountResp, error)
+
+	UserTransactions(context.Context, *UserTransactionReq) (*UserTransactionListResp, error)
 }
 
 // ========================
@@ -48,7 +52,7 @@ type Compound interface {
 
 type compoundProtobufClient struct {
 	client      HTTPClient
-	urls        [4]string
+	urls        [6]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -76,11 +80,13 @@ func NewCompoundProtobufClient(baseURL string, client HTTPClient, opts ...twirp.
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "", "Compound")
-	urls := [4]string{
+	urls := [6]string{
 		serviceURL + "AllMarkets",
 		serviceURL + "PriceRequest",
 		serviceURL + "Transactions",
 		serviceURL + "PayRequest",
+		serviceURL + "Account",
+		serviceURL + "UserTransactions",
 	}
 
 	return &compoundProtobufClient{
@@ -275,13 +281,105 @@ func (c *compoundProtobufClient) callPayRequest(ctx context.Context, in *PayReq)
 	return out, nil
 }
 
+func (c *compoundProtobufClient) Account(ctx context.Context, in *AccountReq) (*AccountResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "")
+	ctx = ctxsetters.WithServiceName(ctx, "Compound")
+	ctx = ctxsetters.WithMethodName(ctx, "Account")
+	caller := c.callAccount
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *AccountReq) (*AccountResp, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AccountReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AccountReq) when calling interceptor")
+					}
+					return c.callAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AccountResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AccountResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *compoundProtobufClient) callAccount(ctx context.Context, in *AccountReq) (*AccountResp, error) {
+	out := new(AccountResp)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *compoundProtobufClient) UserTransactions(ctx context.Context, in *UserTransactionReq) (*UserTransactionListResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "")
+	ctx = ctxsetters.WithServiceName(ctx, "Compound")
+	ctx = ctxsetters.WithMethodName(ctx, "UserTransactions")
+	caller := c.callUserTransactions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UserTransactionReq) (*UserTransactionListResp, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UserTransactionReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UserTransactionReq) when calling interceptor")
+					}
+					return c.callUserTransactions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UserTransactionListResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UserTransactionListResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *compoundProtobufClient) callUserTransactions(ctx context.Context, in *UserTransactionReq) (*UserTransactionListResp, error) {
+	out := new(UserTransactionListResp)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ====================
 // Compound JSON Client
 // ====================
 
 type compoundJSONClient struct {
 	client      HTTPClient
-	urls        [4]string
+	urls        [6]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -309,11 +407,13 @@ func NewCompoundJSONClient(baseURL string, client HTTPClient, opts ...twirp.Clie
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "", "Compound")
-	urls := [4]string{
+	urls := [6]string{
 		serviceURL + "AllMarkets",
 		serviceURL + "PriceRequest",
 		serviceURL + "Transactions",
 		serviceURL + "PayRequest",
+		serviceURL + "Account",
+		serviceURL + "UserTransactions",
 	}
 
 	return &compoundJSONClient{
@@ -508,6 +608,98 @@ func (c *compoundJSONClient) callPayRequest(ctx context.Context, in *PayReq) (*P
 	return out, nil
 }
 
+func (c *compoundJSONClient) Account(ctx context.Context, in *AccountReq) (*AccountResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "")
+	ctx = ctxsetters.WithServiceName(ctx, "Compound")
+	ctx = ctxsetters.WithMethodName(ctx, "Account")
+	caller := c.callAccount
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *AccountReq) (*AccountResp, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AccountReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AccountReq) when calling interceptor")
+					}
+					return c.callAccount(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AccountResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AccountResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *compoundJSONClient) callAccount(ctx context.Context, in *AccountReq) (*AccountResp, error) {
+	out := new(AccountResp)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *compoundJSONClient) UserTransactions(ctx context.Context, in *UserTransactionReq) (*UserTransactionListResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "")
+	ctx = ctxsetters.WithServiceName(ctx, "Compound")
+	ctx = ctxsetters.WithMethodName(ctx, "UserTransactions")
+	caller := c.callUserTransactions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UserTransactionReq) (*UserTransactionListResp, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UserTransactionReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UserTransactionReq) when calling interceptor")
+					}
+					return c.callUserTransactions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UserTransactionListResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UserTransactionListResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *compoundJSONClient) callUserTransactions(ctx context.Context, in *UserTransactionReq) (*UserTransactionListResp, error) {
+	out := new(UserTransactionListResp)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // =======================
 // Compound Server Handler
 // =======================
@@ -617,6 +809,12 @@ func (s *compoundServer) ServeHTTP(resp http.ResponseWriter, req *http.Request)
 	case "PayRequest":
 		s.servePayRequest(ctx, resp, req)
 		return
+	case "Account":
+		s.serveAccount(ctx, resp, req)
+		return
+	case "UserTransactions":
+		s.serveUserTransactions(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
@@ -1344,6 +1542,366 @@ func (s *compoundServer) servePayRequestProtobuf(ctx context.Context, resp http.
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *compoundServer) serveAccount(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveAccountJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveAccountProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *compoundServer) serveAccountJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Account")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(AccountReq)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Compound.Account
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *AccountReq) (*AccountResp, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AccountReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AccountReq) when calling interceptor")
+					}
+					return s.Compound.Account(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AccountResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AccountResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *AccountResp
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AccountResp and nil error while calling Account. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *compoundServer) serveAccountProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Account")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(AccountReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Compound.Account
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *AccountReq) (*AccountResp, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*AccountReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*AccountReq) when calling interceptor")
+					}
+					return s.Compound.Account(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*AccountResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*AccountResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *AccountResp
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AccountResp and nil error while calling Account. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *compoundServer) serveUserTransactions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUserTransactionsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUserTransactionsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *compoundServer) serveUserTransactionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UserTransactions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UserTransactionReq)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Compound.UserTransactions
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UserTransactionReq) (*UserTransactionListResp, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UserTransactionReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UserTransactionReq) when calling interceptor")
+					}
+					return s.Compound.UserTransactions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UserTransactionListResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UserTransactionListResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UserTransactionListResp
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UserTransactionListResp and nil error while calling UserTransactions. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *compoundServer) serveUserTransactionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UserTransactions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UserTransactionReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Compound.UserTransactions
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UserTransactionReq) (*UserTransactionListResp, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UserTransactionReq)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UserTransactionReq) when calling interceptor")
+					}
+					return s.Compound.UserTransactions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UserTransactionListResp)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UserTransactionListResp) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UserTransactionListResp
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UserTransactionListResp and nil error while calling UserTransactions. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *compoundServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -1928,83 +2486,108 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 1246 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5b, 0x6f, 0x1b, 0x45,
-	0x14, 0x96, 0x73, 0xf1, 0xe5, 0xf8, 0x9a, 0x69, 0xda, 0x4e, 0xdd, 0x4b, 0x5c, 0x57, 0x94, 0x14,
-	0xca, 0x56, 0xa4, 0x14, 0xc4, 0x0b, 0x92, 0x53, 0x41, 0x15, 0x41, 0x4b, 0xb5, 0x0d, 0x2f, 0x7d,
-	0x59, 0x8d, 0x77, 0xc7, 0xf6, 0x90, 0xf5, 0xce, 0x66, 0x66, 0x36, 0xad, 0xf9, 0x53, 0x08, 0x89,
-	0x37, 0x24, 0x5e, 0xf9, 0x5b, 0x68, 0xce, 0x8c, 0x9d, 0x75, 0x02, 0xa9, 0x90, 0x78, 0xdb, 0xf3,
-	0x9d, 0x73, 0x66, 0xce, 0xf5, 0x9b, 0x85, 0xb6, 0xe6, 0xea, 0x4c, 0xc4, 0x3c, 0xc8, 0x95, 0x34,
-	0xb2, 0xbf, 0x37, 0x95, 0x72, 0x9a, 0xf2, 0x27, 0x28, 0x8d, 0x8b, 0xc9, 0x13, 0x23, 0xe6, 0x5c,
-	0x1b, 0x36, 0xcf, 0x9d, 0xc1, 0xb0, 0x09, 0x8d, 0x97, 0x4c, 0x9d, 0x70, 0x13, 0xf2, 0xd3, 0xe1,
-	0x9f, 0x0d, 0xa8, 0x3a, 0x89, 0x74, 0x60, 0x43, 0x24, 0xb4, 0x32, 0xa8, 0xec, 0x6f, 0x86, 0x1b,
-	0x22, 0x21, 0xb7, 0xa0, 0xce, 0xb4, 0xe6, 0x26, 0x12, 0x09, 0xdd, 0x18, 0x54, 0xf6, 0x1b, 0x61,
-	0x0d, 0xe5, 0xa3, 0x84, 0xdc, 0x80, 0xaa, 0x5e, 0xcc, 0xc7, 0x32, 0xa5, 0x9b, 0xa8, 0xf0, 0x12,
-	0x79, 0x08, 0xdd, 0xd8, 0xc8, 0x13, 0x9e, 0x45, 0x2b, 0xcf, 0x2d, 0x34, 0x68, 0x3b, 0x78, 0xe4,
-	0xfd, 0xef, 0x02, 0x18, 0x69, 0x58, 0x1a, 0xc5, 0x4c, 0xcf, 0xe8, 0x36, 0x9a, 0x34, 0x10, 0x79,
-	0xce, 0xf4, 0x8c, 0x3c, 0x80, 0xb6, 0x53, 0x8f, 0xa5, 0x52, 0xf2, 0x9d, 0xa6, 0x55, 0xb4, 0x68,
-	0x21, 0x78, 0xe8, 0x30, 0xd2, 0x87, 0xba, 0xe2, 0x36, 0x75, 0xae, 0x69, 0x0d, 0xf5, 0x2b, 0x99,
-	0x50, 0xa8, 0xb9, 0x0b, 0x35, 0xad, 0xbb, 0xc8, 0xbd, 0x48, 0x1e, 0x03, 0x11, 0x99, 0x30, 0x11,
-	0x7f, 0x1f, 0xcf, 0x58, 0x36, 0xe5, 0x91, 0x62, 0x86, 0xd3, 0x06, 0x1a, 0xf5, 0xac, 0xe6, 0x5b,
-	0xaf, 0x08, 0x99, 0xe1, 0xe4, 0x23, 0xe8, 0xf8, 0x33, 0xa3, 0x09, 0x8b, 0x8d, 0x54, 0x14, 0x5c,
-	0x3a, 0x1e, 0xfd, 0x0e, 0x41, 0xf2, 0x14, 0xae, 0xa7, 0xe2, 0xb4, 0x10, 0x09, 0x33, 0x42, 0x66,
-	0x91, 0xc8, 0x62, 0x9e, 0x19, 0x71, 0xc6, 0x69, 0x13, 0xad, 0x77, 0x4b, 0xca, 0xa3, 0xa5, 0xce,
-	0xd6, 0xc0, 0xa5, 0x17, 0xc5, 0x2c, 0xa7, 0x2d, 0x57, 0x03, 0x87, 0x3c, 0x67, 0x39, 0xf9, 0x14,
-	0x76, 0x62, 0x99, 0xa6, 0xcc, 0x70, 0xc5, 0xd2, 0xe5, 0xed, 0x6d, 0x17, 0xe7, 0xb9, 0xc2, 0x07,
-	0x70, 0x1f, 0x5a, 0x71, 0x2a, 0xf5, 0x2a, 0xca, 0x0e, 0xda, 0x35, 0x11, 0xf3, 0x26, 0xb7, 0xa1,
-	0x31, 0x66, 0xda, 0xe7, 0xdb, 0x75, 0xf5, 0xb2, 0x00, 0xe6, 0x79, 0x0f, 0x60, 0x5e, 0xa4, 0x46,
-	0xe4, 0xa9, 0xe0, 0x8a, 0xf6, 0x50, 0x5b, 0x42, 0xc8, 0xc7, 0xd0, 0xfd, 0xb9, 0x98, 0xe7, 0x51,
-	0xc9, 0x68, 0x07, 0x8d, 0x3a, 0x16, 0x7e, 0x79, 0x6e, 0x48, 0x60, 0xeb, 0x44, 0x64, 0x27, 0x94,
-	0xa0, 0x16, 0xbf, 0x6d, 0x70, 0xe3, 0x54, 0xc6, 0x27, 0x51, 0x56, 0xcc, 0xc7, 0x5c, 0xd1, 0x6b,
-	0x38, 0x61, 0x4d, 0xc4, 0x5e, 0x21, 0x44, 0x1e, 0x41, 0xaf, 0x30, 0x22, 0x15, 0xbf, 0xb8, 0x02,
-	0x62, 0x8c, 0xbb, 0x78, 0x44, 0xb7, 0x84, 0x63, 0xa8, 0x0f, 0xa0, 0xbd, 0xde, 0xbb, 0xeb, 0x6e,
-	0x36, 0x78, 0xb9, 0x6f, 0x9f, 0xc3, 0x75, 0x5d, 0xe4, 0x79, 0xba, 0x40, 0x93, 0x28, 0xe7, 0x2a,
-	0xc2, 0xeb, 0xe8, 0x0d, 0x34, 0x26, 0x4e, 0x69, 0x4d, 0x5f, 0x73, 0x75, 0x68, 0x35, 0xd6, 0xc5,
-	0xb7, 0xe3, 0x82, 0xcb, 0x4d, 0xe7, 0xe2, 0x94, 0x6b, 0x2e, 0xbb, 0xb0, 0x9d, 0x2b, 0x11, 0x73,
-	0x4a, 0xd1, 0xc4, 0x09, 0xe4, 0x10, 0xba, 0xf8, 0x11, 0x15, 0x79, 0x62, 0x4f, 0x62, 0x86, 0xde,
-	0x1a, 0x54, 0xf6, 0x9b, 0x07, 0xfd, 0xc0, 0x6d, 0x66, 0xb0, 0xdc, 0xcc, 0xe0, 0x78, 0xb9, 0x99,
-	0x61, 0x1b, 0x5d, 0x7e, 0x42, 0x8f, 0x91, 0xc1, 0x92, 0xb9, 0x60, 0x44, 0x96, 0xf0, 0xf7, 0xb4,
-	0xef, 0xfa, 0xe9, 0xb0, 0x23, 0x0b, 0xe1, 0x0a, 0x1a, 0x66, 0x0a, 0x4d, 0x6f, 0x0f, 0x2a, 0xfb,
-	0xdb, 0xa1, 0x97, 0xc8, 0xd7, 0x00, 0xb1, 0xe2, 0xcc, 0xf0, 0xc4, 0xde, 0x7c, 0xe7, 0x83, 0x37,
-	0x37, 0xbc, 0xf5, 0xc8, 0x58, 0x57, 0x17, 0x33, 0xba, 0xde, 0xfd, 0xb0, 0xab, 0xb7, 0x1e, 0x19,
-	0x72, 0x07, 0x1a, 0x58, 0x53, 0xc1, 0x95, 0xa6, 0xf7, 0xb0, 0xc1, 0xe7, 0x80, 0xd5, 0xba, 0xd0,
-	0xad, 0x76, 0xcf, 0x69, 0x57, 0x80, 0x5d, 0x04, 0xdf, 0x2c, 0x96, 0x2f, 0xe8, 0xc0, 0x2d, 0x82,
-	0x43, 0x46, 0xf9, 0xa2, 0xb4, 0x27, 0x56, 0x7d, 0xbf, 0xbc, 0x27, 0xa3, 0x7c, 0x31, 0xfc, 0x0c,
-	0x3a, 0x8e, 0xbf, 0x7e, 0x10, 0xda, 0x84, 0x5c, 0xe7, 0xe4, 0x36, 0x6c, 0x25, 0xcc, 0x30, 0x5a,
-	0x19, 0x6c, 0xee, 0x37, 0x0f, 0x6a, 0x81, 0x27, 0x3b, 0x04, 0x87, 0x00, 0xf5, 0xd7, 0xb6, 0xd4,
-	0x96, 0xfb, 0x5e, 0x40, 0xdb, 0x7f, 0xc7, 0x5c, 0x9c, 0x71, 0x65, 0x69, 0x63, 0xce, 0xed, 0x40,
-	0x6a, 0x74, 0x6e, 0x84, 0x4b, 0xd1, 0x66, 0x60, 0x66, 0x8a, 0xeb, 0x99, 0x4c, 0x1d, 0x19, 0x6e,
-	0x87, 0xe7, 0xc0, 0xf0, 0x10, 0x9a, 0x78, 0xd0, 0x1b, 0x31, 0xcd, 0xb8, 0xb2, 0x73, 0xe1, 0xda,
-	0x56, 0x41, 0x43, 0x27, 0xd8, 0x3c, 0xce, 0xb8, 0x12, 0x93, 0x45, 0x74, 0xc2, 0x17, 0x9e, 0x50,
-	0x1b, 0x0e, 0xf9, 0x9e, 0x2f, 0x86, 0x7f, 0x55, 0x60, 0x1b, 0x0f, 0x59, 0xe3, 0xdd, 0xca, 0xbf,
-	0xf1, 0xee, 0xc6, 0x1a, 0xef, 0xde, 0x82, 0xba, 0x51, 0x2c, 0xe6, 0xd6, 0xc5, 0x31, 0x72, 0x0d,
-	0xe5, 0xa3, 0x84, 0x7c, 0x62, 0x69, 0xd2, 0xe5, 0x87, 0x5c, 0xdc, 0x3c, 0xe8, 0x04, 0x6b, 0x59,
-	0x87, 0x2b, 0x3d, 0x79, 0x08, 0x35, 0x8d, 0x29, 0x68, 0xba, 0x8d, 0xc5, 0x6b, 0x05, 0xa5, 0xbc,
-	0xc2, 0xa5, 0x72, 0xbd, 0x1a, 0xd5, 0x8b, 0xd5, 0x08, 0xa0, 0xb7, 0x2c, 0x71, 0xc1, 0x7d, 0x4f,
-	0xfa, 0x6b, 0x3d, 0xa9, 0xfa, 0x08, 0x5c, 0x4b, 0xde, 0x42, 0xe7, 0x58, 0xb1, 0x4c, 0xb3, 0x18,
-	0x97, 0x9c, 0x9f, 0x92, 0x03, 0xa8, 0xca, 0xc9, 0x44, 0x73, 0x83, 0xf9, 0x5f, 0x3d, 0x84, 0xde,
-	0xd2, 0x16, 0x3d, 0x15, 0x73, 0x61, 0x7c, 0x77, 0x9c, 0x30, 0xfc, 0x7d, 0x03, 0x9a, 0xa5, 0xc3,
-	0x2f, 0xbd, 0x71, 0x37, 0xa0, 0xea, 0x34, 0xde, 0xcd, 0x4b, 0x57, 0x15, 0xf4, 0x26, 0xd4, 0x0a,
-	0xcd, 0xd5, 0xf9, 0xdb, 0x56, 0xb5, 0xe2, 0x51, 0x62, 0x19, 0x76, 0x22, 0xd3, 0xd4, 0x2e, 0x6d,
-	0xe2, 0xdf, 0xb4, 0xba, 0x03, 0xb0, 0x0d, 0x3b, 0x3a, 0x63, 0xb9, 0x9e, 0x49, 0x13, 0xad, 0x4e,
-	0x76, 0xcf, 0x5a, 0x77, 0xa9, 0x38, 0xf6, 0x37, 0x94, 0x07, 0xa0, 0x76, 0x69, 0x00, 0xd8, 0x5c,
-	0x16, 0x99, 0xf1, 0xef, 0x9a, 0x97, 0x2c, 0xef, 0x62, 0x7d, 0xed, 0x43, 0xd6, 0x72, 0x75, 0xbd,
-	0xc0, 0x04, 0xf0, 0x1f, 0x98, 0x60, 0xf8, 0x15, 0x5c, 0x2b, 0x55, 0x6d, 0xb5, 0x59, 0x83, 0xb5,
-	0x2e, 0xb6, 0x82, 0x72, 0xdb, 0x5c, 0x2f, 0x7f, 0xad, 0x40, 0xf5, 0x35, 0x5b, 0xd8, 0x26, 0x5e,
-	0x3d, 0xc6, 0x3e, 0x8b, 0x8d, 0xb5, 0x2c, 0xae, 0xa8, 0xfa, 0x5a, 0x71, 0xb7, 0x2e, 0x14, 0x77,
-	0x0f, 0x9a, 0x73, 0x3e, 0x97, 0x91, 0x7d, 0xcf, 0xbe, 0xfc, 0xc2, 0xd7, 0x1e, 0x2c, 0x74, 0x88,
-	0x88, 0x3d, 0xf8, 0x9d, 0x30, 0xb3, 0x68, 0xca, 0xdc, 0xbf, 0x44, 0x3d, 0xac, 0x59, 0xf9, 0x05,
-	0xd3, 0xc3, 0x10, 0x6a, 0x18, 0xb0, 0xce, 0x49, 0x0f, 0x36, 0x0b, 0x95, 0xfa, 0x60, 0xed, 0x27,
-	0x79, 0x06, 0x1d, 0x63, 0x73, 0x9c, 0xd8, 0x7e, 0x67, 0x79, 0xe1, 0x02, 0xb6, 0x2b, 0x74, 0xec,
-	0xe1, 0x23, 0x8b, 0x86, 0x6d, 0x53, 0x16, 0x87, 0xbf, 0x55, 0xa0, 0xbd, 0x66, 0xf0, 0x3f, 0x17,
-	0x83, 0xc0, 0x96, 0x4d, 0xce, 0xd7, 0x01, 0xbf, 0xc9, 0x37, 0xb0, 0x23, 0xf3, 0x5c, 0x66, 0x3c,
-	0x33, 0xee, 0x99, 0xd6, 0x62, 0x8a, 0x95, 0x68, 0x1e, 0xec, 0x04, 0x3f, 0x7a, 0x0d, 0xbe, 0xd4,
-	0x6f, 0xc4, 0x34, 0xec, 0xc9, 0x32, 0xa2, 0xc5, 0x74, 0xf8, 0x0a, 0x7a, 0x17, 0xad, 0xec, 0x9e,
-	0x2f, 0xb9, 0x61, 0xc9, 0x88, 0xe7, 0xc0, 0xd5, 0x9c, 0x78, 0xf0, 0x47, 0x05, 0xea, 0xcf, 0xe5,
-	0x3c, 0x97, 0x45, 0x96, 0x90, 0x47, 0x00, 0xa3, 0x34, 0x75, 0x44, 0xac, 0x09, 0x04, 0xab, 0xff,
-	0xcf, 0x7e, 0x37, 0xb8, 0xc0, 0xde, 0x8f, 0xa1, 0x55, 0x66, 0x0f, 0xd2, 0x58, 0xb2, 0xd5, 0x69,
-	0x7f, 0x27, 0xb8, 0xc4, 0x2b, 0xcf, 0xa0, 0x55, 0x1a, 0x42, 0x4d, 0xba, 0xc1, 0x3a, 0x95, 0xf4,
-	0x77, 0x83, 0x7f, 0x1a, 0xe4, 0x3d, 0x00, 0x37, 0xa5, 0x78, 0x45, 0x2d, 0x70, 0x42, 0xbf, 0x1e,
-	0xf8, 0x51, 0x38, 0xac, 0xbf, 0xad, 0x06, 0xc1, 0x13, 0x95, 0xc7, 0xe3, 0x2a, 0x6e, 0xca, 0xd3,
-	0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x19, 0x7f, 0xfb, 0x27, 0x66, 0x0b, 0x00, 0x00,
+	// 1633 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x5f, 0x6f, 0x23, 0x49,
+	0x11, 0x97, 0xed, 0xf8, 0x5f, 0xf9, 0x6f, 0x7a, 0xb3, 0xbb, 0xb3, 0xde, 0xdc, 0xc5, 0x37, 0xc7,
+	0x1d, 0xb9, 0xbd, 0x63, 0x56, 0xe4, 0x38, 0x10, 0x2f, 0x48, 0x4e, 0x04, 0xa7, 0x08, 0xee, 0x58,
+	0xcd, 0xe6, 0x78, 0x38, 0x21, 0x8d, 0xda, 0xe3, 0xb6, 0xdd, 0x64, 0x3c, 0x33, 0xe9, 0xee, 0xc9,
+	0xae, 0xf9, 0x06, 0x3c, 0xf0, 0x2d, 0x78, 0x07, 0x24, 0xbe, 0x03, 0x4f, 0x7c, 0x04, 0xbe, 0x0b,
+	0xea, 0xea, 0x1e, 0x7b, 0xc6, 0x49, 0xbc, 0x2c, 0xe2, 0x6d, 0xea, 0x57, 0xd5, 0xd3, 0x5d, 0xbf,
+	0xaa, 0xae, 0xaa, 0x86, 0x9e, 0x64, 0xe2, 0x96, 0x87, 0xcc, 0x4b, 0x45, 0xa2, 0x92, 0xd1, 0xc9,
+	0x22, 0x49, 0x16, 0x11, 0x7b, 0x89, 0xd2, 0x34, 0x9b, 0xbf, 0x54, 0x7c, 0xc5, 0xa4, 0xa2, 0xab,
+	0xd4, 0x18, 0xb8, 0x1d, 0x68, 0x7f, 0x43, 0xc5, 0x35, 0x53, 0x3e, 0xbb, 0x71, 0xff, 0xdd, 0x86,
+	0x86, 0x91, 0x48, 0x1f, 0xaa, 0x7c, 0xe6, 0x54, 0xc6, 0x95, 0xd3, 0x9a, 0x5f, 0xe5, 0x33, 0xf2,
+	0x0c, 0x5a, 0x54, 0x4a, 0xa6, 0x02, 0x3e, 0x73, 0xaa, 0xe3, 0xca, 0x69, 0xdb, 0x6f, 0xa2, 0x7c,
+	0x39, 0x23, 0x4f, 0xa0, 0x21, 0xd7, 0xab, 0x69, 0x12, 0x39, 0x35, 0x54, 0x58, 0x89, 0x7c, 0x0a,
+	0x83, 0x50, 0x25, 0xd7, 0x2c, 0x0e, 0x36, 0x2b, 0x0f, 0xd0, 0xa0, 0x67, 0xe0, 0x89, 0x5d, 0xff,
+	0x01, 0x80, 0x4a, 0x14, 0x8d, 0x82, 0x90, 0xca, 0xa5, 0x53, 0x47, 0x93, 0x36, 0x22, 0x17, 0x54,
+	0x2e, 0xc9, 0xc7, 0xd0, 0x33, 0xea, 0x69, 0x22, 0x44, 0xf2, 0x46, 0x3a, 0x0d, 0xb4, 0xe8, 0x22,
+	0x78, 0x6e, 0x30, 0x32, 0x82, 0x96, 0x60, 0xda, 0x75, 0x26, 0x9d, 0x26, 0xea, 0x37, 0x32, 0x71,
+	0xa0, 0x69, 0x36, 0x94, 0x4e, 0xcb, 0x9c, 0xdc, 0x8a, 0xe4, 0x0b, 0x20, 0x3c, 0xe6, 0x2a, 0x60,
+	0x6f, 0xc3, 0x25, 0x8d, 0x17, 0x2c, 0x10, 0x54, 0x31, 0xa7, 0x8d, 0x46, 0x43, 0xad, 0xf9, 0xa5,
+	0x55, 0xf8, 0x54, 0x31, 0xf2, 0x09, 0xf4, 0xed, 0x3f, 0x83, 0x39, 0x0d, 0x55, 0x22, 0x1c, 0x30,
+	0xee, 0x58, 0xf4, 0x57, 0x08, 0x92, 0x2f, 0xe1, 0x71, 0xc4, 0x6f, 0x32, 0x3e, 0xa3, 0x8a, 0x27,
+	0x71, 0xc0, 0xe3, 0x90, 0xc5, 0x8a, 0xdf, 0x32, 0xa7, 0x83, 0xd6, 0x47, 0x05, 0xe5, 0x65, 0xae,
+	0xd3, 0x1c, 0x18, 0xf7, 0x82, 0x90, 0xa6, 0x4e, 0xd7, 0x70, 0x60, 0x90, 0x0b, 0x9a, 0x92, 0xcf,
+	0xe1, 0x30, 0x4c, 0xa2, 0x88, 0x2a, 0x26, 0x68, 0x94, 0xef, 0xde, 0x33, 0xe7, 0xdc, 0x2a, 0xec,
+	0x01, 0x3e, 0x82, 0x6e, 0x18, 0x25, 0x72, 0x73, 0xca, 0x3e, 0xda, 0x75, 0x10, 0xb3, 0x26, 0xcf,
+	0xa1, 0x3d, 0xa5, 0xd2, 0xfa, 0x3b, 0x30, 0x7c, 0x69, 0x00, 0xfd, 0xfc, 0x10, 0x60, 0x95, 0x45,
+	0x8a, 0xa7, 0x11, 0x67, 0xc2, 0x19, 0xa2, 0xb6, 0x80, 0x90, 0x1f, 0xc2, 0xe0, 0x0f, 0xd9, 0x2a,
+	0x0d, 0x0a, 0x46, 0x87, 0x68, 0xd4, 0xd7, 0xf0, 0x37, 0x5b, 0x43, 0x02, 0x07, 0xd7, 0x3c, 0xbe,
+	0x76, 0x08, 0x6a, 0xf1, 0x5b, 0x1f, 0x6e, 0x1a, 0x25, 0xe1, 0x75, 0x10, 0x67, 0xab, 0x29, 0x13,
+	0xce, 0x23, 0xcc, 0xb0, 0x0e, 0x62, 0xdf, 0x22, 0x44, 0x3e, 0x83, 0x61, 0xa6, 0x78, 0xc4, 0xff,
+	0x68, 0x08, 0xc4, 0x33, 0x1e, 0xe1, 0x2f, 0x06, 0x05, 0x1c, 0x8f, 0xfa, 0x31, 0xf4, 0xca, 0xb1,
+	0x7b, 0x6c, 0x72, 0x83, 0x15, 0xe3, 0xf6, 0x63, 0x78, 0x2c, 0xb3, 0x34, 0x8d, 0xd6, 0x68, 0x12,
+	0xa4, 0x4c, 0x04, 0xb8, 0x9d, 0xf3, 0x04, 0x8d, 0x89, 0x51, 0x6a, 0xd3, 0x57, 0x4c, 0x9c, 0x6b,
+	0x8d, 0x5e, 0x62, 0xc3, 0xb1, 0xb3, 0xe4, 0xa9, 0x59, 0x62, 0x94, 0xa5, 0x25, 0x47, 0x50, 0x4f,
+	0x05, 0x0f, 0x99, 0xe3, 0xa0, 0x89, 0x11, 0xc8, 0x39, 0x0c, 0xf0, 0x23, 0xc8, 0xd2, 0x99, 0xfe,
+	0x13, 0x55, 0xce, 0xb3, 0x71, 0xe5, 0xb4, 0x73, 0x36, 0xf2, 0xcc, 0xcd, 0xf4, 0xf2, 0x9b, 0xe9,
+	0x5d, 0xe5, 0x37, 0xd3, 0xef, 0xe1, 0x92, 0xef, 0x70, 0xc5, 0x44, 0x21, 0x65, 0xe6, 0x30, 0x3c,
+	0x9e, 0xb1, 0xb7, 0xce, 0xc8, 0xc4, 0xd3, 0x60, 0x97, 0x1a, 0xc2, 0x2b, 0xa8, 0xa8, 0xca, 0xa4,
+	0xf3, 0x7c, 0x5c, 0x39, 0xad, 0xfb, 0x56, 0x22, 0x3f, 0x07, 0x08, 0x05, 0xa3, 0x8a, 0xcd, 0xf4,
+	0xce, 0xc7, 0xef, 0xdc, 0xb9, 0x6d, 0xad, 0x27, 0x4a, 0x2f, 0x35, 0x67, 0xc6, 0xa5, 0x1f, 0xbc,
+	0x7b, 0xa9, 0xb5, 0x9e, 0x28, 0x72, 0x0c, 0x6d, 0xe4, 0x94, 0x33, 0x21, 0x9d, 0x0f, 0x31, 0xc0,
+	0x5b, 0x40, 0x6b, 0xcd, 0xd1, 0xb5, 0xf6, 0xc4, 0x68, 0x37, 0x80, 0xbe, 0x08, 0x36, 0x58, 0x34,
+	0x5d, 0x3b, 0x63, 0x73, 0x11, 0x0c, 0x32, 0x49, 0xd7, 0x85, 0x7b, 0xa2, 0xd5, 0x1f, 0x15, 0xef,
+	0x89, 0x55, 0xdb, 0xd5, 0xfa, 0x1a, 0xb9, 0xc5, 0xd5, 0x17, 0x34, 0x75, 0x7f, 0x04, 0x7d, 0x53,
+	0xde, 0x7e, 0xc3, 0xa5, 0xf2, 0x99, 0x4c, 0xc9, 0x73, 0x38, 0x98, 0x51, 0x45, 0x9d, 0xca, 0xb8,
+	0x76, 0xda, 0x39, 0x6b, 0x7a, 0xb6, 0x16, 0x22, 0xe8, 0x02, 0xb4, 0x5e, 0xe9, 0x48, 0xe8, 0xd2,
+	0xf8, 0x35, 0xf4, 0xec, 0x77, 0xc8, 0xf8, 0x2d, 0x13, 0xba, 0xaa, 0xac, 0x98, 0xce, 0x57, 0x89,
+	0x8b, 0xdb, 0x7e, 0x2e, 0x6a, 0x07, 0xd5, 0x52, 0x30, 0xb9, 0x4c, 0x22, 0x53, 0x2b, 0xeb, 0xfe,
+	0x16, 0x70, 0xcf, 0xa1, 0x83, 0x3f, 0x7a, 0xcd, 0x17, 0x31, 0x13, 0x3a, 0x6d, 0x4c, 0x54, 0x2b,
+	0x68, 0x68, 0x04, 0xed, 0xc7, 0x2d, 0x13, 0x7c, 0xbe, 0x0e, 0xae, 0xd9, 0xda, 0xd6, 0xdb, 0xb6,
+	0x41, 0x7e, 0xcd, 0xd6, 0xee, 0x3f, 0x2b, 0x50, 0xc7, 0x9f, 0x94, 0xca, 0x72, 0xe5, 0xa1, 0xb2,
+	0x5c, 0x2d, 0x95, 0xe5, 0x67, 0xd0, 0x52, 0x82, 0x86, 0x4c, 0x2f, 0x31, 0x05, 0xbb, 0x89, 0xf2,
+	0xe5, 0x8c, 0xbc, 0xd0, 0x55, 0xd4, 0xf8, 0x87, 0xa5, 0xba, 0x73, 0xd6, 0xf7, 0x4a, 0x5e, 0xfb,
+	0x1b, 0x3d, 0xf9, 0x14, 0x9a, 0x12, 0x5d, 0x90, 0x4e, 0x1d, 0xc9, 0xeb, 0x7a, 0x05, 0xbf, 0xfc,
+	0x5c, 0x59, 0x66, 0xa3, 0xb1, 0xcb, 0x86, 0x07, 0xc3, 0x9c, 0xe2, 0x8c, 0xd9, 0x98, 0x8c, 0x4a,
+	0x31, 0x69, 0xd8, 0x13, 0x98, 0x90, 0x7c, 0x0f, 0xfd, 0x2b, 0x41, 0x63, 0x49, 0x43, 0xac, 0x01,
+	0xec, 0x86, 0x9c, 0x41, 0x23, 0x99, 0xcf, 0x25, 0x53, 0xe8, 0xff, 0xfe, 0x1c, 0xb5, 0x96, 0x9a,
+	0xf4, 0x88, 0xaf, 0xb8, 0xb2, 0xd1, 0x31, 0x82, 0xfb, 0x8f, 0x2a, 0x74, 0x0a, 0x3f, 0xbf, 0xd3,
+	0x02, 0x9f, 0x40, 0xc3, 0x68, 0xec, 0x32, 0x2b, 0xed, 0x23, 0xf4, 0x29, 0x34, 0x33, 0xc9, 0xc4,
+	0xb6, 0xf5, 0x35, 0xb4, 0x78, 0x39, 0xd3, 0x05, 0x78, 0x9e, 0x44, 0x91, 0xbe, 0xd3, 0x33, 0xdb,
+	0xf2, 0x5a, 0x06, 0xc0, 0x30, 0x1c, 0xca, 0x98, 0xa6, 0x72, 0x99, 0xa8, 0x60, 0xf3, 0x67, 0xd3,
+	0xf5, 0x06, 0xb9, 0xe2, 0xca, 0xee, 0x50, 0x4c, 0x80, 0xe6, 0x9d, 0x04, 0xa0, 0xab, 0x24, 0x8b,
+	0x95, 0x6d, 0x7b, 0x56, 0xd2, 0x65, 0x19, 0xf9, 0xd5, 0x7d, 0xae, 0x6b, 0x78, 0xdd, 0x29, 0x14,
+	0xf0, 0x1e, 0x85, 0xc2, 0xfd, 0x19, 0x3c, 0x2a, 0xb0, 0xb6, 0xb9, 0x59, 0xe3, 0x52, 0x14, 0xbb,
+	0x5e, 0x31, 0x6c, 0x26, 0x96, 0x7f, 0xaa, 0x02, 0xf9, 0x4e, 0x32, 0xb1, 0x13, 0xd0, 0x02, 0x67,
+	0x95, 0x12, 0x67, 0x0e, 0x34, 0x8d, 0x95, 0x74, 0xaa, 0xe3, 0xda, 0x69, 0xdd, 0xcf, 0xc5, 0x12,
+	0x09, 0xb5, 0x32, 0x09, 0x25, 0xa2, 0x0f, 0x76, 0x88, 0xf6, 0xe0, 0x60, 0x2e, 0x92, 0x15, 0x06,
+	0x60, 0xbf, 0xbf, 0x68, 0x47, 0x5e, 0x40, 0x55, 0x25, 0x18, 0x89, 0xfd, 0xd6, 0x55, 0x95, 0x68,
+	0xf6, 0xc3, 0x4c, 0xc8, 0x44, 0x60, 0x58, 0x6a, 0xbe, 0x95, 0xb6, 0xb9, 0xd7, 0x2a, 0xe6, 0xde,
+	0xef, 0xe1, 0xe9, 0x0e, 0x15, 0xff, 0x3d, 0x91, 0xe4, 0x04, 0x3a, 0x31, 0x7b, 0xab, 0x02, 0xbb,
+	0x5f, 0x15, 0xf7, 0x03, 0x0d, 0x5d, 0x20, 0xe2, 0xfe, 0xb5, 0x02, 0x8d, 0x57, 0x74, 0xad, 0xd9,
+	0xdd, 0x5f, 0x30, 0x6c, 0xbe, 0x54, 0x4b, 0xf9, 0xb2, 0x27, 0xbf, 0xf7, 0xb2, 0x7b, 0x02, 0x9d,
+	0x15, 0x5b, 0x25, 0x81, 0x1e, 0x2c, 0x7e, 0xfa, 0x13, 0x9b, 0xe5, 0xa0, 0xa1, 0x73, 0x44, 0xf4,
+	0x8f, 0xdf, 0x70, 0xb5, 0x0c, 0x16, 0xd4, 0x0c, 0x75, 0x2d, 0xbf, 0xa9, 0xe5, 0xaf, 0xa9, 0x74,
+	0x7d, 0x68, 0xe2, 0x81, 0x65, 0x4a, 0x86, 0x50, 0xcb, 0x44, 0x64, 0x0f, 0xab, 0x3f, 0xc9, 0x57,
+	0xd0, 0x57, 0x9a, 0x84, 0xb9, 0xce, 0x92, 0x38, 0xcd, 0xcc, 0x81, 0x75, 0xb1, 0xba, 0xb2, 0xf0,
+	0xa5, 0x46, 0xfd, 0x9e, 0x2a, 0x8a, 0xee, 0xdf, 0x2b, 0xd0, 0x2b, 0x19, 0xfc, 0x9f, 0xc9, 0x20,
+	0x70, 0xa0, 0x9d, 0xb3, 0x3c, 0xe0, 0x37, 0xf9, 0x05, 0x1c, 0x26, 0x69, 0x9a, 0xc4, 0x2c, 0x56,
+	0x66, 0x5e, 0x92, 0x7c, 0x61, 0xd3, 0xed, 0xd0, 0xfb, 0xad, 0xd5, 0xe0, 0xc8, 0xf4, 0x9a, 0x2f,
+	0xfc, 0x61, 0x52, 0x44, 0x24, 0x5f, 0xb8, 0xdf, 0xc2, 0x70, 0xd7, 0x4a, 0x57, 0xd4, 0xbc, 0x0a,
+	0xe7, 0xbd, 0x67, 0x0b, 0xbc, 0xa3, 0xfb, 0x7c, 0x02, 0x30, 0x09, 0x43, 0xed, 0xc9, 0xbe, 0xab,
+	0xe6, 0xfe, 0xad, 0x0a, 0x3d, 0x6b, 0xf7, 0x1a, 0xbb, 0xe7, 0x3e, 0xaa, 0xee, 0x99, 0xf3, 0xab,
+	0xf7, 0xcd, 0xf9, 0x0f, 0xbd, 0x13, 0xc6, 0xd0, 0xd9, 0xce, 0xb0, 0xd2, 0xd2, 0x57, 0x84, 0xf4,
+	0x44, 0x9a, 0xc5, 0x33, 0x26, 0xa2, 0x35, 0x8f, 0x17, 0x79, 0x22, 0x6d, 0x91, 0xed, 0xec, 0xd5,
+	0x28, 0xce, 0x5e, 0x47, 0x50, 0xbf, 0xa5, 0x51, 0xc6, 0x6c, 0x5d, 0x34, 0x82, 0x9e, 0x2e, 0x0b,
+	0xa3, 0xb4, 0x31, 0x30, 0xf5, 0x71, 0xb0, 0xc5, 0x7f, 0x87, 0xa6, 0x9f, 0xc3, 0x61, 0x71, 0x92,
+	0x37, 0x5b, 0xd8, 0xd7, 0x41, 0x41, 0x81, 0xfd, 0xc9, 0xfd, 0x57, 0x65, 0x43, 0x99, 0x79, 0x94,
+	0xfc, 0x2f, 0xbd, 0xf9, 0x18, 0xda, 0xa9, 0xe0, 0x71, 0xc8, 0x53, 0x9a, 0xb3, 0xb4, 0x05, 0xf4,
+	0x03, 0x84, 0xc7, 0x8a, 0x09, 0x26, 0x95, 0x1d, 0x05, 0xed, 0x7b, 0x2a, 0x47, 0xcd, 0x30, 0xe8,
+	0x40, 0x73, 0x4a, 0x23, 0x1a, 0x87, 0xcc, 0x52, 0x95, 0x8b, 0xef, 0xc3, 0x93, 0xfb, 0xe7, 0x2a,
+	0x74, 0x36, 0xa9, 0x22, 0xd3, 0x87, 0xcb, 0xf2, 0x0b, 0x68, 0xd9, 0xe1, 0xce, 0xd4, 0x65, 0x7d,
+	0x0f, 0x4b, 0xb9, 0xe3, 0x6f, 0xf4, 0xe4, 0x14, 0x9a, 0xf9, 0x2b, 0xae, 0x56, 0x36, 0x35, 0x9c,
+	0xf9, 0xb9, 0xfa, 0xde, 0x30, 0x1d, 0xdc, 0x1f, 0xa6, 0xed, 0x7c, 0x6c, 0xcc, 0xea, 0xc5, 0xf9,
+	0xd8, 0x98, 0x1c, 0x43, 0xdb, 0x04, 0x8c, 0xab, 0xb5, 0x75, 0x7e, 0x0b, 0xe8, 0x57, 0xc4, 0x92,
+	0xd1, 0x48, 0x2d, 0xf3, 0x17, 0x93, 0x21, 0xa2, 0x6b, 0x40, 0xf3, 0x64, 0x3a, 0xfb, 0x4b, 0x15,
+	0x5a, 0x17, 0xc9, 0x2a, 0x4d, 0xb2, 0x78, 0x46, 0x3e, 0x03, 0x98, 0x44, 0x91, 0x19, 0x16, 0x25,
+	0x01, 0x6f, 0xf3, 0x84, 0x1e, 0x0d, 0xbc, 0x9d, 0x09, 0xf3, 0x0b, 0xe8, 0x16, 0x27, 0x1c, 0xd2,
+	0xce, 0x27, 0xaa, 0x9b, 0xd1, 0xa1, 0x77, 0x67, 0xf6, 0xf9, 0x0a, 0xba, 0x85, 0xfa, 0x2e, 0xc9,
+	0xc0, 0x2b, 0x77, 0xc7, 0xd1, 0x91, 0x77, 0x5f, 0x8f, 0x38, 0x01, 0x30, 0xf5, 0x1d, 0xb7, 0x68,
+	0x7a, 0x46, 0x18, 0xb5, 0xbc, 0xbc, 0x88, 0xfe, 0x00, 0x9a, 0x96, 0x68, 0xd2, 0xf1, 0xb6, 0x15,
+	0x60, 0xd4, 0xf5, 0x8a, 0x31, 0x9e, 0xc0, 0x70, 0xa7, 0x0b, 0x49, 0xf2, 0xc8, 0xbb, 0xdb, 0xa3,
+	0x47, 0x8e, 0xf7, 0x40, 0xb7, 0x3a, 0x6f, 0x7d, 0xdf, 0xf0, 0xbc, 0x97, 0x22, 0x0d, 0xa7, 0x0d,
+	0x6c, 0x8c, 0x5f, 0xfe, 0x27, 0x00, 0x00, 0xff, 0xff, 0x2e, 0xa3, 0xd8, 0xca, 0x92, 0x10, 0x00,
+	0x00,
 }
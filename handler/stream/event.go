@@ -0,0 +1,158 @@
+package stream
+
+import (
+	"compound/core"
+	"fmt"
+	"strings"
+)
+
+// the kinds of the stream events
+const (
+	EventTransaction = "transaction"
+	EventMarket      = "market"
+	EventPrice       = "price"
+	EventProposal    = "proposal"
+)
+
+// Cursor the position of the stream
+//
+// 	transactions are ordered by their ids, the market, price and proposal updates are
+// 	ordered by the output id (version) that made them
+type Cursor struct {
+	Transaction int64
+	Version     int64
+}
+
+// ParseCursor parse the cursor in the format of {transaction}-{version}
+func ParseCursor(s string) (Cursor, error) {
+	var c Cursor
+	if s == "" {
+		return c, nil
+	}
+
+	if _, err := fmt.Sscanf(s, "%d-%d", &c.Transaction, &c.Version); err != nil {
+		return c, fmt.Errorf("invalid cursor %q", s)
+	}
+
+	return c, nil
+}
+
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d-%d", c.Transaction, c.Version)
+}
+
+// IsZero the client starts from the live events without a cursor
+func (c Cursor) IsZero() bool {
+	return c.Transaction == 0 && c.Version == 0
+}
+
+// Event a transaction, market, price or proposal update pushed to the clients
+type Event struct {
+	Kind string
+	// Cursor the position to resume from after this event
+	Cursor Cursor
+	// Version the output id of the market, price or proposal update
+	Version int64
+	// the attributes to filter by, empty if the event doesn't have one
+	UserID  string
+	Assets  []string
+	Action  core.ActionType
+	Payload interface{}
+}
+
+// after the event is not delivered to the client at the cursor yet
+func (e *Event) after(c Cursor) bool {
+	if e.Kind == EventTransaction {
+		return e.Cursor.Transaction > c.Transaction
+	}
+
+	return e.Version > c.Version
+}
+
+// Filter select the events pushed to the client
+//
+// 	an empty field matches all the events, the user, asset and action filters only apply to
+// 	the events with the attribute, eg. market updates are pushed whatever the user is
+type Filter struct {
+	Kinds   map[string]bool
+	UserID  string
+	AssetID string
+	Actions map[core.ActionType]bool
+}
+
+// ParseFilter parse the filter from the comma separated event kinds & action names
+func ParseFilter(kinds, userID, assetID, actions string) (Filter, error) {
+	f := Filter{
+		UserID:  userID,
+		AssetID: assetID,
+	}
+
+	for _, kind := range splitList(kinds) {
+		switch kind {
+		case EventTransaction, EventMarket, EventPrice, EventProposal:
+		default:
+			return f, fmt.Errorf("unknown event %q", kind)
+		}
+
+		if f.Kinds == nil {
+			f.Kinds = map[string]bool{}
+		}
+		f.Kinds[kind] = true
+	}
+
+	for _, name := range splitList(actions) {
+		action := core.ParseActionType(name)
+		if action == core.ActionTypeDefault {
+			return f, fmt.Errorf("unknown action %q", name)
+		}
+
+		if f.Actions == nil {
+			f.Actions = map[core.ActionType]bool{}
+		}
+		f.Actions[action] = true
+	}
+
+	return f, nil
+}
+
+// Match the event is selected by the filter
+func (f Filter) Match(e *Event) bool {
+	if len(f.Kinds) > 0 && !f.Kinds[e.Kind] {
+		return false
+	}
+
+	if f.UserID != "" && e.UserID != "" && e.UserID != f.UserID {
+		return false
+	}
+
+	if f.AssetID != "" && len(e.Assets) > 0 && !containsString(e.Assets, f.AssetID) {
+		return false
+	}
+
+	if len(f.Actions) > 0 && e.Action != core.ActionTypeDefault && !f.Actions[e.Action] {
+		return false
+	}
+
+	return true
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+package stream
+
+import (
+	"compound/handler/param"
+	"compound/handler/render"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fox-one/pkg/logger"
+)
+
+// heartbeat keeps the idle connections alive through the proxies
+const heartbeat = 15 * time.Second
+
+// Handle serve the events as server-sent events
+//
+// 	query: events, user, asset, action & cursor, the Last-Event-ID header
+// 	set by the reconnecting EventSource is used if the cursor is empty
+func Handle(hub *Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := logger.FromContext(ctx)
+
+		var params struct {
+			Events string `json:"events"`
+			User   string `json:"user"`
+			Asset  string `json:"asset"`
+			Action string `json:"action"`
+			Cursor string `json:"cursor"`
+		}
+
+		if err := param.Binding(r, &params); err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		filter, err := ParseFilter(params.Events, params.User, params.Asset, params.Action)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		if params.Cursor == "" {
+			params.Cursor = r.Header.Get("Last-Event-ID")
+		}
+
+		cursor, err := ParseCursor(params.Cursor)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			render.BadRequest(w, errors.New("streaming unsupported"))
+			return
+		}
+
+		sub, backlog, err := hub.Subscribe(ctx, filter, cursor)
+		if err != nil {
+			render.BadRequest(w, err)
+			return
+		}
+		defer hub.Unsubscribe(sub)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		for _, e := range backlog {
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+			case e, ok := <-sub.Events():
+				if !ok {
+					log.Infoln("stream: client dropped")
+					return
+				}
+
+				if err := writeEvent(w, e); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e *Event) error {
+	data, err := json.Marshal(e.Payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.Cursor, e.Kind, data)
+	return err
+}
@@ -0,0 +1,415 @@
+package stream
+
+import (
+	"compound/core"
+	"compound/handler/views"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fox-one/pkg/logger"
+)
+
+const (
+	limit = 500
+	// recentSize the number of the recent events kept for the reconnecting clients
+	recentSize = 1024
+	// maxBacklog the max number of the events loaded from the database for a resuming client
+	maxBacklog = 10000
+	// bufferSize the events buffered for a client, the client falling behind is dropped
+	bufferSize = 256
+)
+
+// ErrCursorExpired the cursor is too old to resume from
+var ErrCursorExpired = errors.New("cursor expired")
+
+// CheckpointFunc read the id of the last output handled by the payee,
+// the updates made by the outputs after it are not streamed yet
+type CheckpointFunc func(ctx context.Context) (int64, error)
+
+// Hub polls the database for the new transactions, market, price & proposal updates
+// and fans them out to the subscribed clients
+type Hub struct {
+	transactions core.TransactionStore
+	markets      core.IMarketStore
+	priceTicks   core.PriceTickStore
+	proposals    core.ProposalStore
+	checkpoint   CheckpointFunc
+
+	mux     sync.Mutex
+	ready   bool
+	cursor  Cursor
+	oldest  Cursor
+	recent  []*Event
+	clients map[*Subscription]struct{}
+}
+
+// New new hub
+func New(
+	transactions core.TransactionStore,
+	markets core.IMarketStore,
+	priceTicks core.PriceTickStore,
+	proposals core.ProposalStore,
+	checkpoint CheckpointFunc,
+) *Hub {
+	return &Hub{
+		transactions: transactions,
+		markets:      markets,
+		priceTicks:   priceTicks,
+		proposals:    proposals,
+		checkpoint:   checkpoint,
+		clients:      map[*Subscription]struct{}{},
+	}
+}
+
+// Run poll the updates every second
+func (h *Hub) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx).WithField("worker", "stream")
+	ctx = logger.WithContext(ctx, log)
+
+	dur := time.Millisecond
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.closeAll()
+			return ctx.Err()
+		case <-time.After(dur):
+			if err := h.run(ctx); err == nil {
+				dur = time.Second
+			} else {
+				dur = 5 * time.Second
+			}
+		}
+	}
+}
+
+func (h *Hub) run(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	if !h.isReady() {
+		return h.start(ctx)
+	}
+
+	to, err := h.position(ctx)
+	if err != nil {
+		return err
+	}
+
+	h.mux.Lock()
+	from := h.cursor
+	h.mux.Unlock()
+
+	events, err := h.fetch(ctx, from, to, 0)
+	if err != nil {
+		log.WithError(err).Errorln("fetch")
+		return err
+	}
+
+	h.publish(events, to)
+	return nil
+}
+
+// position the latest transaction & the payee checkpoint
+func (h *Hub) position(ctx context.Context) (Cursor, error) {
+	log := logger.FromContext(ctx)
+
+	lastID, err := h.transactions.LastID(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("transactions.LastID")
+		return Cursor{}, err
+	}
+
+	version, err := h.checkpoint(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("read checkpoint")
+		return Cursor{}, err
+	}
+
+	return Cursor{Transaction: lastID, Version: version}, nil
+}
+
+// start the live events begin at the current position
+func (h *Hub) start(ctx context.Context) error {
+	cursor, err := h.position(ctx)
+	if err != nil {
+		return err
+	}
+
+	h.mux.Lock()
+	h.cursor = cursor
+	h.oldest = cursor
+	h.ready = true
+	h.mux.Unlock()
+
+	logger.FromContext(ctx).Infoln("stream starts at", cursor)
+	return nil
+}
+
+func (h *Hub) isReady() bool {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	return h.ready
+}
+
+func (h *Hub) publish(events []*Event, cursor Cursor) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.cursor = cursor
+
+	for _, e := range events {
+		h.recent = append(h.recent, e)
+		if n := len(h.recent) - recentSize; n > 0 {
+			h.oldest = h.recent[n-1].Cursor
+			h.recent = h.recent[n:]
+		}
+
+		for sub := range h.clients {
+			if !sub.filter.Match(e) {
+				continue
+			}
+
+			select {
+			case sub.events <- e:
+			default:
+				// the client falls behind, let it reconnect with the last cursor
+				h.drop(sub)
+			}
+		}
+	}
+}
+
+func (h *Hub) closeAll() {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	for sub := range h.clients {
+		h.drop(sub)
+	}
+}
+
+// drop must be called with the lock held
+func (h *Hub) drop(sub *Subscription) {
+	if _, ok := h.clients[sub]; ok {
+		delete(h.clients, sub)
+		close(sub.events)
+	}
+}
+
+// fetch list the transactions in (from.Transaction, to.Transaction] and the updates
+// made by the outputs in (from.Version, to.Version]
+func (h *Hub) fetch(ctx context.Context, from, to Cursor, max int) ([]*Event, error) {
+	cursor := from
+
+	var events []*Event
+	for cursor.Transaction < to.Transaction {
+		transactions, err := h.transactions.ListFrom(ctx, cursor.Transaction, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range transactions {
+			if tx.ID > to.Transaction {
+				break
+			}
+
+			cursor.Transaction = tx.ID
+			events = append(events, &Event{
+				Kind:    EventTransaction,
+				Cursor:  cursor,
+				UserID:  tx.UserID,
+				Assets:  []string{tx.AssetID},
+				Action:  tx.Action,
+				Payload: tx,
+			})
+		}
+
+		if max > 0 && len(events) > max {
+			return nil, ErrCursorExpired
+		}
+
+		if len(transactions) < limit {
+			break
+		}
+	}
+
+	if to.Version <= from.Version {
+		return events, nil
+	}
+
+	updates, err := h.fetchUpdates(ctx, from.Version, to.Version, max)
+	if err != nil {
+		return nil, err
+	}
+
+	if max > 0 && len(events)+len(updates) > max {
+		return nil, ErrCursorExpired
+	}
+
+	for idx, e := range updates {
+		// resuming from the event replays the rest updates of the same output
+		cursor.Version = e.Version
+		if next := idx + 1; next < len(updates) && updates[next].Version == e.Version {
+			cursor.Version = e.Version - 1
+		}
+
+		e.Cursor = cursor
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+// fetchUpdates list the market, price & proposal updates ordered by the versions
+func (h *Hub) fetchUpdates(ctx context.Context, from, to int64, max int) ([]*Event, error) {
+	var events []*Event
+
+	markets, err := h.markets.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range markets {
+		if m.Version > from && m.Version <= to {
+			events = append(events, &Event{
+				Kind:    EventMarket,
+				Version: m.Version,
+				Assets:  []string{m.AssetID, m.CTokenAssetID},
+				Payload: m,
+			})
+		}
+	}
+
+	for version := from; version < to; {
+		ticks, err := h.priceTicks.ListFrom(ctx, version, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tick := range ticks {
+			if version = tick.Version; version > to {
+				break
+			}
+
+			if tick.Status != core.PriceTickStatusAccepted {
+				continue
+			}
+
+			events = append(events, &Event{
+				Kind:    EventPrice,
+				Version: tick.Version,
+				Assets:  []string{tick.AssetID},
+				Payload: tick,
+			})
+		}
+
+		if max > 0 && len(events) > max {
+			return nil, ErrCursorExpired
+		}
+
+		if len(ticks) < limit {
+			break
+		}
+	}
+
+	for version := from; version < to; {
+		proposals, err := h.proposals.ListUpdated(ctx, version, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range proposals {
+			if version = p.Version; version > to {
+				break
+			}
+
+			events = append(events, &Event{
+				Kind:    EventProposal,
+				Version: p.Version,
+				Action:  p.Action,
+				Payload: views.ProposalView(*p),
+			})
+		}
+
+		if max > 0 && len(events) > max {
+			return nil, ErrCursorExpired
+		}
+
+		if len(proposals) < limit {
+			break
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Version < events[j].Version
+	})
+
+	return events, nil
+}
+
+// Subscription the live events of a client
+type Subscription struct {
+	filter Filter
+	events chan *Event
+}
+
+// Subscribe subscribe the events after the cursor, start from the live events if the cursor is zero
+//
+// 	the backlog is replayed from the recent events if the cursor is still in there,
+// 	or loaded from the database otherwise. The live events always come after the backlog
+func (h *Hub) Subscribe(ctx context.Context, filter Filter, from Cursor) (*Subscription, []*Event, error) {
+	sub := &Subscription{
+		filter: filter,
+		events: make(chan *Event, bufferSize),
+	}
+
+	h.mux.Lock()
+	if !h.ready {
+		h.mux.Unlock()
+		return nil, nil, errors.New("stream not ready")
+	}
+
+	cursor, oldest := h.cursor, h.oldest
+	recent := h.recent
+	h.clients[sub] = struct{}{}
+	h.mux.Unlock()
+
+	if from.IsZero() {
+		return sub, nil, nil
+	}
+
+	events := recent
+	if from.Transaction < oldest.Transaction || from.Version < oldest.Version {
+		var err error
+		if events, err = h.fetch(ctx, from, cursor, maxBacklog); err != nil {
+			h.Unsubscribe(sub)
+			return nil, nil, err
+		}
+	}
+
+	var backlog []*Event
+	for _, e := range events {
+		if e.after(from) && filter.Match(e) {
+			backlog = append(backlog, e)
+		}
+	}
+
+	return sub, backlog, nil
+}
+
+// Unsubscribe remove the client
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.drop(sub)
+}
+
+// Events the live events, closed if the client is dropped
+func (sub *Subscription) Events() <-chan *Event {
+	return sub.events
+}
@@ -0,0 +1,145 @@
+package stream
+
+import (
+	"compound/core"
+	"compound/store/memory"
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCursor(t *testing.T) {
+	c, err := ParseCursor("12-345")
+	require.Nil(t, err)
+	assert.Equal(t, Cursor{Transaction: 12, Version: 345}, c)
+	assert.Equal(t, "12-345", c.String())
+
+	c, err = ParseCursor("")
+	require.Nil(t, err)
+	assert.True(t, c.IsZero())
+
+	_, err = ParseCursor("abc")
+	assert.NotNil(t, err)
+}
+
+func TestFilter(t *testing.T) {
+	_, err := ParseFilter("transaction,unknown", "", "", "")
+	assert.NotNil(t, err)
+
+	_, err = ParseFilter("", "", "", "supply,nothing")
+	assert.NotNil(t, err)
+
+	f, err := ParseFilter("transaction, market", "alice", "btc", "Supply,borrow")
+	require.Nil(t, err)
+
+	assert.True(t, f.Match(&Event{Kind: EventTransaction, UserID: "alice", Assets: []string{"btc"}, Action: core.ActionTypeSupply}))
+	assert.True(t, f.Match(&Event{Kind: EventTransaction, UserID: "alice", Assets: []string{"btc"}, Action: core.ActionTypeBorrow}))
+	assert.False(t, f.Match(&Event{Kind: EventTransaction, UserID: "bob", Assets: []string{"btc"}, Action: core.ActionTypeSupply}))
+	assert.False(t, f.Match(&Event{Kind: EventTransaction, UserID: "alice", Assets: []string{"eth"}, Action: core.ActionTypeSupply}))
+	assert.False(t, f.Match(&Event{Kind: EventTransaction, UserID: "alice", Assets: []string{"btc"}, Action: core.ActionTypeRepay}))
+	// the market updates have no user & action
+	assert.True(t, f.Match(&Event{Kind: EventMarket, Assets: []string{"btc", "cbtc"}}))
+	assert.False(t, f.Match(&Event{Kind: EventPrice, Assets: []string{"btc"}}))
+}
+
+func TestHub(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		transactions = memory.NewTransactionStore()
+		markets      = memory.NewMarketStore()
+		priceTicks   = memory.NewPriceTickStore()
+		proposals    = memory.NewProposalStore()
+		checkpoint   int64
+	)
+
+	hub := New(transactions, markets, priceTicks, proposals, func(ctx context.Context) (int64, error) {
+		return checkpoint, nil
+	})
+
+	_, _, err := hub.Subscribe(ctx, Filter{}, Cursor{})
+	assert.NotNil(t, err, "not ready")
+
+	market := &core.Market{
+		AssetID:       uuid.Must(uuid.NewV4()).String(),
+		CTokenAssetID: uuid.Must(uuid.NewV4()).String(),
+		Symbol:        "BTC",
+	}
+	require.Nil(t, markets.Create(ctx, market))
+	require.Nil(t, transactions.Create(ctx, &core.Transaction{
+		TraceID: uuid.Must(uuid.NewV4()).String(),
+		UserID:  uuid.Must(uuid.NewV4()).String(),
+	}))
+	checkpoint = 1
+
+	// start after the existing transaction & checkpoint
+	require.Nil(t, hub.run(ctx))
+
+	live, backlog, err := hub.Subscribe(ctx, Filter{}, Cursor{})
+	require.Nil(t, err)
+	assert.Empty(t, backlog)
+
+	alice := uuid.Must(uuid.NewV4()).String()
+	require.Nil(t, transactions.Create(ctx, &core.Transaction{
+		TraceID: uuid.Must(uuid.NewV4()).String(),
+		UserID:  alice,
+		AssetID: market.AssetID,
+		Action:  core.ActionTypeSupply,
+	}))
+
+	market, err = markets.Find(ctx, market.AssetID)
+	require.Nil(t, err)
+	market.TotalCash = decimal.NewFromInt(1)
+	require.Nil(t, markets.Update(ctx, market, 2))
+	require.Nil(t, priceTicks.Create(ctx, &core.PriceTick{
+		AssetID: market.AssetID,
+		Version: 2,
+		Price:   decimal.NewFromInt(30000),
+		Status:  core.PriceTickStatusAccepted,
+	}))
+
+	// the updates after the payee checkpoint are not pushed yet
+	require.Nil(t, hub.run(ctx))
+	if assert.Len(t, live.Events(), 1) {
+		e := <-live.Events()
+		assert.Equal(t, EventTransaction, e.Kind)
+		assert.Equal(t, Cursor{Transaction: 2, Version: 1}, e.Cursor)
+	}
+
+	checkpoint = 2
+	require.Nil(t, hub.run(ctx))
+	if assert.Len(t, live.Events(), 2) {
+		e := <-live.Events()
+		assert.Equal(t, EventMarket, e.Kind)
+		// resuming from the market update replays the price of the same output
+		assert.Equal(t, Cursor{Transaction: 2, Version: 1}, e.Cursor)
+
+		e = <-live.Events()
+		assert.Equal(t, EventPrice, e.Kind)
+		assert.Equal(t, Cursor{Transaction: 2, Version: 2}, e.Cursor)
+	}
+
+	// resume from the recent events
+	_, backlog, err = hub.Subscribe(ctx, Filter{Kinds: map[string]bool{EventPrice: true}}, Cursor{Transaction: 2, Version: 1})
+	require.Nil(t, err)
+	if assert.Len(t, backlog, 1) {
+		assert.Equal(t, EventPrice, backlog[0].Kind)
+	}
+
+	// resume from the database
+	_, backlog, err = hub.Subscribe(ctx, Filter{UserID: alice, Kinds: map[string]bool{EventTransaction: true}}, Cursor{Transaction: 0, Version: 0})
+	require.Nil(t, err)
+	assert.Empty(t, backlog, "zero cursor starts from the live events")
+
+	_, backlog, err = hub.Subscribe(ctx, Filter{UserID: alice}, Cursor{Transaction: 0, Version: 1})
+	require.Nil(t, err)
+	if assert.Len(t, backlog, 3) {
+		assert.Equal(t, alice, backlog[0].UserID)
+		assert.Equal(t, EventMarket, backlog[1].Kind)
+		assert.Equal(t, EventPrice, backlog[2].Kind)
+	}
+}
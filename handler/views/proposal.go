@@ -20,16 +20,23 @@ type (
 	}
 
 	Proposal struct {
-		ID        string          `json:"id,omitempty"`
-		CreatedAt time.Time       `json:"created_at"`
-		UpdatedAt time.Time       `json:"updated_at"`
-		PassedAt  *time.Time      `json:"passed_at,omitempty"`
-		Creator   string          `json:"creator,omitempty"`
-		AssetID   string          `json:"asset_id,omitempty"`
-		Amount    decimal.Decimal `json:"amount,omitempty"`
-		Action    string          `json:"action,omitempty"`
-		Votes     []string        `json:"votes,omitempty"`
-		Items     []ProposalItem  `json:"items,omitempty"`
+		ID        string     `json:"id,omitempty"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		PassedAt  *time.Time `json:"passed_at,omitempty"`
+		// Status pending, passed, rejected, expired or executed
+		Status string `json:"status,omitempty"`
+		// ExecutableAt the passed proposal is queued by the timelock until the time
+		ExecutableAt *time.Time      `json:"executable_at,omitempty"`
+		ExecutedAt   *time.Time      `json:"executed_at,omitempty"`
+		CanceledAt   *time.Time      `json:"canceled_at,omitempty"`
+		Creator      string          `json:"creator,omitempty"`
+		AssetID      string          `json:"asset_id,omitempty"`
+		Amount       decimal.Decimal `json:"amount,omitempty"`
+		Action       string          `json:"action,omitempty"`
+		Votes        []string        `json:"votes,omitempty"`
+		Rejections   []string        `json:"rejections,omitempty"`
+		Items        []ProposalItem  `json:"items,omitempty"`
 	}
 )
 
@@ -52,18 +59,29 @@ func ProposalItemViews(pitems []core.ProposalItem) []ProposalItem {
 
 func ProposalView(p core.Proposal) Proposal {
 	view := Proposal{
-		ID:        p.TraceID,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
-		Creator:   p.Creator,
-		AssetID:   p.AssetID,
-		Amount:    p.Amount,
-		Action:    p.Action.String(),
-		Votes:     p.Votes,
+		ID:         p.TraceID,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+		Creator:    p.Creator,
+		AssetID:    p.AssetID,
+		Amount:     p.Amount,
+		Action:     p.Action.String(),
+		Status:     p.Status.String(),
+		Votes:      p.Votes,
+		Rejections: p.Rejections,
 	}
 	if p.PassedAt.Valid {
 		view.PassedAt = &p.PassedAt.Time
 	}
+	if p.ExecutableAt.Valid {
+		view.ExecutableAt = &p.ExecutableAt.Time
+	}
+	if p.ExecutedAt.Valid {
+		view.ExecutedAt = &p.ExecutedAt.Time
+	}
+	if p.CanceledAt.Valid {
+		view.CanceledAt = &p.CanceledAt.Time
+	}
 	return view
 }
 
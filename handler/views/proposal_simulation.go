@@ -0,0 +1,35 @@
+package views
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// MarketChange the market before and after the proposal, before is nil for a new market
+	MarketChange struct {
+		Before *Market `json:"before,omitempty"`
+		After  *Market `json:"after"`
+	}
+
+	// PropertyChange the property value before and after the proposal
+	PropertyChange struct {
+		Key    string `json:"key"`
+		Before string `json:"before"`
+		After  string `json:"after"`
+	}
+
+	// LiquidatableAccount the account with shortfall after the proposal
+	LiquidatableAccount struct {
+		UserID          string          `json:"user_id"`
+		LiquidityBefore decimal.Decimal `json:"liquidity_before"`
+		LiquidityAfter  decimal.Decimal `json:"liquidity_after"`
+	}
+
+	// ProposalSimulation the effects of the proposal applied to the current state
+	ProposalSimulation struct {
+		Action       string                `json:"action"`
+		Markets      []MarketChange        `json:"markets"`
+		Properties   []PropertyChange      `json:"properties"`
+		Liquidatable []LiquidatableAccount `json:"liquidatable"`
+	}
+)
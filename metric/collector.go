@@ -0,0 +1,160 @@
+package metric
+
+import (
+	"compound/core"
+	"context"
+	"time"
+
+	"github.com/fox-one/pkg/property"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// syncCheckpointKey the time of the last output synced by the syncer
+const syncCheckpointKey = "sync_checkpoint"
+
+var (
+	checkpointLagOutputsDesc = prometheus.NewDesc(
+		"rings_checkpoint_lag_outputs",
+		"Outputs synced but not handled by the payee yet.",
+		nil, nil,
+	)
+	checkpointLagSecondsDesc = prometheus.NewDesc(
+		"rings_checkpoint_lag_seconds",
+		"Duration between the sync_checkpoint and the output at the outputs_checkpoint.",
+		nil, nil,
+	)
+	unhandledTransfersDesc = prometheus.NewDesc(
+		"rings_unhandled_transfers",
+		"Transfers not handled by the cashier yet.",
+		nil, nil,
+	)
+	marketUtilizationDesc = prometheus.NewDesc(
+		"rings_market_utilization",
+		"Utilization rate of the market.",
+		[]string{"symbol"}, nil,
+	)
+	marketPriceDesc = prometheus.NewDesc(
+		"rings_market_price",
+		"Price of the market.",
+		[]string{"symbol"}, nil,
+	)
+	marketPriceAgeDesc = prometheus.NewDesc(
+		"rings_market_price_age_seconds",
+		"Duration since the price of the market was updated.",
+		[]string{"symbol"}, nil,
+	)
+)
+
+// CheckpointFunc read the checkpoint of the outputs handled by the payee
+type CheckpointFunc func(ctx context.Context) (int64, error)
+
+// Collector collect the wallet & market states from the stores on scraping
+type Collector struct {
+	wallets    core.WalletStore
+	properties property.Store
+	markets    core.IMarketStore
+	checkpoint CheckpointFunc
+	timeout    time.Duration
+}
+
+// NewCollector new store collector
+func NewCollector(
+	wallets core.WalletStore,
+	properties property.Store,
+	markets core.IMarketStore,
+	checkpoint CheckpointFunc,
+) *Collector {
+	return &Collector{
+		wallets:    wallets,
+		properties: properties,
+		markets:    markets,
+		checkpoint: checkpoint,
+		timeout:    5 * time.Second,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- checkpointLagOutputsDesc
+	ch <- checkpointLagSecondsDesc
+	ch <- unhandledTransfersDesc
+	ch <- marketUtilizationDesc
+	ch <- marketPriceDesc
+	ch <- marketPriceAgeDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	now := time.Now()
+	c.collectCheckpoint(ctx, ch)
+
+	if unhandled, err := c.wallets.CountUnhandledTransfers(ctx); err != nil {
+		ch <- prometheus.NewInvalidMetric(unhandledTransfersDesc, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(unhandledTransfersDesc, prometheus.GaugeValue, float64(unhandled))
+	}
+
+	markets, err := c.markets.All(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(marketPriceDesc, err)
+		return
+	}
+
+	for _, m := range markets {
+		utilization, _ := m.UtilizationRate.Float64()
+		price, _ := m.Price.Float64()
+
+		ch <- prometheus.MustNewConstMetric(marketUtilizationDesc, prometheus.GaugeValue, utilization, m.Symbol)
+		ch <- prometheus.MustNewConstMetric(marketPriceDesc, prometheus.GaugeValue, price, m.Symbol)
+		if !m.PriceUpdatedAt.IsZero() {
+			ch <- prometheus.MustNewConstMetric(marketPriceAgeDesc, prometheus.GaugeValue, now.Sub(m.PriceUpdatedAt).Seconds(), m.Symbol)
+		}
+	}
+}
+
+// collectCheckpoint the lag of the payee behind the syncer, in outputs & in time
+func (c *Collector) collectCheckpoint(ctx context.Context, ch chan<- prometheus.Metric) {
+	lastOutputID, err := c.wallets.CountOutputs(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(checkpointLagOutputsDesc, err)
+		return
+	}
+
+	checkpoint, err := c.checkpoint(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(checkpointLagOutputsDesc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(checkpointLagOutputsDesc, prometheus.GaugeValue, float64(lastOutputID-checkpoint))
+
+	v, err := c.properties.Get(ctx, syncCheckpointKey)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(checkpointLagSecondsDesc, err)
+		return
+	}
+
+	synced := v.Time()
+	if synced.IsZero() {
+		return
+	}
+
+	var lag time.Duration
+	if checkpoint < lastOutputID {
+		// the first output not handled yet
+		outputs, err := c.wallets.List(ctx, checkpoint, 1)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(checkpointLagSecondsDesc, err)
+			return
+		}
+
+		if len(outputs) > 0 && synced.After(outputs[0].CreatedAt) {
+			lag = synced.Sub(outputs[0].CreatedAt)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(checkpointLagSecondsDesc, prometheus.GaugeValue, lag.Seconds())
+}
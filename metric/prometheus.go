@@ -0,0 +1,96 @@
+package metric
+
+import (
+	"compound/worker"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "rings"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	workerLoopDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "loop_duration_seconds",
+		Help:      "Duration of the worker loops.",
+		Buckets:   []float64{.005, .01, .05, .1, .5, 1, 5, 10, 30, 60},
+	}, []string{"worker"})
+
+	workerLoopErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "loop_errors_total",
+		Help:      "Worker loops failed, the idle ones excluded.",
+	}, []string{"worker"})
+
+	payeeOutputs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "payee",
+		Name:      "outputs_total",
+		Help:      "Outputs handled by the payee, by action type & error code.",
+	}, []string{"action", "code"})
+
+	payeeRefunds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "payee",
+		Name:      "refunds_total",
+		Help:      "Outputs refunded by the payee, by action type.",
+	}, []string{"action"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		workerLoopDuration,
+		workerLoopErrors,
+		payeeOutputs,
+		payeeRefunds,
+	)
+}
+
+// Register register the collector to the /metrics handler
+func Register(c prometheus.Collector) {
+	registry.MustRegister(c)
+}
+
+// Handler serve the metrics in the prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveWorker run a loop of the worker, record the duration & the error,
+// worker.ErrIdle means nothing to do and is not counted as an error
+func ObserveWorker(name string, run func() error) error {
+	start := time.Now()
+	err := run()
+	workerLoopDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+
+	if err != nil && !errors.Is(err, worker.ErrIdle) {
+		workerLoopErrors.WithLabelValues(name).Inc()
+	}
+
+	return err
+}
+
+// ObservePayeeOutput record an output handled by the payee, the code is empty if succeeded
+func ObservePayeeOutput(action, code string) {
+	if code == "" {
+		code = "ok"
+	}
+
+	payeeOutputs.WithLabelValues(action, code).Inc()
+}
+
+// ObservePayeeRefund record an output refunded by the payee
+func ObservePayeeRefund(action string) {
+	payeeRefunds.WithLabelValues(action).Inc()
+}
@@ -0,0 +1,79 @@
+package metric
+
+import (
+	"compound/core"
+	"compound/store/memory"
+	"compound/worker"
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T) string {
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, w.Code)
+
+	b, err := io.ReadAll(w.Body)
+	require.Nil(t, err)
+	return string(b)
+}
+
+func TestPrometheus(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		wallets    = memory.NewWalletStore()
+		properties = memory.NewPropertyStore()
+		markets    = memory.NewMarketStore()
+	)
+
+	Register(NewCollector(wallets, properties, markets, func(ctx context.Context) (int64, error) {
+		v, err := properties.Get(ctx, "outputs_checkpoint")
+		return v.Int64(), err
+	}))
+
+	_ = ObserveWorker("cashier", func() error { return worker.ErrIdle })
+	_ = ObserveWorker("cashier", func() error { return errors.New("timeout") })
+	ObservePayeeOutput(core.ActionTypeSupply.String(), "")
+	ObservePayeeOutput(core.ActionTypeBorrow.String(), "payee/market-closed")
+	ObservePayeeRefund(core.ActionTypeBorrow.String())
+
+	now := time.Now()
+	require.Nil(t, wallets.Save(ctx, []*core.Output{
+		{TraceID: uuid.Must(uuid.NewV4()).String(), CreatedAt: now.Add(-time.Minute)},
+		{TraceID: uuid.Must(uuid.NewV4()).String(), CreatedAt: now},
+	}, true))
+	require.Nil(t, properties.Save(ctx, "outputs_checkpoint", 1))
+	require.Nil(t, properties.Save(ctx, syncCheckpointKey, now.Add(time.Minute)))
+
+	require.Nil(t, markets.Create(ctx, &core.Market{
+		Symbol:          "BTC",
+		AssetID:         uuid.Must(uuid.NewV4()).String(),
+		CTokenAssetID:   uuid.Must(uuid.NewV4()).String(),
+		UtilizationRate: decimal.NewFromFloat(0.5),
+		Price:           decimal.NewFromInt(30000),
+		PriceUpdatedAt:  now.Add(-time.Hour),
+	}))
+
+	body := scrape(t)
+	assert.Contains(t, body, `rings_worker_loop_duration_seconds_count{worker="cashier"} 2`)
+	assert.Contains(t, body, `rings_worker_loop_errors_total{worker="cashier"} 1`)
+	assert.Contains(t, body, `rings_payee_outputs_total{action="Supply",code="ok"} 1`)
+	assert.Contains(t, body, `rings_payee_outputs_total{action="Borrow",code="payee/market-closed"} 1`)
+	assert.Contains(t, body, `rings_payee_refunds_total{action="Borrow"} 1`)
+	assert.Contains(t, body, "rings_checkpoint_lag_outputs 1\n")
+	assert.Contains(t, body, "rings_checkpoint_lag_seconds 60\n", "synced a minute after the first output not handled")
+	assert.Contains(t, body, "rings_unhandled_transfers 0\n")
+	assert.Contains(t, body, `rings_market_utilization{symbol="BTC"} 0.5`)
+	assert.Contains(t, body, `rings_market_price{symbol="BTC"} 30000`)
+	assert.Contains(t, body, `rings_market_price_age_seconds{symbol="BTC"} 36`)
+}
@@ -23,3 +23,8 @@ func GetBlockByTime(ctx context.Context, t time.Time) (int64, error) {
 
 	return seconds / SecondsPerBlock, nil
 }
+
+// GetTimeByBlock get the start time of block
+func GetTimeByBlock(block int64) time.Time {
+	return time.Unix(genesis+block*SecondsPerBlock, 0).UTC()
+}
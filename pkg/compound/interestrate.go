@@ -58,9 +58,7 @@ func GetBorrowRatePerBlock(utilizationRate, baseRate, multiplier, jumpMultiplier
 // GetSupplyRatePerBlock supply rate per block
 func GetSupplyRatePerBlock(utilizationRate, baseRate, multiplier, jumpMultiplier, kink, reserveFactor decimal.Decimal) decimal.Decimal {
 	borrowRate := GetBorrowRatePerBlock(utilizationRate, baseRate, multiplier, jumpMultiplier, kink)
-	oneMinusReserveFactor := decimal.NewFromInt(1).Sub(reserveFactor)
-	rateToPool := borrowRate.Mul(oneMinusReserveFactor)
-	return utilizationRate.Mul(rateToPool).Truncate(MaxPricision)
+	return supplyRatePerBlock(borrowRate, utilizationRate, reserveFactor)
 }
 
 // GetBaseRatePerBlock base rate per block
@@ -0,0 +1,48 @@
+package compound
+
+import (
+	"compound/core"
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AccrueInterest accrue interest market per block(15 seconds)
+//
+// Accruing interest only occurs when there is a behavior that causes changes in market transaction data, such as supply, borrow, pledge, unpledge, redeem, repay, price updating
+func AccrueInterest(ctx context.Context, market *core.Market, time time.Time) {
+	blockNum, err := GetBlockByTime(ctx, time)
+	if err != nil {
+		panic(err)
+	}
+
+	if !market.BorrowIndex.IsPositive() {
+		market.BorrowIndex = decimal.New(1, 0)
+	}
+
+	if blockDelta := blockNum - market.BlockNumber; blockDelta > 0 {
+		borrowRate := BorrowRatePerBlock(market)
+		timesBorrowRate := borrowRate.Mul(decimal.NewFromInt(blockDelta))
+		interestAccumulated := market.TotalBorrows.Mul(timesBorrowRate).Truncate(MaxPricision)
+
+		market.BlockNumber = blockNum
+		market.TotalBorrows = market.TotalBorrows.Add(interestAccumulated)
+		market.Reserves = market.Reserves.Add(interestAccumulated.Mul(market.ReserveFactor).Truncate(MaxPricision))
+		market.BorrowIndex = market.BorrowIndex.Add(
+			timesBorrowRate.Mul(market.BorrowIndex).
+				Shift(MaxPricision).Ceil().Shift(-MaxPricision))
+	}
+
+	//utilization rate
+	uRate := UtilizationRate(market.TotalCash, market.TotalBorrows, market.Reserves)
+	//exchange rate
+	exchangeRate := GetExchangeRate(market.TotalCash, market.TotalBorrows, market.Reserves, market.CTokens, market.InitExchangeRate)
+	supplyRate := SupplyRatePerBlock(market)
+	borrowRate := BorrowRatePerBlock(market)
+
+	market.UtilizationRate = uRate.Truncate(16)
+	market.ExchangeRate = exchangeRate.Truncate(16)
+	market.SupplyRatePerBlock = supplyRate.Truncate(16)
+	market.BorrowRatePerBlock = borrowRate.Truncate(16)
+}
@@ -0,0 +1,38 @@
+package compound
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// Median the median of the values, the mean of the two middle ones if the count is even
+func Median(values []decimal.Decimal) decimal.Decimal {
+	if len(values) == 0 {
+		return decimal.Zero
+	}
+
+	sorted := make([]decimal.Decimal, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+
+	return sorted[mid-1].Add(sorted[mid]).Div(decimal.New(2, 0)).Truncate(MaxPricision)
+}
+
+// Deviation the relative deviation of the price from the median
+//
+// 	deviation = |price - median| / median
+func Deviation(price, median decimal.Decimal) decimal.Decimal {
+	if !median.IsPositive() {
+		return decimal.Zero
+	}
+
+	return price.Sub(median).Abs().Div(median).Truncate(MaxPricision)
+}
@@ -0,0 +1,34 @@
+package compound
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedian(t *testing.T) {
+	values := func(vs ...int64) []decimal.Decimal {
+		ds := make([]decimal.Decimal, 0, len(vs))
+		for _, v := range vs {
+			ds = append(ds, decimal.NewFromInt(v))
+		}
+		return ds
+	}
+
+	assert.True(t, Median(nil).IsZero())
+	assert.True(t, Median(values(7)).Equal(decimal.NewFromInt(7)))
+	assert.True(t, Median(values(9, 1, 5)).Equal(decimal.NewFromInt(5)))
+	assert.True(t, Median(values(4, 1, 3, 100)).Equal(decimal.NewFromFloat(3.5)))
+
+	// the input is not reordered
+	vs := values(3, 1, 2)
+	Median(vs)
+	assert.True(t, vs[0].Equal(decimal.NewFromInt(3)))
+}
+
+func TestDeviation(t *testing.T) {
+	assert.True(t, Deviation(decimal.NewFromInt(110), decimal.NewFromInt(100)).Equal(decimal.NewFromFloat(0.1)))
+	assert.True(t, Deviation(decimal.NewFromInt(80), decimal.NewFromInt(100)).Equal(decimal.NewFromFloat(0.2)))
+	assert.True(t, Deviation(decimal.NewFromInt(80), decimal.Zero).IsZero())
+}
@@ -0,0 +1,115 @@
+package compound
+
+import (
+	"compound/core"
+
+	"github.com/shopspring/decimal"
+)
+
+// InterestRateModel calculates the interest rates per block from the utilization rate
+type InterestRateModel interface {
+	BorrowRatePerBlock(utilizationRate decimal.Decimal) decimal.Decimal
+	SupplyRatePerBlock(utilizationRate, reserveFactor decimal.Decimal) decimal.Decimal
+}
+
+// JumpRateModel the borrow rate jumps after the utilization rate hits the kink
+//
+// 	borrow_rate = base_rate + min(utilization_rate, kink) * multiplier + max(utilization_rate - kink, 0) * jump_multiplier
+type JumpRateModel struct {
+	BaseRate       decimal.Decimal
+	Multiplier     decimal.Decimal
+	JumpMultiplier decimal.Decimal
+	Kink           decimal.Decimal
+}
+
+// BorrowRatePerBlock borrow rate per block
+func (m JumpRateModel) BorrowRatePerBlock(utilizationRate decimal.Decimal) decimal.Decimal {
+	return GetBorrowRatePerBlock(utilizationRate, m.BaseRate, m.Multiplier, m.JumpMultiplier, m.Kink)
+}
+
+// SupplyRatePerBlock supply rate per block
+func (m JumpRateModel) SupplyRatePerBlock(utilizationRate, reserveFactor decimal.Decimal) decimal.Decimal {
+	return supplyRatePerBlock(m.BorrowRatePerBlock(utilizationRate), utilizationRate, reserveFactor)
+}
+
+// WhitePaperModel the borrow rate grows linearly with the utilization rate
+//
+// 	borrow_rate = base_rate + utilization_rate * multiplier
+type WhitePaperModel struct {
+	BaseRate   decimal.Decimal
+	Multiplier decimal.Decimal
+}
+
+// BorrowRatePerBlock borrow rate per block
+func (m WhitePaperModel) BorrowRatePerBlock(utilizationRate decimal.Decimal) decimal.Decimal {
+	return utilizationRate.Mul(GetMultiplierPerBlock(m.Multiplier)).Add(GetBaseRatePerBlock(m.BaseRate)).Truncate(MaxPricision)
+}
+
+// SupplyRatePerBlock supply rate per block
+func (m WhitePaperModel) SupplyRatePerBlock(utilizationRate, reserveFactor decimal.Decimal) decimal.Decimal {
+	return supplyRatePerBlock(m.BorrowRatePerBlock(utilizationRate), utilizationRate, reserveFactor)
+}
+
+// StableCoinModel the jump rate model with a floor borrow rate
+//
+// 	borrow_rate = max(jump_rate, floor_rate)
+type StableCoinModel struct {
+	JumpRateModel
+	FloorRate decimal.Decimal
+}
+
+// BorrowRatePerBlock borrow rate per block
+func (m StableCoinModel) BorrowRatePerBlock(utilizationRate decimal.Decimal) decimal.Decimal {
+	return decimal.Max(m.JumpRateModel.BorrowRatePerBlock(utilizationRate), m.FloorRate.Div(BlocksPerYear).Truncate(MaxPricision))
+}
+
+// SupplyRatePerBlock supply rate per block
+func (m StableCoinModel) SupplyRatePerBlock(utilizationRate, reserveFactor decimal.Decimal) decimal.Decimal {
+	return supplyRatePerBlock(m.BorrowRatePerBlock(utilizationRate), utilizationRate, reserveFactor)
+}
+
+// MarketInterestRateModel the interest rate model chosen by the market
+func MarketInterestRateModel(market *core.Market) InterestRateModel {
+	jumpRate := JumpRateModel{
+		BaseRate:       market.BaseRate,
+		Multiplier:     market.Multiplier,
+		JumpMultiplier: market.JumpMultiplier,
+		Kink:           market.Kink,
+	}
+
+	switch market.InterestRateModel {
+	case core.InterestRateModelWhitePaper:
+		return WhitePaperModel{
+			BaseRate:   market.BaseRate,
+			Multiplier: market.Multiplier,
+		}
+	case core.InterestRateModelStableCoin:
+		return StableCoinModel{
+			JumpRateModel: jumpRate,
+			FloorRate:     market.FloorRate,
+		}
+	default:
+		return jumpRate
+	}
+}
+
+// BorrowRatePerBlock current borrow rate per block of the market
+func BorrowRatePerBlock(market *core.Market) decimal.Decimal {
+	return MarketInterestRateModel(market).BorrowRatePerBlock(
+		UtilizationRate(market.TotalCash, market.TotalBorrows, market.Reserves),
+	)
+}
+
+// SupplyRatePerBlock current supply rate per block of the market
+func SupplyRatePerBlock(market *core.Market) decimal.Decimal {
+	return MarketInterestRateModel(market).SupplyRatePerBlock(
+		UtilizationRate(market.TotalCash, market.TotalBorrows, market.Reserves),
+		market.ReserveFactor,
+	)
+}
+
+func supplyRatePerBlock(borrowRate, utilizationRate, reserveFactor decimal.Decimal) decimal.Decimal {
+	oneMinusReserveFactor := decimal.NewFromInt(1).Sub(reserveFactor)
+	rateToPool := borrowRate.Mul(oneMinusReserveFactor)
+	return utilizationRate.Mul(rateToPool).Truncate(MaxPricision)
+}
@@ -0,0 +1,50 @@
+package compound
+
+import (
+	"compound/core"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketInterestRateModel(t *testing.T) {
+	market := &core.Market{
+		TotalCash:      decimal.NewFromInt(800),
+		TotalBorrows:   decimal.NewFromInt(200),
+		BaseRate:       decimal.NewFromFloat(0.02),
+		Multiplier:     decimal.NewFromFloat(0.1),
+		JumpMultiplier: decimal.NewFromFloat(0.5),
+		Kink:           decimal.NewFromFloat(0.1),
+		ReserveFactor:  decimal.NewFromFloat(0.1),
+		FloorRate:      decimal.NewFromFloat(0.5),
+	}
+	util := UtilizationRate(market.TotalCash, market.TotalBorrows, market.Reserves)
+
+	t.Run("jump-rate", func(t *testing.T) {
+		market.InterestRateModel = core.InterestRateModelDefault
+		expect := GetBorrowRatePerBlock(util, market.BaseRate, market.Multiplier, market.JumpMultiplier, market.Kink)
+		assert.True(t, expect.Equal(BorrowRatePerBlock(market)))
+
+		market.InterestRateModel = core.InterestRateModelJumpRate
+		assert.True(t, expect.Equal(BorrowRatePerBlock(market)))
+		assert.True(t, GetSupplyRatePerBlock(util, market.BaseRate, market.Multiplier, market.JumpMultiplier, market.Kink, market.ReserveFactor).
+			Equal(SupplyRatePerBlock(market)))
+	})
+
+	t.Run("whitepaper", func(t *testing.T) {
+		market.InterestRateModel = core.InterestRateModelWhitePaper
+		expect := GetBorrowRatePerBlock(util, market.BaseRate, market.Multiplier, decimal.Zero, decimal.Zero)
+		assert.True(t, expect.Equal(BorrowRatePerBlock(market)))
+	})
+
+	t.Run("stable-coin", func(t *testing.T) {
+		market.InterestRateModel = core.InterestRateModelStableCoin
+		floor := market.FloorRate.Div(BlocksPerYear).Truncate(MaxPricision)
+		assert.True(t, floor.Equal(BorrowRatePerBlock(market)))
+
+		market.FloorRate = decimal.Zero
+		expect := GetBorrowRatePerBlock(util, market.BaseRate, market.Multiplier, market.JumpMultiplier, market.Kink)
+		assert.True(t, expect.Equal(BorrowRatePerBlock(market)))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"compound/pkg/compound"
 	"context"
 	"errors"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -30,64 +31,96 @@ func New(
 
 // CalculateAccountLiquidity calculate account liquidity
 //
-// 	supplyValue = supply.collaterals * market.exchange_rate * market.collateral_factor * market.price
+// 	supplyValue = supply.collaterals * market.exchange_rate * market.collateral_factor * market.collateral_price
 // 	borrowValue = borrow.Balance()
 // 	liquidity = total_supply_values - total_borrow_values
 func (s *accountService) CalculateAccountLiquidity(ctx context.Context, userID string, newMarkets ...*core.Market) (decimal.Decimal, error) {
-	supplies, e := s.supplyStore.FindByUser(ctx, userID)
+	account, e := s.positions(ctx, userID, newMarkets)
 	if e != nil {
 		return decimal.Zero, e
 	}
-	supplyValue := decimal.Zero
+
+	return account.Liquidity, nil
+}
+
+// positions value the positions of the account by the markets given, the others are loaded from the store,
+// the values are not truncated
+func (s *accountService) positions(ctx context.Context, userID string, newMarkets []*core.Market) (*core.AccountSnapshot, error) {
+	account := core.AccountSnapshot{
+		UserID:   userID,
+		Supplies: make([]*core.AccountSupply, 0),
+		Borrows:  make([]*core.AccountBorrow, 0),
+	}
+
+	supplies, e := s.supplyStore.FindByUser(ctx, userID)
+	if e != nil {
+		return nil, e
+	}
+
 	for _, supply := range supplies {
 		market, e := s.findMarketByCtokenAssetID(ctx, newMarkets, supply.CTokenAssetID)
 		if e != nil {
 			market, e = s.marketStore.FindByCToken(ctx, supply.CTokenAssetID)
 			if e != nil {
-				return decimal.Zero, e
+				return nil, e
 			}
 		}
 
 		if market.ID == 0 {
-			return decimal.Zero, errors.New("no market")
+			return nil, errors.New("no market")
 		}
 
-		price := market.Price
-		exchangeRate := market.ExchangeRate
-		value := supply.Collaterals.Mul(exchangeRate).Mul(market.CollateralFactor).Mul(price)
-		supplyValue = supplyValue.Add(value)
+		price := market.CollateralPrice()
+		underlying := supply.Collaterals.Mul(market.ExchangeRate)
+		value := underlying.Mul(price)
+		collateralValue := value.Mul(market.CollateralFactor)
+		account.CollateralValue = account.CollateralValue.Add(collateralValue)
+		account.Supplies = append(account.Supplies, &core.AccountSupply{
+			AssetID:         market.AssetID,
+			CTokenAssetID:   market.CTokenAssetID,
+			Symbol:          market.Symbol,
+			Collaterals:     supply.Collaterals,
+			Underlying:      underlying,
+			Price:           price,
+			Value:           value,
+			CollateralValue: collateralValue,
+		})
 	}
 
 	borrows, e := s.borrowStore.FindByUser(ctx, userID)
 	if e != nil {
-		return decimal.Zero, e
+		return nil, e
 	}
 
-	borrowValue := decimal.Zero
-
 	for _, borrow := range borrows {
 		market, e := s.findMarketByAssetID(ctx, newMarkets, borrow.AssetID)
 		if e != nil {
 			market, e = s.marketStore.Find(ctx, borrow.AssetID)
 			if e != nil {
-				return decimal.Zero, e
+				return nil, e
 			}
 		}
 
 		if market.ID == 0 {
-			return decimal.Zero, errors.New("no market")
+			return nil, errors.New("no market")
 		}
 
-		price := market.Price
-
-		borrowBalance := compound.BorrowBalance(ctx, borrow, market)
-		value := borrowBalance.Mul(price)
-		borrowValue = borrowValue.Add(value)
+		balance := compound.BorrowBalance(ctx, borrow, market)
+		value := balance.Mul(market.Price)
+		account.BorrowValue = account.BorrowValue.Add(value)
+		account.Borrows = append(account.Borrows, &core.AccountBorrow{
+			AssetID:       borrow.AssetID,
+			Symbol:        market.Symbol,
+			Principal:     borrow.Principal,
+			InterestIndex: borrow.InterestIndex,
+			Balance:       balance,
+			Price:         market.Price,
+			Value:         value,
+		})
 	}
 
-	liquidity := supplyValue.Sub(borrowValue)
-
-	return liquidity, nil
+	account.Liquidity = account.CollateralValue.Sub(account.BorrowValue)
+	return &account, nil
 }
 
 // SeizeTokenAllowed
@@ -106,6 +139,66 @@ func (s *accountService) SeizeTokenAllowed(ctx context.Context, supply *core.Sup
 	return true
 }
 
+// Snapshot positions, liquidity and health factor of the account, valued by the markets accrued to now
+//
+// 	health_factor = total_collateral_values / total_borrow_values
+// 	liquidation_price = price - price * liquidity / (collateral_values - borrow_values of the asset)
+func (s *accountService) Snapshot(ctx context.Context, userID string) (*core.AccountSnapshot, error) {
+	markets, e := s.marketStore.All(ctx)
+	if e != nil {
+		return nil, e
+	}
+
+	now := time.Now()
+	for _, market := range markets {
+		compound.AccrueInterest(ctx, market, now)
+	}
+
+	snapshot, e := s.positions(ctx, userID, markets)
+	if e != nil {
+		return nil, e
+	}
+
+	if snapshot.BorrowValue.IsPositive() {
+		snapshot.HealthFactor = snapshot.CollateralValue.Div(snapshot.BorrowValue).Truncate(compound.MaxPricision)
+	}
+
+	for _, supply := range snapshot.Supplies {
+		// the collateral & the borrow prices of the asset are assumed to move together
+		exposure := supply.CollateralValue
+		for _, borrow := range snapshot.Borrows {
+			if borrow.AssetID == supply.AssetID {
+				exposure = exposure.Sub(borrow.Value)
+			}
+		}
+
+		if !exposure.IsPositive() || !snapshot.BorrowValue.IsPositive() {
+			continue
+		}
+
+		price := supply.Price.Sub(supply.Price.Mul(snapshot.Liquidity).Div(exposure))
+		if price.IsPositive() {
+			supply.LiquidationPrice = price.Truncate(compound.MaxPricision)
+		}
+	}
+
+	for _, supply := range snapshot.Supplies {
+		supply.Underlying = supply.Underlying.Truncate(compound.MaxPricision)
+		supply.Value = supply.Value.Truncate(compound.MaxPricision)
+		supply.CollateralValue = supply.CollateralValue.Truncate(compound.MaxPricision)
+	}
+
+	for _, borrow := range snapshot.Borrows {
+		borrow.Value = borrow.Value.Truncate(compound.MaxPricision)
+	}
+
+	snapshot.CollateralValue = snapshot.CollateralValue.Truncate(compound.MaxPricision)
+	snapshot.BorrowValue = snapshot.BorrowValue.Truncate(compound.MaxPricision)
+	snapshot.Liquidity = snapshot.Liquidity.Truncate(compound.MaxPricision)
+
+	return snapshot, nil
+}
+
 func (s *accountService) findMarketByAssetID(ctx context.Context, src []*core.Market, assetID string) (*core.Market, error) {
 	if src == nil {
 		return nil, errors.New("no market found")
@@ -0,0 +1,196 @@
+package account
+
+import (
+	"compound/core"
+	"compound/pkg/compound"
+	"compound/store/memory"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type accountTest struct {
+	ctx      context.Context
+	markets  core.IMarketStore
+	supplies core.ISupplyStore
+	borrows  core.IBorrowStore
+	accountz core.IAccountService
+	// block the block accrued a day ago
+	block int64
+}
+
+func newAccountTest(t *testing.T) *accountTest {
+	ctx := context.Background()
+	block, err := compound.GetBlockByTime(ctx, time.Now().Add(-24*time.Hour))
+	require.Nil(t, err)
+
+	a := &accountTest{
+		ctx:      ctx,
+		markets:  memory.NewMarketStore(),
+		supplies: memory.NewSupplyStore(),
+		borrows:  memory.NewBorrowStore(),
+		block:    block,
+	}
+	a.accountz = New(a.markets, a.supplies, a.borrows)
+	return a
+}
+
+func (a *accountTest) market(t *testing.T, symbol string, price decimal.Decimal) *core.Market {
+	market := &core.Market{
+		Symbol:           symbol,
+		AssetID:          uuid.Must(uuid.NewV4()).String(),
+		CTokenAssetID:    uuid.Must(uuid.NewV4()).String(),
+		TotalCash:        decimal.NewFromInt(1000),
+		TotalBorrows:     decimal.NewFromInt(500),
+		CTokens:          decimal.NewFromInt(1500),
+		InitExchangeRate: decimal.New(1, 0),
+		ExchangeRate:     decimal.New(1, 0),
+		ReserveFactor:    decimal.NewFromFloat(0.1),
+		BorrowIndex:      decimal.New(1, 0),
+		CollateralFactor: decimal.NewFromFloat(0.75),
+		BaseRate:         decimal.NewFromFloat(0.025),
+		Multiplier:       decimal.NewFromFloat(0.1),
+		JumpMultiplier:   decimal.NewFromFloat(0.5),
+		Kink:             decimal.NewFromFloat(0.8),
+		BlockNumber:      a.block,
+		Price:            price,
+		Status:           core.MarketStatusOpen,
+	}
+
+	require.Nil(t, a.markets.Create(a.ctx, market))
+	return market
+}
+
+func (a *accountTest) pledge(t *testing.T, userID string, market *core.Market, collaterals decimal.Decimal) {
+	require.Nil(t, a.supplies.Create(a.ctx, &core.Supply{
+		UserID:        userID,
+		CTokenAssetID: market.CTokenAssetID,
+		Collaterals:   collaterals,
+	}))
+}
+
+func (a *accountTest) borrow(t *testing.T, userID string, market *core.Market, principal decimal.Decimal) {
+	require.Nil(t, a.borrows.Create(a.ctx, &core.Borrow{
+		UserID:        userID,
+		AssetID:       market.AssetID,
+		Principal:     principal,
+		InterestIndex: decimal.New(1, 0),
+	}))
+}
+
+// accrued the markets accrued to the time
+func (a *accountTest) accrued(t *testing.T, at time.Time) []*core.Market {
+	markets, err := a.markets.All(a.ctx)
+	require.Nil(t, err)
+
+	for _, market := range markets {
+		compound.AccrueInterest(a.ctx, market, at)
+	}
+
+	return markets
+}
+
+func TestSnapshotMatchesLiquidity(t *testing.T) {
+	a := newAccountTest(t)
+	userID := uuid.Must(uuid.NewV4()).String()
+
+	btc := a.market(t, "BTC", decimal.NewFromInt(50000))
+	usdt := a.market(t, "USDT", decimal.New(1, 0))
+	a.pledge(t, userID, btc, decimal.New(1, 0))
+	a.borrow(t, userID, usdt, decimal.NewFromInt(20000))
+
+	before := time.Now()
+	snapshot, err := a.accountz.Snapshot(a.ctx, userID)
+	require.Nil(t, err)
+
+	// the borrow balance grows with the interest accrued since the market was updated
+	require.Len(t, snapshot.Borrows, 1)
+	assert.True(t, snapshot.Borrows[0].Balance.GreaterThan(decimal.NewFromInt(20000)))
+
+	// the liquidity equals the one the payee calculates with the markets accrued to the same block
+	low, err := a.accountz.CalculateAccountLiquidity(a.ctx, userID, a.accrued(t, before)...)
+	require.Nil(t, err)
+	high, err := a.accountz.CalculateAccountLiquidity(a.ctx, userID, a.accrued(t, time.Now())...)
+	require.Nil(t, err)
+	assert.True(t, snapshot.Liquidity.LessThanOrEqual(low.Truncate(compound.MaxPricision)), "%s > %s", snapshot.Liquidity, low)
+	assert.True(t, snapshot.Liquidity.GreaterThanOrEqual(high.Truncate(compound.MaxPricision)), "%s < %s", snapshot.Liquidity, high)
+
+	// the liquidity without accruing is higher
+	stale, err := a.accountz.CalculateAccountLiquidity(a.ctx, userID)
+	require.Nil(t, err)
+	assert.True(t, stale.GreaterThan(snapshot.Liquidity))
+}
+
+func TestSnapshotLiquidationPrice(t *testing.T) {
+	a := newAccountTest(t)
+	userID := uuid.Must(uuid.NewV4()).String()
+
+	btc := a.market(t, "BTC", decimal.NewFromInt(50000))
+	usdt := a.market(t, "USDT", decimal.New(1, 0))
+	a.pledge(t, userID, btc, decimal.New(1, 0))
+	a.pledge(t, userID, usdt, decimal.NewFromInt(1000))
+	a.borrow(t, userID, usdt, decimal.NewFromInt(20000))
+	// a little btc borrowed too, its value moves with the btc price as well
+	a.borrow(t, userID, btc, decimal.NewFromFloat(0.1))
+
+	snapshot, err := a.accountz.Snapshot(a.ctx, userID)
+	require.Nil(t, err)
+	require.True(t, snapshot.Liquidity.IsPositive())
+	require.True(t, snapshot.HealthFactor.GreaterThan(decimal.New(1, 0)))
+
+	var supply *core.AccountSupply
+	for _, s := range snapshot.Supplies {
+		if s.AssetID == btc.AssetID {
+			supply = s
+		}
+	}
+	require.NotNil(t, supply)
+	require.True(t, supply.LiquidationPrice.IsPositive())
+	require.True(t, supply.LiquidationPrice.LessThan(supply.Price))
+
+	// the account has no liquidity left at the liquidation price
+	markets, err := a.markets.All(a.ctx)
+	require.Nil(t, err)
+	for _, market := range markets {
+		compound.AccrueInterest(a.ctx, market, time.Now())
+		if market.AssetID == btc.AssetID {
+			market.Price = supply.LiquidationPrice
+		}
+	}
+
+	liquidity, err := a.accountz.CalculateAccountLiquidity(a.ctx, userID, markets...)
+	require.Nil(t, err)
+	assert.True(t, liquidity.Abs().LessThan(decimal.NewFromFloat(0.01)), "liquidity %s", liquidity)
+}
+
+func TestSnapshotValuesCollateralByTWAP(t *testing.T) {
+	a := newAccountTest(t)
+	userID := uuid.Must(uuid.NewV4()).String()
+
+	btc := a.market(t, "BTC", decimal.NewFromInt(50000))
+	btc.ValuationMode = core.ValuationModeTWAP
+	btc.TWAPPrice = decimal.NewFromInt(40000)
+	require.Nil(t, a.markets.Update(a.ctx, btc, btc.Version+1))
+
+	usdt := a.market(t, "USDT", decimal.New(1, 0))
+	a.pledge(t, userID, btc, decimal.New(1, 0))
+	a.borrow(t, userID, usdt, decimal.NewFromInt(20000))
+
+	snapshot, err := a.accountz.Snapshot(a.ctx, userID)
+	require.Nil(t, err)
+	require.Len(t, snapshot.Supplies, 1)
+
+	supply := snapshot.Supplies[0]
+	assert.Equal(t, "40000", supply.Price.String())
+	assert.True(t, supply.Value.Equal(supply.Underlying.Mul(supply.Price).Truncate(compound.MaxPricision)))
+	assert.Equal(t, snapshot.CollateralValue.String(), supply.CollateralValue.String())
+	// the borrow is valued by the spot price, the liquidity is zero at the liquidation price
+	assert.True(t, snapshot.Borrows[0].Price.Equal(usdt.Price))
+	zero := supply.LiquidationPrice.Mul(supply.Underlying).Mul(btc.CollateralFactor).Sub(snapshot.BorrowValue)
+	assert.True(t, zero.Abs().LessThan(decimal.NewFromFloat(0.0001)), "liquidity %s", zero)
+}
@@ -33,17 +33,25 @@ func (s *service) fetchUserName(ctx context.Context, userID string) string {
 }
 
 func (s *service) requestVoteAction(ctx context.Context, proposal *core.Proposal, sysver int64) (string, error) {
+	return s.requestAction(ctx, proposal, core.ActionTypeProposalVote, uuid.Modify(proposal.TraceID, s.system.ClientID), sysver)
+}
+
+func (s *service) requestRejectAction(ctx context.Context, proposal *core.Proposal, sysver int64) (string, error) {
+	return s.requestAction(ctx, proposal, core.ActionTypeProposalReject, uuid.Modify(proposal.TraceID, s.system.ClientID+"reject"), sysver)
+}
+
+func (s *service) requestAction(ctx context.Context, proposal *core.Proposal, action core.ActionType, traceID string, sysver int64) (string, error) {
 	trace, _ := uuid.FromString(proposal.TraceID)
 
 	var memo []byte
 	var err error
 	if sysver < 2 {
-		memo, err = mtg.Encode(int(core.ActionTypeProposalVote), trace)
+		memo, err = mtg.Encode(int(action), trace)
 		if err != nil {
 			return "", err
 		}
 	} else {
-		memo, err = mtg.Encode(core.ActionTypeProposalVote, trace)
+		memo, err = mtg.Encode(action, trace)
 		if err != nil {
 			return "", err
 		}
@@ -56,7 +64,7 @@ func (s *service) requestVoteAction(ctx context.Context, proposal *core.Proposal
 	input := mixin.TransferInput{
 		AssetID: s.system.VoteAsset,
 		Amount:  s.system.VoteAmount,
-		TraceID: uuid.Modify(proposal.TraceID, s.system.ClientID),
+		TraceID: traceID,
 		Memo:    base64.StdEncoding.EncodeToString(memo),
 	}
 	input.OpponentMultisig.Receivers = s.system.MemberIDs
@@ -6,6 +6,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 )
 
 func (s *service) ListItems(ctx context.Context, p *core.Proposal) ([]core.ProposalItem, error) {
@@ -18,6 +20,11 @@ func (s *service) ListItems(ctx context.Context, p *core.Proposal) ([]core.Propo
 			return nil, err
 		}
 
+		supplyCap := action.SupplyCap.String()
+		if action.SupplyCap.IsNegative() {
+			supplyCap = "unchanged"
+		}
+
 		items = []core.ProposalItem{
 			{
 				Key:   "symbol",
@@ -87,6 +94,10 @@ func (s *service) ListItems(ctx context.Context, p *core.Proposal) ([]core.Propo
 				Key:   "max_pledge",
 				Value: action.MaxPledge.String(),
 			},
+			{
+				Key:   "supply_cap",
+				Value: supplyCap,
+			},
 		}
 	case core.ActionTypeProposalWithdrawReserves:
 		var action proposal.WithdrawReq
@@ -150,6 +161,131 @@ func (s *service) ListItems(ctx context.Context, p *core.Proposal) ([]core.Propo
 			},
 		}
 
+	case core.ActionTypeProposalSetInterestRateModel:
+		var action proposal.InterestRateModelReq
+		if err := json.Unmarshal(p.Content, &action); err != nil {
+			return nil, err
+		}
+		items = []core.ProposalItem{
+			{
+				Key:    "asset",
+				Value:  action.AssetID,
+				Hint:   s.fetchAssetSymbol(ctx, action.AssetID),
+				Action: assetAction(action.AssetID),
+			},
+			{
+				Key:   "model",
+				Value: action.Model.String(),
+			},
+			{
+				Key:   "floor_rate",
+				Value: action.FloorRate.String(),
+			},
+		}
+
+	case core.ActionTypeProposalSetIsolation:
+		var action proposal.IsolationReq
+		if err := json.Unmarshal(p.Content, &action); err != nil {
+			return nil, err
+		}
+		items = []core.ProposalItem{
+			{
+				Key:    "asset",
+				Value:  action.AssetID,
+				Hint:   s.fetchAssetSymbol(ctx, action.AssetID),
+				Action: assetAction(action.AssetID),
+			},
+			{
+				Key:   "mode",
+				Value: action.Mode.String(),
+			},
+		}
+
+		if action.Mode == core.CollateralModeIsolated {
+			items = append(items, core.ProposalItem{
+				Key:   "debt_ceiling",
+				Value: action.DebtCeiling.String(),
+			})
+
+			for _, assetID := range action.BorrowAssets {
+				items = append(items, core.ProposalItem{
+					Key:    "borrow_asset",
+					Value:  assetID,
+					Hint:   s.fetchAssetSymbol(ctx, assetID),
+					Action: assetAction(assetID),
+				})
+			}
+		}
+
+	case core.ActionTypeProposalSetPriceGuard:
+		var action proposal.PriceGuardReq
+		if err := json.Unmarshal(p.Content, &action); err != nil {
+			return nil, err
+		}
+		items = []core.ProposalItem{
+			{
+				Key:    "asset",
+				Value:  action.AssetID,
+				Hint:   s.fetchAssetSymbol(ctx, action.AssetID),
+				Action: assetAction(action.AssetID),
+			},
+			{
+				Key:   "samples",
+				Value: strconv.Itoa(action.Samples),
+			},
+			{
+				Key:   "window",
+				Value: (time.Duration(action.Window) * time.Second).String(),
+			},
+			{
+				Key:   "max_deviation",
+				Value: action.MaxDeviation.String(),
+			},
+		}
+
+	case core.ActionTypeProposalSetMaxPriceAge:
+		var action proposal.MaxPriceAgeReq
+		if err := json.Unmarshal(p.Content, &action); err != nil {
+			return nil, err
+		}
+		items = []core.ProposalItem{
+			{
+				Key:    "asset",
+				Value:  action.AssetID,
+				Hint:   s.fetchAssetSymbol(ctx, action.AssetID),
+				Action: assetAction(action.AssetID),
+			},
+			{
+				Key:   "max_age",
+				Value: (time.Duration(action.MaxAge) * time.Second).String(),
+			},
+		}
+
+	case core.ActionTypeProposalSetTWAP:
+		var action proposal.TWAPReq
+		if err := json.Unmarshal(p.Content, &action); err != nil {
+			return nil, err
+		}
+		items = []core.ProposalItem{
+			{
+				Key:    "asset",
+				Value:  action.AssetID,
+				Hint:   s.fetchAssetSymbol(ctx, action.AssetID),
+				Action: assetAction(action.AssetID),
+			},
+			{
+				Key:   "mode",
+				Value: action.Mode.String(),
+			},
+		}
+
+		if action.Window > 0 {
+			items = append(items, core.ProposalItem{
+				Key:   "window",
+				Value: (time.Duration(action.Window) * time.Second).String(),
+			})
+		}
+
 	case core.ActionTypeProposalAddOracleSigner:
 		var action proposal.AddOracleSignerReq
 		if err := json.Unmarshal(p.Content, &action); err != nil {
@@ -167,6 +303,36 @@ func (s *service) ListItems(ctx context.Context, p *core.Proposal) ([]core.Propo
 				Value: action.PublicKey,
 			},
 		}
+
+	case core.ActionTypeProposalCancel:
+		var action proposal.CancelReq
+		if err := json.Unmarshal(p.Content, &action); err != nil {
+			return nil, err
+		}
+		items = []core.ProposalItem{
+			{
+				Key:   "proposal",
+				Value: action.TraceID,
+			},
+		}
+
+	case core.ActionTypeProposalRotateOracleSigner:
+		var action proposal.RotateOracleSignerReq
+		if err := json.Unmarshal(p.Content, &action); err != nil {
+			return nil, err
+		}
+		items = []core.ProposalItem{
+			{
+				Key:    "client",
+				Value:  action.UserID,
+				Hint:   s.fetchUserName(ctx, action.UserID),
+				Action: userAction(action.UserID),
+			},
+			{
+				Key:   "public_key",
+				Value: action.PublicKey,
+			},
+		}
 	}
 	return items, nil
 }
@@ -78,6 +78,20 @@ func (s *service) ProposalCreated(ctx context.Context, p *core.Proposal, by stri
 		Action: voteAction,
 	})
 
+	// the members can reject the proposal since sysversion 3
+	if sysver >= 3 {
+		rejectAction, err := s.requestRejectAction(ctx, p, sysver)
+		if err != nil {
+			return err
+		}
+
+		items = append(items, Item{
+			Key:    "Reject",
+			Value:  "Reject",
+			Action: rejectAction,
+		})
+	}
+
 	buttons := generateButtons(items)
 	buttonsData, _ := json.Marshal(buttons)
 	post := execute("proposal_created", view)
@@ -152,3 +166,47 @@ func (s *service) ProposalPassed(ctx context.Context, p *core.Proposal, sysver i
 
 	return s.messages.Create(ctx, messages)
 }
+
+// ProposalRejected send proposal rejected message to all the node managers
+func (s *service) ProposalRejected(ctx context.Context, p *core.Proposal, by string, sysver int64) error {
+	view := Proposal{
+		ApprovedBy: s.fetchUserName(ctx, by),
+	}
+
+	post := execute("proposal_rejected", view)
+
+	var messages []*core.Message
+	for _, admin := range s.system.Admins {
+		quote := uuid.Modify(p.TraceID, s.system.ClientID+admin)
+		messages = append(messages, core.BuildMessage(&mixin.MessageRequest{
+			RecipientID:    admin,
+			ConversationID: mixin.UniqueConversationID(s.system.ClientID, admin),
+			MessageID:      uuid.Modify(quote, "Proposal Rejected"),
+			Category:       mixin.MessageCategoryPlainText,
+			Data:           base64.StdEncoding.EncodeToString(post),
+			QuoteMessageID: quote,
+		}))
+	}
+
+	return s.messages.Create(ctx, messages)
+}
+
+// ProposalExpired send proposal expired message to all the node managers
+func (s *service) ProposalExpired(ctx context.Context, p *core.Proposal, sysver int64) error {
+	post := execute("proposal_expired", nil)
+
+	var messages []*core.Message
+	for _, admin := range s.system.Admins {
+		quote := uuid.Modify(p.TraceID, s.system.ClientID+admin)
+		messages = append(messages, core.BuildMessage(&mixin.MessageRequest{
+			RecipientID:    admin,
+			ConversationID: mixin.UniqueConversationID(s.system.ClientID, admin),
+			MessageID:      uuid.Modify(quote, "Proposal Expired"),
+			Category:       mixin.MessageCategoryPlainText,
+			Data:           base64.StdEncoding.EncodeToString(post),
+			QuoteMessageID: quote,
+		}))
+	}
+
+	return s.messages.Create(ctx, messages)
+}
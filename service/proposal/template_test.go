@@ -33,6 +33,7 @@ func Test_execute(t *testing.T) {
 
 	tests := []testData{
 		addTestData("proposal_passed", nil),
+		addTestData("proposal_expired", nil),
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"compound/core"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/fox-one/mixin-sdk-go"
+	"github.com/shopspring/decimal"
+)
+
+// multisigDivergence the multisig request signed by the other members disagrees with the transfer
+func multisigDivergence(req *mixin.MultisigRequest, transfer *core.Transfer, reason error) *core.DivergenceError {
+	d := newDivergence(core.DivergenceStageMultisig, transfer, reason)
+	d.Signers = req.Signers
+	d.ObservedMemo = req.Memo
+	d.ObservedAmount = req.Amount
+	d.ObservedReceivers = req.Receivers
+
+	return &core.DivergenceError{Divergence: d}
+}
+
+// transactionDivergence the transaction spent the outputs disagrees with the transfer
+func transactionDivergence(tx *mixin.Transaction, outputs []*core.Output, transfer *core.Transfer, reason error) *core.DivergenceError {
+	d := newDivergence(core.DivergenceStageTransaction, transfer, reason)
+	d.ObservedMemo = string(tx.Extra)
+
+	if len(tx.Outputs) > 0 {
+		d.ObservedAmount, _ = decimal.NewFromString(tx.Outputs[0].Amount.String())
+
+		for _, key := range tx.Outputs[0].Keys {
+			d.ObservedReceivers = append(d.ObservedReceivers, key.String())
+		}
+	}
+
+	if len(outputs) > 0 && outputs[0].UTXO != nil {
+		d.Signers = Signers(tx, outputs[0].UTXO.Members)
+	}
+
+	return &core.DivergenceError{Divergence: d}
+}
+
+func newDivergence(stage string, transfer *core.Transfer, reason error) *core.Divergence {
+	data, _ := json.Marshal(transfer)
+
+	return &core.Divergence{
+		TraceID:           transfer.TraceID,
+		AssetID:           transfer.AssetID,
+		Stage:             stage,
+		Reason:            reason.Error(),
+		ExpectedMemo:      transfer.Memo,
+		ExpectedAmount:    transfer.Amount,
+		ExpectedReceivers: transfer.Opponents,
+		Transfer:          data,
+	}
+}
+
+// Signers the members signed the first input of the transaction,
+// the keys of the multisig outputs are derived from the sorted members
+func Signers(tx *mixin.Transaction, members []string) []string {
+	var indexes []int
+	if tx.AggregatedSignature != nil {
+		indexes = append(indexes, tx.AggregatedSignature.Signers...)
+	} else if len(tx.Signatures) > 0 {
+		for idx := range tx.Signatures[0] {
+			indexes = append(indexes, int(idx))
+		}
+	}
+	sort.Ints(indexes)
+
+	members = append([]string{}, members...)
+	sort.Strings(members)
+
+	signers := make([]string, 0, len(indexes))
+	for _, idx := range indexes {
+		if idx < len(members) {
+			signers = append(signers, members[idx])
+		} else {
+			signers = append(signers, fmt.Sprintf("key #%d", idx))
+		}
+	}
+
+	return signers
+}
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"compound/core"
+	"errors"
+	"testing"
+
+	"github.com/fox-one/mixin-sdk-go"
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionDivergence(t *testing.T) {
+	transfer := &core.Transfer{
+		TraceID:   uuid.Must(uuid.NewV4()).String(),
+		AssetID:   uuid.Must(uuid.NewV4()).String(),
+		Amount:    decimal.NewFromInt(2),
+		Memo:      "local",
+		Opponents: []string{uuid.Must(uuid.NewV4()).String()},
+	}
+
+	var keys [2]mixin.Key
+	keys[0][0], keys[1][31] = 1, 2
+
+	tx := &mixin.Transaction{
+		Extra: []byte("other"),
+		Outputs: []*mixin.Output{
+			{Amount: mixin.NewIntegerFromString("1"), Keys: keys[:]},
+		},
+	}
+
+	d := transactionDivergence(tx, nil, transfer, errors.New("memo not match")).Divergence
+	assert.Equal(t, core.DivergenceStageTransaction, d.Stage)
+	assert.Equal(t, "other", d.ObservedMemo)
+	assert.Equal(t, "1", d.ObservedAmount.String())
+	assert.Equal(t, []string(transfer.Opponents), []string(d.ExpectedReceivers))
+	assert.Equal(t, []string{
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+	}, []string(d.ObservedReceivers))
+}
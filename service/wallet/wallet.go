@@ -77,7 +77,7 @@ func (s *walletService) Spend(ctx context.Context, outputs []*core.Output, trans
 		}
 
 		if err := s.validateTransaction(tx, transfer); err != nil {
-			return nil, fmt.Errorf("validateTransaction failed: %w", err)
+			return nil, transactionDivergence(tx, outputs, transfer, err)
 		}
 	case mixin.UTXOStateSigned:
 		sig, err := s.client.CreateMultisig(ctx, mixin.MultisigActionSign, tx)
@@ -98,7 +98,7 @@ func (s *walletService) Spend(ctx context.Context, outputs []*core.Output, trans
 				// }
 
 				// 消费失败
-				return nil, valiErr
+				return nil, multisigDivergence(sig, transfer, valiErr)
 			}
 
 			sig, err = s.client.SignMultisig(ctx, sig.RequestID, s.pin)
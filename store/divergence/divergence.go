@@ -0,0 +1,107 @@
+package divergence
+
+import (
+	"compound/core"
+	"context"
+
+	"github.com/fox-one/pkg/store/db"
+	"github.com/jinzhu/gorm"
+)
+
+type divergenceStore struct {
+	db *db.DB
+}
+
+// New new divergence store
+func New(db *db.DB) core.DivergenceStore {
+	return &divergenceStore{
+		db: db,
+	}
+}
+
+func init() {
+	db.RegisterMigrate(func(db *db.DB) error {
+		tx := db.Update().Model(core.Divergence{})
+
+		if err := tx.AutoMigrate(core.Divergence{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.AddUniqueIndex("idx_divergences_trace", "trace_id").Error; err != nil {
+			return err
+		}
+
+		if err := tx.AddIndex("idx_divergences_resolved", "resolved").Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *divergenceStore) Save(ctx context.Context, divergence *core.Divergence) error {
+	return s.db.Tx(func(tx *db.DB) error {
+		var last core.Divergence
+		if err := tx.Update().Where("trace_id = ?", divergence.TraceID).Take(&last).Error; err != nil {
+			if gorm.IsRecordNotFoundError(err) {
+				return tx.Update().Create(divergence).Error
+			}
+
+			return err
+		}
+
+		divergence.ID = last.ID
+		divergence.CreatedAt = last.CreatedAt
+		divergence.Resolved = false
+		return tx.Update().Model(divergence).Updates(map[string]interface{}{
+			"asset_id":           divergence.AssetID,
+			"stage":              divergence.Stage,
+			"reason":             divergence.Reason,
+			"signers":            divergence.Signers,
+			"expected_memo":      divergence.ExpectedMemo,
+			"observed_memo":      divergence.ObservedMemo,
+			"expected_amount":    divergence.ExpectedAmount,
+			"observed_amount":    divergence.ObservedAmount,
+			"expected_receivers": divergence.ExpectedReceivers,
+			"observed_receivers": divergence.ObservedReceivers,
+			"transfer":           divergence.Transfer,
+			"resolved":           false,
+		}).Error
+	})
+}
+
+func (s *divergenceStore) Find(ctx context.Context, traceID string) (*core.Divergence, error) {
+	var divergence core.Divergence
+	if err := s.db.View().Where("trace_id = ?", traceID).Take(&divergence).Error; err != nil {
+		return nil, err
+	}
+
+	return &divergence, nil
+}
+
+func (s *divergenceStore) List(ctx context.Context, cursor int64, limit int) ([]*core.Divergence, error) {
+	query := s.db.View().Order("id DESC").Limit(limit)
+	if cursor > 0 {
+		query = query.Where("id < ?", cursor)
+	}
+
+	var divergences []*core.Divergence
+	if err := query.Find(&divergences).Error; err != nil {
+		return nil, err
+	}
+
+	return divergences, nil
+}
+
+func (s *divergenceStore) ListUnresolved(ctx context.Context) ([]*core.Divergence, error) {
+	var divergences []*core.Divergence
+	if err := s.db.View().Where("resolved = ?", false).Order("id").Find(&divergences).Error; err != nil {
+		return nil, err
+	}
+
+	return divergences, nil
+}
+
+func (s *divergenceStore) Resolve(ctx context.Context, traceID string) error {
+	return s.db.Update().Model(core.Divergence{}).Where("trace_id = ?", traceID).Update("resolved", true).Error
+}
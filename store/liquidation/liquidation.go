@@ -0,0 +1,65 @@
+package liquidation
+
+import (
+	"compound/core"
+	"context"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+)
+
+type candidateStore struct {
+	db *db.DB
+}
+
+// NewCandidateStore new liquidation candidate store
+func NewCandidateStore(db *db.DB) core.LiquidationCandidateStore {
+	return &candidateStore{
+		db: db,
+	}
+}
+
+func init() {
+	db.RegisterMigrate(func(db *db.DB) error {
+		tx := db.Update().Model(core.LiquidationCandidate{})
+
+		if err := tx.AutoMigrate(core.LiquidationCandidate{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *candidateStore) Save(ctx context.Context, candidates []*core.LiquidationCandidate, scannedAt time.Time) error {
+	return s.db.Tx(func(tx *db.DB) error {
+		for _, candidate := range candidates {
+			update := tx.Update().Model(core.LiquidationCandidate{}).Where("user_id = ?", candidate.UserID).Updates(map[string]interface{}{
+				"shortfall":  candidate.Shortfall,
+				"repays":     candidate.Repays,
+				"seizes":     candidate.Seizes,
+				"scanned_at": candidate.ScannedAt,
+			})
+			if update.Error != nil {
+				return update.Error
+			}
+
+			if update.RowsAffected == 0 {
+				if err := tx.Update().Create(candidate).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Update().Where("scanned_at < ?", scannedAt).Delete(core.LiquidationCandidate{}).Error
+	})
+}
+
+func (s *candidateStore) List(ctx context.Context) ([]*core.LiquidationCandidate, error) {
+	var candidates []*core.LiquidationCandidate
+	if err := s.db.View().Order("shortfall DESC").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
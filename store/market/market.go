@@ -2,6 +2,7 @@ package market
 
 import (
 	"compound/core"
+	"compound/pkg/compound"
 	"context"
 
 	"github.com/fox-one/pkg/store/db"
@@ -112,20 +113,43 @@ func (s *marketStore) Update(ctx context.Context, market *core.Market, version i
 		// do real update
 		oldVersion := market.Version
 		market.Version = version
-		tx := s.db.Update().Model(market).Where("version=?", oldVersion).Updates(market)
 
-		if tx.Error != nil {
-			return tx.Error
-		}
-
-		if tx.RowsAffected == 0 {
-			return db.ErrOptimisticLock
-		}
+		return s.db.Tx(func(tx *db.DB) error {
+			update := tx.Update().Model(market).Where("version=?", oldVersion).Updates(market)
+			if update.Error != nil {
+				return update.Error
+			}
+
+			if update.RowsAffected == 0 {
+				return db.ErrOptimisticLock
+			}
+
+			// Updates with struct skips the zero values, write the columns can be set back to zero explicitly
+			if err := tx.Update().Model(market).Updates(zeroableColumns(market)).Error; err != nil {
+				return err
+			}
+
+			// keep the market history
+			snapshot := core.NewMarketSnapshot(market, compound.GetTimeByBlock(market.BlockNumber))
+			return tx.Update().Where("asset_id = ? AND version = ?", snapshot.AssetID, snapshot.Version).FirstOrCreate(snapshot).Error
+		})
 	}
 
 	return nil
 }
 
+func zeroableColumns(market *core.Market) map[string]interface{} {
+	return map[string]interface{}{
+		"interest_rate_model":    market.InterestRateModel,
+		"isolated_borrow_assets": market.IsolatedBorrowAssets,
+		"price_threshold":        market.PriceThreshold,
+		"price_samples":          market.PriceSamples,
+		"price_window":           market.PriceWindow,
+		"max_price_age":          market.MaxPriceAge,
+		"twap_window":            market.TWAPWindow,
+	}
+}
+
 func afterFind(market *core.Market) *core.Market {
 	if !market.ExchangeRate.IsPositive() {
 		market.ExchangeRate = decimal.New(1, 0)
@@ -0,0 +1,134 @@
+package market
+
+import (
+	"compound/core"
+	"compound/pkg/compound"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// every new version persisted by Update is snapshotted once
+func TestUpdateSnapshots(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(db.Config{
+		Dialect: "sqlite3",
+		Host:    filepath.Join(t.TempDir(), "market.db"),
+	})
+	require.Nil(t, err)
+	defer conn.Close()
+	require.Nil(t, db.Migrate(conn))
+
+	var (
+		markets   = New(conn)
+		snapshots = NewSnapshotStore(conn)
+	)
+
+	block, err := compound.GetBlockByTime(ctx, time.Now())
+	require.Nil(t, err)
+
+	market := &core.Market{
+		Symbol:        "BTC",
+		AssetID:       uuid.Must(uuid.NewV4()).String(),
+		CTokenAssetID: uuid.Must(uuid.NewV4()).String(),
+		TotalCash:     decimal.NewFromInt(10),
+		BlockNumber:   block,
+		Price:         decimal.NewFromInt(30000),
+	}
+	require.Nil(t, markets.Create(ctx, market))
+
+	// the market created isn't a version
+	from, to := compound.GetTimeByBlock(block).Add(-time.Hour), time.Now().Add(time.Hour)
+	list, err := snapshots.List(ctx, market.AssetID, from, to)
+	require.Nil(t, err)
+	assert.Empty(t, list)
+
+	market.TotalCash = decimal.NewFromInt(12)
+	require.Nil(t, markets.Update(ctx, market, 1))
+	market.Price = decimal.NewFromInt(31000)
+	require.Nil(t, markets.Update(ctx, market, 2))
+	// an outdated version isn't persisted
+	require.Nil(t, markets.Update(ctx, market, 2))
+
+	// a stale copy fails the optimistic lock without snapshotting
+	stale, err := markets.Find(ctx, market.AssetID)
+	require.Nil(t, err)
+	stale.Version = 1
+	stale.TotalCash = decimal.NewFromInt(100)
+	assert.Equal(t, db.ErrOptimisticLock, markets.Update(ctx, stale, 3))
+
+	list, err = snapshots.List(ctx, market.AssetID, from, to)
+	require.Nil(t, err)
+	if assert.Len(t, list, 2) {
+		assert.Equal(t, int64(1), list[0].Version)
+		assert.Equal(t, "12", list[0].TotalCash.String())
+		assert.Equal(t, "30000", list[0].Price.String())
+
+		assert.Equal(t, int64(2), list[1].Version)
+		assert.Equal(t, "31000", list[1].Price.String())
+		assert.Equal(t, compound.GetTimeByBlock(block).Unix(), list[1].CreatedAt.Unix())
+	}
+
+	market, err = markets.Find(ctx, market.AssetID)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), market.Version)
+	assert.Equal(t, "12", market.TotalCash.String())
+}
+
+// the fields set back to zero are persisted
+func TestUpdateZeroValues(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(db.Config{
+		Dialect: "sqlite3",
+		Host:    filepath.Join(t.TempDir(), "market.db"),
+	})
+	require.Nil(t, err)
+	defer conn.Close()
+	require.Nil(t, db.Migrate(conn))
+
+	markets := New(conn)
+	market := &core.Market{
+		Symbol:        "BTC",
+		AssetID:       uuid.Must(uuid.NewV4()).String(),
+		CTokenAssetID: uuid.Must(uuid.NewV4()).String(),
+	}
+	require.Nil(t, markets.Create(ctx, market))
+
+	market.PriceThreshold = 3
+	market.MaxPriceAge = 600
+	market.PriceSamples = 5
+	market.PriceWindow = 300
+	market.TWAPWindow = 3600
+	market.InterestRateModel = core.InterestRateModelStableCoin
+	market.IsolatedBorrowAssets = []string{uuid.Must(uuid.NewV4()).String()}
+	require.Nil(t, markets.Update(ctx, market, 1))
+
+	market.PriceThreshold = 0
+	market.MaxPriceAge = 0
+	market.PriceSamples = 0
+	market.PriceWindow = 0
+	market.TWAPWindow = 0
+	market.InterestRateModel = core.InterestRateModelDefault
+	market.IsolatedBorrowAssets = nil
+	require.Nil(t, markets.Update(ctx, market, 2))
+
+	market, err = markets.Find(ctx, market.AssetID)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), market.Version)
+	assert.Zero(t, market.PriceThreshold)
+	assert.Zero(t, market.MaxPriceAge)
+	assert.Zero(t, market.PriceSamples)
+	assert.Zero(t, market.PriceWindow)
+	assert.Zero(t, market.TWAPWindow)
+	assert.Equal(t, core.InterestRateModelDefault, market.InterestRateModel)
+	assert.Empty(t, market.IsolatedBorrowAssets)
+}
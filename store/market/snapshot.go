@@ -0,0 +1,41 @@
+package market
+
+import (
+	"compound/core"
+	"context"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+)
+
+type snapshotStore struct {
+	db *db.DB
+}
+
+// NewSnapshotStore new market snapshot store
+func NewSnapshotStore(db *db.DB) core.MarketSnapshotStore {
+	return &snapshotStore{db: db}
+}
+
+func init() {
+	db.RegisterMigrate(func(db *db.DB) error {
+		tx := db.Update().Model(core.MarketSnapshot{})
+		if err := tx.AutoMigrate(core.MarketSnapshot{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *snapshotStore) List(ctx context.Context, assetID string, from, to time.Time) ([]*core.MarketSnapshot, error) {
+	var snapshots []*core.MarketSnapshot
+	if err := s.db.View().
+		Where("asset_id = ? AND created_at >= ? AND created_at < ?", assetID, from, to).
+		Order("version ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
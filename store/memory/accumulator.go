@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sync"
+)
+
+type priceAccumulatorStore struct {
+	mux          sync.RWMutex
+	lastID       int64
+	accumulators map[string][]*core.PriceAccumulator
+}
+
+// NewPriceAccumulatorStore new in-memory price accumulator store
+func NewPriceAccumulatorStore() core.PriceAccumulatorStore {
+	return &priceAccumulatorStore{
+		accumulators: make(map[string][]*core.PriceAccumulator),
+	}
+}
+
+func (s *priceAccumulatorStore) Save(ctx context.Context, accumulator *core.PriceAccumulator) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	list := s.accumulators[accumulator.AssetID]
+	idx := len(list)
+	for idx > 0 && list[idx-1].Block >= accumulator.Block {
+		idx--
+	}
+
+	if idx < len(list) && list[idx].Block == accumulator.Block {
+		if last := list[idx]; accumulator.Version > last.Version {
+			last.Version = accumulator.Version
+			last.Price = accumulator.Price
+		}
+
+		return nil
+	}
+
+	s.lastID++
+	accumulator.ID = s.lastID
+	a := *accumulator
+
+	// keep the accumulators ordered by block
+	list = append(list, nil)
+	copy(list[idx+1:], list[idx:])
+	list[idx] = &a
+	s.accumulators[accumulator.AssetID] = list
+	return nil
+}
+
+func (s *priceAccumulatorStore) Find(ctx context.Context, assetID string, block int64) (*core.PriceAccumulator, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	list := s.accumulators[assetID]
+	for idx := len(list) - 1; idx >= 0; idx-- {
+		if list[idx].Block <= block {
+			accumulator := *list[idx]
+			return &accumulator, nil
+		}
+	}
+
+	return &core.PriceAccumulator{}, nil
+}
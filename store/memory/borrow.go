@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+)
+
+type borrowStore struct {
+	mux     sync.RWMutex
+	lastID  uint64
+	borrows map[string]*core.Borrow
+}
+
+// NewBorrowStore new in-memory borrow store
+func NewBorrowStore() core.IBorrowStore {
+	return &borrowStore{
+		borrows: make(map[string]*core.Borrow),
+	}
+}
+
+func borrowKey(userID, assetID string) string {
+	return userID + ":" + assetID
+}
+
+func (s *borrowStore) Create(ctx context.Context, borrow *core.Borrow) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	key := borrowKey(borrow.UserID, borrow.AssetID)
+	if _, ok := s.borrows[key]; ok {
+		return errDuplicated
+	}
+
+	s.lastID++
+	borrow.ID = s.lastID
+	now := time.Now()
+	borrow.CreatedAt, borrow.UpdatedAt = now, now
+
+	v := *borrow
+	s.borrows[key] = &v
+	return nil
+}
+
+func (s *borrowStore) Find(ctx context.Context, userID string, assetID string) (*core.Borrow, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if v, ok := s.borrows[borrowKey(userID, assetID)]; ok {
+		borrow := *v
+		return &borrow, nil
+	}
+
+	return &core.Borrow{}, nil
+}
+
+func (s *borrowStore) FindByUser(ctx context.Context, userID string) ([]*core.Borrow, error) {
+	return s.filter(func(borrow *core.Borrow) bool {
+		return borrow.UserID == userID
+	}), nil
+}
+
+func (s *borrowStore) FindByAssetID(ctx context.Context, assetID string) ([]*core.Borrow, error) {
+	return s.filter(func(borrow *core.Borrow) bool {
+		return borrow.AssetID == assetID
+	}), nil
+}
+
+func (s *borrowStore) All(ctx context.Context) ([]*core.Borrow, error) {
+	return s.filter(func(borrow *core.Borrow) bool {
+		return true
+	}), nil
+}
+
+func (s *borrowStore) filter(match func(borrow *core.Borrow) bool) []*core.Borrow {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var borrows []*core.Borrow
+	for _, v := range s.borrows {
+		if match(v) {
+			borrow := *v
+			borrows = append(borrows, &borrow)
+		}
+	}
+
+	sort.Slice(borrows, func(i, j int) bool {
+		return borrows[i].ID < borrows[j].ID
+	})
+
+	return borrows
+}
+
+func (s *borrowStore) CountOfBorrowers(ctx context.Context, assetID string) (int64, error) {
+	borrows := s.filter(func(borrow *core.Borrow) bool {
+		return borrow.AssetID == assetID
+	})
+
+	return int64(len(borrows)), nil
+}
+
+func (s *borrowStore) Update(ctx context.Context, borrow *core.Borrow, version int64) error {
+	if version <= borrow.Version {
+		return nil
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	v, ok := s.borrows[borrowKey(borrow.UserID, borrow.AssetID)]
+	if !ok || v.Version != borrow.Version {
+		return db.ErrOptimisticLock
+	}
+
+	borrow.Version = version
+	borrow.UpdatedAt = time.Now()
+	*v = *borrow
+	return nil
+}
+
+func (s *borrowStore) Users(ctx context.Context) ([]string, error) {
+	borrows, _ := s.All(ctx)
+
+	var (
+		users []string
+		seen  = make(map[string]bool, len(borrows))
+	)
+
+	for _, borrow := range borrows {
+		if !seen[borrow.UserID] {
+			seen[borrow.UserID] = true
+			users = append(users, borrow.UserID)
+		}
+	}
+
+	return users, nil
+}
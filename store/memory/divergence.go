@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+type divergenceStore struct {
+	mux         sync.RWMutex
+	divergences []*core.Divergence
+}
+
+// NewDivergenceStore new in-memory divergence store
+func NewDivergenceStore() core.DivergenceStore {
+	return &divergenceStore{}
+}
+
+func (s *divergenceStore) find(traceID string) *core.Divergence {
+	for _, d := range s.divergences {
+		if d.TraceID == traceID {
+			return d
+		}
+	}
+
+	return nil
+}
+
+func (s *divergenceStore) Save(ctx context.Context, divergence *core.Divergence) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	now := time.Now()
+	divergence.UpdatedAt = now
+	divergence.Resolved = false
+
+	if last := s.find(divergence.TraceID); last != nil {
+		divergence.ID = last.ID
+		divergence.CreatedAt = last.CreatedAt
+		*last = *divergence
+		return nil
+	}
+
+	divergence.ID = int64(len(s.divergences) + 1)
+	divergence.CreatedAt = now
+	d := *divergence
+	s.divergences = append(s.divergences, &d)
+	return nil
+}
+
+func (s *divergenceStore) Find(ctx context.Context, traceID string) (*core.Divergence, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	d := s.find(traceID)
+	if d == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	divergence := *d
+	return &divergence, nil
+}
+
+func (s *divergenceStore) List(ctx context.Context, cursor int64, limit int) ([]*core.Divergence, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var divergences []*core.Divergence
+	for idx := len(s.divergences) - 1; idx >= 0; idx-- {
+		if limit > 0 && len(divergences) >= limit {
+			break
+		}
+
+		if d := s.divergences[idx]; cursor == 0 || d.ID < cursor {
+			divergence := *d
+			divergences = append(divergences, &divergence)
+		}
+	}
+
+	return divergences, nil
+}
+
+func (s *divergenceStore) ListUnresolved(ctx context.Context) ([]*core.Divergence, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var divergences []*core.Divergence
+	for _, d := range s.divergences {
+		if !d.Resolved {
+			divergence := *d
+			divergences = append(divergences, &divergence)
+		}
+	}
+
+	return divergences, nil
+}
+
+func (s *divergenceStore) Resolve(ctx context.Context, traceID string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if d := s.find(traceID); d != nil {
+		d.Resolved = true
+		d.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
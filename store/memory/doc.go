@@ -0,0 +1,9 @@
+// Package memory implements the stores in memory, they are safe for concurrent use and
+// keep the optimistic version checks of the db stores, so the payee can run against them
+// without a database, eg in the scenario tests.
+package memory
+
+import "errors"
+
+// errDuplicated violate the unique index
+var errDuplicated = errors.New("memory: duplicated entry")
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type candidateStore struct {
+	mux        sync.RWMutex
+	candidates map[string]*core.LiquidationCandidate
+	lastID     int64
+}
+
+// NewLiquidationCandidateStore new in-memory liquidation candidate store
+func NewLiquidationCandidateStore() core.LiquidationCandidateStore {
+	return &candidateStore{
+		candidates: make(map[string]*core.LiquidationCandidate),
+	}
+}
+
+func (s *candidateStore) Save(ctx context.Context, candidates []*core.LiquidationCandidate, scannedAt time.Time) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, c := range candidates {
+		if last, ok := s.candidates[c.UserID]; ok {
+			c.ID = last.ID
+			c.CreatedAt = last.CreatedAt
+		} else {
+			s.lastID++
+			c.ID = s.lastID
+			c.CreatedAt = time.Now()
+		}
+
+		c.UpdatedAt = time.Now()
+		candidate := *c
+		s.candidates[c.UserID] = &candidate
+	}
+
+	for userID, c := range s.candidates {
+		if c.ScannedAt.Before(scannedAt) {
+			delete(s.candidates, userID)
+		}
+	}
+
+	return nil
+}
+
+func (s *candidateStore) List(ctx context.Context) ([]*core.LiquidationCandidate, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	candidates := make([]*core.LiquidationCandidate, 0, len(s.candidates))
+	for _, c := range s.candidates {
+		candidate := *c
+		candidates = append(candidates, &candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Shortfall.GreaterThan(candidates[j].Shortfall)
+	})
+
+	return candidates, nil
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/fox-one/pkg/store/db"
+	"github.com/shopspring/decimal"
+)
+
+type marketStore struct {
+	mux     sync.RWMutex
+	lastID  uint64
+	markets map[string]*core.Market
+}
+
+// NewMarketStore new in-memory market store
+func NewMarketStore() core.IMarketStore {
+	return &marketStore{
+		markets: make(map[string]*core.Market),
+	}
+}
+
+func (s *marketStore) Create(ctx context.Context, market *core.Market) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if m, ok := s.markets[market.AssetID]; ok {
+		*market = *m
+		return nil
+	}
+
+	s.lastID++
+	market.ID = s.lastID
+	if market.CollateralMode == 0 {
+		market.CollateralMode = core.CollateralModeCross
+	}
+	if market.ValuationMode == 0 {
+		market.ValuationMode = core.ValuationModeSpot
+	}
+
+	m := *market
+	s.markets[market.AssetID] = &m
+	return nil
+}
+
+func (s *marketStore) Find(ctx context.Context, assetID string) (*core.Market, error) {
+	return s.find(func(m *core.Market) bool {
+		return assetID != "" && m.AssetID == assetID
+	}), nil
+}
+
+func (s *marketStore) FindBySymbol(ctx context.Context, symbol string) (*core.Market, error) {
+	return s.find(func(m *core.Market) bool {
+		return symbol != "" && m.Symbol == symbol
+	}), nil
+}
+
+func (s *marketStore) FindByCToken(ctx context.Context, ctokenAssetID string) (*core.Market, error) {
+	return s.find(func(m *core.Market) bool {
+		return ctokenAssetID != "" && m.CTokenAssetID == ctokenAssetID
+	}), nil
+}
+
+func (s *marketStore) find(match func(m *core.Market) bool) *core.Market {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, m := range s.markets {
+		if match(m) {
+			market := *m
+			return afterFindMarket(&market)
+		}
+	}
+
+	return &core.Market{}
+}
+
+func (s *marketStore) All(ctx context.Context) ([]*core.Market, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	markets := make([]*core.Market, 0, len(s.markets))
+	for _, m := range s.markets {
+		market := *m
+		markets = append(markets, afterFindMarket(&market))
+	}
+
+	sort.Slice(markets, func(i, j int) bool {
+		return markets[i].ID < markets[j].ID
+	})
+
+	return markets, nil
+}
+
+func (s *marketStore) AllAsMap(ctx context.Context) (map[string]*core.Market, error) {
+	markets, err := s.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	maps := make(map[string]*core.Market, len(markets))
+	for _, m := range markets {
+		maps[m.AssetID] = m
+	}
+
+	return maps, nil
+}
+
+func (s *marketStore) Update(ctx context.Context, market *core.Market, version int64) error {
+	if version <= market.Version {
+		return nil
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	m, ok := s.markets[market.AssetID]
+	if !ok || m.Version != market.Version {
+		return db.ErrOptimisticLock
+	}
+
+	market.Version = version
+	*m = *market
+	return nil
+}
+
+func afterFindMarket(market *core.Market) *core.Market {
+	if !market.ExchangeRate.IsPositive() {
+		market.ExchangeRate = decimal.New(1, 0)
+	}
+	if !market.BorrowIndex.IsPositive() {
+		market.BorrowIndex = decimal.New(1, 0)
+	}
+	return market
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+type oracleSignerStore struct {
+	mux     sync.RWMutex
+	lastID  int64
+	signers []*core.OracleSigner
+}
+
+// NewOracleSignerStore new in-memory oracle signer store
+func NewOracleSignerStore() core.OracleSignerStore {
+	return &oracleSignerStore{}
+}
+
+func (s *oracleSignerStore) Save(ctx context.Context, userID, publicKey string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	now := time.Now()
+	for _, signer := range s.signers {
+		if signer.UserID == userID {
+			signer.PublicKey = publicKey
+			signer.UpdatedAt = now
+			return nil
+		}
+	}
+
+	index := core.FreeSignerIndex(s.signers)
+	if index == 0 {
+		return errors.New("oracle signers full")
+	}
+
+	s.lastID++
+	s.signers = append(s.signers, &core.OracleSigner{
+		ID:          s.lastID,
+		UserID:      userID,
+		PublicKey:   publicKey,
+		SignerIndex: index,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+
+	return nil
+}
+
+func (s *oracleSignerStore) Delete(ctx context.Context, userID string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for idx, signer := range s.signers {
+		if signer.UserID == userID {
+			s.signers = append(s.signers[:idx:idx], s.signers[idx+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *oracleSignerStore) Reindex(ctx context.Context) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	sort.Slice(s.signers, func(i, j int) bool {
+		return s.signers[i].ID < s.signers[j].ID
+	})
+
+	for idx, signer := range s.signers {
+		signer.SignerIndex = int64(idx + 1)
+	}
+
+	return nil
+}
+
+func (s *oracleSignerStore) Find(ctx context.Context, userID string) (*core.OracleSigner, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, v := range s.signers {
+		if v.UserID == userID {
+			signer := *v
+			return &signer, nil
+		}
+	}
+
+	return &core.OracleSigner{}, nil
+}
+
+func (s *oracleSignerStore) FindAll(ctx context.Context) ([]*core.OracleSigner, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	signers := make([]*core.OracleSigner, 0, len(s.signers))
+	for _, v := range s.signers {
+		signer := *v
+		signers = append(signers, &signer)
+	}
+
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i].SignerIndex < signers[j].SignerIndex
+	})
+
+	return signers, nil
+}
+
+func (s *oracleSignerStore) Participate(ctx context.Context, version int64, indexes []int64, at time.Time) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	signed := make(map[int64]bool, len(indexes))
+	for _, idx := range indexes {
+		signed[idx] = true
+	}
+
+	for _, signer := range s.signers {
+		if signer.Version >= version {
+			continue
+		}
+
+		signer.Version = version
+		if signed[signer.SignerIndex] {
+			signer.Signed++
+			signer.LastSignedAt = at
+		} else {
+			signer.Missed++
+		}
+	}
+
+	return nil
+}
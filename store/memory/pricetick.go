@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sync"
+	"time"
+)
+
+type priceTickStore struct {
+	mux   sync.RWMutex
+	ticks []*core.PriceTick
+}
+
+// NewPriceTickStore new in-memory oracle price store
+func NewPriceTickStore() core.PriceTickStore {
+	return &priceTickStore{}
+}
+
+func (s *priceTickStore) Create(ctx context.Context, tick *core.PriceTick) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, t := range s.ticks {
+		if t.AssetID == tick.AssetID && t.Version == tick.Version {
+			*tick = *t
+			return nil
+		}
+	}
+
+	tick.ID = int64(len(s.ticks) + 1)
+	t := *tick
+	s.ticks = append(s.ticks, &t)
+	return nil
+}
+
+func (s *priceTickStore) ListAccepted(ctx context.Context, assetID string, before int64, limit int) ([]*core.PriceTick, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var ticks []*core.PriceTick
+	// the ticks are created in the order of the versions
+	for idx := len(s.ticks) - 1; idx >= 0; idx-- {
+		if limit > 0 && len(ticks) >= limit {
+			break
+		}
+
+		t := s.ticks[idx]
+		if t.AssetID == assetID && t.Status == core.PriceTickStatusAccepted && t.Version < before {
+			tick := *t
+			ticks = append(ticks, &tick)
+		}
+	}
+
+	return ticks, nil
+}
+
+func (s *priceTickStore) List(ctx context.Context, assetID string, status core.PriceTickStatus, from, to time.Time) ([]*core.PriceTick, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var ticks []*core.PriceTick
+	for _, t := range s.ticks {
+		if t.AssetID != assetID || t.CreatedAt.Before(from) || !t.CreatedAt.Before(to) {
+			continue
+		}
+
+		if status > 0 && t.Status != status {
+			continue
+		}
+
+		tick := *t
+		ticks = append(ticks, &tick)
+	}
+
+	return ticks, nil
+}
+
+func (s *priceTickStore) ListFrom(ctx context.Context, from int64, limit int) ([]*core.PriceTick, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var ticks []*core.PriceTick
+	// the ticks are created in the order of the versions
+	for _, t := range s.ticks {
+		if limit > 0 && len(ticks) >= limit {
+			break
+		}
+
+		if t.Version > from {
+			tick := *t
+			ticks = append(ticks, &tick)
+		}
+	}
+
+	return ticks, nil
+}
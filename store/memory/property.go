@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fox-one/pkg/property"
+)
+
+type propertyStore struct {
+	mux        sync.RWMutex
+	properties map[string]property.Value
+}
+
+// NewPropertyStore new in-memory property store
+func NewPropertyStore() property.Store {
+	return &propertyStore{
+		properties: make(map[string]property.Value),
+	}
+}
+
+func (s *propertyStore) Get(ctx context.Context, key string) (property.Value, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return s.properties[key], nil
+}
+
+func (s *propertyStore) Save(ctx context.Context, key string, value interface{}) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.properties[key] = property.Parse(value)
+	return nil
+}
+
+func (s *propertyStore) Expire(ctx context.Context, key string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.properties, key)
+	return nil
+}
+
+func (s *propertyStore) List(ctx context.Context) (map[string]property.Value, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	values := make(map[string]property.Value, len(s.properties))
+	for k, v := range s.properties {
+		values[k] = v
+	}
+
+	return values, nil
+}
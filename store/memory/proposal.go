@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+)
+
+type proposalStore struct {
+	mux       sync.RWMutex
+	proposals []*core.Proposal
+	traces    map[string]*core.Proposal
+}
+
+// NewProposalStore new in-memory proposal store
+func NewProposalStore() core.ProposalStore {
+	return &proposalStore{
+		traces: make(map[string]*core.Proposal),
+	}
+}
+
+func (s *proposalStore) Create(ctx context.Context, proposal *core.Proposal) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if p, ok := s.traces[proposal.TraceID]; ok {
+		*proposal = *p
+		return nil
+	}
+
+	proposal.ID = int64(len(s.proposals) + 1)
+	if proposal.Status == 0 {
+		proposal.Status = core.ProposalStatusPending
+	}
+	now := time.Now()
+	if proposal.CreatedAt.IsZero() {
+		proposal.CreatedAt = now
+	}
+	proposal.UpdatedAt = now
+
+	p := *proposal
+	s.proposals = append(s.proposals, &p)
+	s.traces[p.TraceID] = &p
+	return nil
+}
+
+func (s *proposalStore) Find(ctx context.Context, trace string) (*core.Proposal, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if p, ok := s.traces[trace]; ok {
+		proposal := *p
+		return &proposal, nil
+	}
+
+	return &core.Proposal{}, nil
+}
+
+// Update only the passed_at, votes, status & timelock times are updated, same as the db store
+func (s *proposalStore) Update(ctx context.Context, proposal *core.Proposal, version int64) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	p, ok := s.traces[proposal.TraceID]
+	if !ok || p.Version != proposal.Version {
+		return db.ErrOptimisticLock
+	}
+
+	p.PassedAt = proposal.PassedAt
+	p.Votes = append(p.Votes[:0:0], proposal.Votes...)
+	p.Status = proposal.Status
+	p.Rejections = append(p.Rejections[:0:0], proposal.Rejections...)
+	p.ExecutableAt = proposal.ExecutableAt
+	p.ExecutedAt = proposal.ExecutedAt
+	p.CanceledAt = proposal.CanceledAt
+	p.Version = version
+	p.UpdatedAt = time.Now()
+
+	proposal.Version = p.Version
+	proposal.UpdatedAt = p.UpdatedAt
+	return nil
+}
+
+func (s *proposalStore) List(ctx context.Context, fromID int64, limit int) ([]*core.Proposal, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var proposals []*core.Proposal
+	for _, p := range s.proposals {
+		if p.ID <= fromID {
+			continue
+		}
+
+		if limit > 0 && len(proposals) >= limit {
+			break
+		}
+
+		proposal := *p
+		proposals = append(proposals, &proposal)
+	}
+
+	return proposals, nil
+}
+
+func (s *proposalStore) ListQueued(ctx context.Context, at time.Time) ([]*core.Proposal, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var proposals []*core.Proposal
+	for _, p := range s.proposals {
+		if p.Queued() && !p.ExecutableAt.Time.After(at) {
+			proposal := *p
+			proposals = append(proposals, &proposal)
+		}
+	}
+
+	sort.SliceStable(proposals, func(i, j int) bool {
+		return proposals[i].ExecutableAt.Time.Before(proposals[j].ExecutableAt.Time)
+	})
+
+	return proposals, nil
+}
+
+func (s *proposalStore) ListPending(ctx context.Context, before time.Time) ([]*core.Proposal, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var proposals []*core.Proposal
+	for _, p := range s.proposals {
+		if p.Status == core.ProposalStatusPending && p.CreatedAt.Before(before) {
+			proposal := *p
+			proposals = append(proposals, &proposal)
+		}
+	}
+
+	return proposals, nil
+}
+
+func (s *proposalStore) ListByStatus(ctx context.Context, status core.ProposalStatus, fromID int64, limit int) ([]*core.Proposal, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var proposals []*core.Proposal
+	for _, p := range s.proposals {
+		if p.ID <= fromID || p.Status != status {
+			continue
+		}
+
+		if limit > 0 && len(proposals) >= limit {
+			break
+		}
+
+		proposal := *p
+		proposals = append(proposals, &proposal)
+	}
+
+	return proposals, nil
+}
+
+func (s *proposalStore) ListUpdated(ctx context.Context, from int64, limit int) ([]*core.Proposal, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var proposals []*core.Proposal
+	for _, p := range s.proposals {
+		if p.Version > from {
+			proposal := *p
+			proposals = append(proposals, &proposal)
+		}
+	}
+
+	sort.SliceStable(proposals, func(i, j int) bool {
+		return proposals[i].Version < proposals[j].Version
+	})
+
+	if limit > 0 && len(proposals) > limit {
+		proposals = proposals[:limit]
+	}
+
+	return proposals, nil
+}
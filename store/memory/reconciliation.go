@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sync"
+)
+
+type reconciliationStore struct {
+	mux     sync.RWMutex
+	reports []*core.Reconciliation
+}
+
+// NewReconciliationStore new in-memory reconciliation store
+func NewReconciliationStore() core.ReconciliationStore {
+	return &reconciliationStore{}
+}
+
+func (s *reconciliationStore) Create(ctx context.Context, reports []*core.Reconciliation) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, r := range reports {
+		r.ID = int64(len(s.reports) + 1)
+		report := *r
+		s.reports = append(s.reports, &report)
+	}
+
+	return nil
+}
+
+func (s *reconciliationStore) ListLatest(ctx context.Context) ([]*core.Reconciliation, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if len(s.reports) == 0 {
+		return nil, nil
+	}
+
+	last := s.reports[len(s.reports)-1]
+
+	var reports []*core.Reconciliation
+	for _, r := range s.reports {
+		if r.CreatedAt.Equal(last.CreatedAt) {
+			report := *r
+			reports = append(reports, &report)
+		}
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type reserveStore struct {
+	mux     sync.RWMutex
+	entries []*core.ReserveEntry
+}
+
+// NewReserveStore new in-memory reserve entry store
+func NewReserveStore() core.ReserveStore {
+	return &reserveStore{}
+}
+
+func (s *reserveStore) Create(ctx context.Context, entry *core.ReserveEntry) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, e := range s.entries {
+		if e.AssetID == entry.AssetID && e.Source == entry.Source && e.Version == entry.Version {
+			*entry = *e
+			return nil
+		}
+	}
+
+	entry.ID = int64(len(s.entries) + 1)
+	e := *entry
+	s.entries = append(s.entries, &e)
+	return nil
+}
+
+func (s *reserveStore) List(ctx context.Context, assetID string, from, to time.Time) ([]*core.ReserveEntry, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var entries []*core.ReserveEntry
+	for _, e := range s.entries {
+		if e.AssetID == assetID && !e.CreatedAt.Before(from) && e.CreatedAt.Before(to) {
+			entry := *e
+			entries = append(entries, &entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	return entries, nil
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+)
+
+type subscriberStore struct {
+	mux         sync.RWMutex
+	lastID      int64
+	subscribers map[string]*core.Subscriber
+}
+
+// NewSubscriberStore new in-memory subscriber store
+func NewSubscriberStore() core.SubscriberStore {
+	return &subscriberStore{
+		subscribers: map[string]*core.Subscriber{},
+	}
+}
+
+func (s *subscriberStore) Save(ctx context.Context, subscriber *core.Subscriber) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if old, ok := s.subscribers[subscriber.UserID]; ok {
+		old.HealthFactor = subscriber.HealthFactor
+		old.Version = subscriber.Version
+		old.UpdatedAt = time.Now()
+		return nil
+	}
+
+	s.lastID++
+	subscriber.ID = s.lastID
+	subscriber.CreatedAt = time.Now()
+	subscriber.UpdatedAt = subscriber.CreatedAt
+	sub := *subscriber
+	s.subscribers[subscriber.UserID] = &sub
+	return nil
+}
+
+func (s *subscriberStore) Find(ctx context.Context, userID string) (*core.Subscriber, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if sub, ok := s.subscribers[userID]; ok {
+		subscriber := *sub
+		return &subscriber, nil
+	}
+
+	return &core.Subscriber{}, nil
+}
+
+func (s *subscriberStore) Delete(ctx context.Context, userID string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.subscribers, userID)
+	return nil
+}
+
+func (s *subscriberStore) List(ctx context.Context, fromID int64, limit int) ([]*core.Subscriber, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var subscribers []*core.Subscriber
+	for _, sub := range s.subscribers {
+		if sub.ID > fromID {
+			subscriber := *sub
+			subscribers = append(subscribers, &subscriber)
+		}
+	}
+
+	sort.Slice(subscribers, func(i, j int) bool {
+		return subscribers[i].ID < subscribers[j].ID
+	})
+
+	if len(subscribers) > limit {
+		subscribers = subscribers[:limit]
+	}
+
+	return subscribers, nil
+}
+
+func (s *subscriberStore) Warned(ctx context.Context, userID string, at time.Time) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if sub, ok := s.subscribers[userID]; ok {
+		sub.WarnedAt = sql.NullTime{Time: at, Valid: true}
+	}
+
+	return nil
+}
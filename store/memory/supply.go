@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+	"github.com/shopspring/decimal"
+)
+
+type supplyStore struct {
+	mux      sync.RWMutex
+	lastID   uint64
+	supplies map[string]*core.Supply
+}
+
+// NewSupplyStore new in-memory supply store
+func NewSupplyStore() core.ISupplyStore {
+	return &supplyStore{
+		supplies: make(map[string]*core.Supply),
+	}
+}
+
+func supplyKey(userID, ctokenAssetID string) string {
+	return userID + ":" + ctokenAssetID
+}
+
+func (s *supplyStore) Create(ctx context.Context, supply *core.Supply) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	key := supplyKey(supply.UserID, supply.CTokenAssetID)
+	if _, ok := s.supplies[key]; ok {
+		return errDuplicated
+	}
+
+	s.lastID++
+	supply.ID = s.lastID
+	now := time.Now()
+	supply.CreatedAt, supply.UpdatedAt = now, now
+
+	v := *supply
+	s.supplies[key] = &v
+	return nil
+}
+
+func (s *supplyStore) Find(ctx context.Context, userID string, ctokenAssetID string) (*core.Supply, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if v, ok := s.supplies[supplyKey(userID, ctokenAssetID)]; ok {
+		supply := *v
+		return &supply, nil
+	}
+
+	return &core.Supply{}, nil
+}
+
+func (s *supplyStore) FindByUser(ctx context.Context, userID string) ([]*core.Supply, error) {
+	return s.filter(func(supply *core.Supply) bool {
+		return supply.UserID == userID
+	}), nil
+}
+
+func (s *supplyStore) FindByCTokenAssetID(ctx context.Context, assetID string) ([]*core.Supply, error) {
+	return s.filter(func(supply *core.Supply) bool {
+		return supply.CTokenAssetID == assetID
+	}), nil
+}
+
+func (s *supplyStore) All(ctx context.Context) ([]*core.Supply, error) {
+	return s.filter(func(supply *core.Supply) bool {
+		return true
+	}), nil
+}
+
+func (s *supplyStore) filter(match func(supply *core.Supply) bool) []*core.Supply {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var supplies []*core.Supply
+	for _, v := range s.supplies {
+		if match(v) {
+			supply := *v
+			supplies = append(supplies, &supply)
+		}
+	}
+
+	sort.Slice(supplies, func(i, j int) bool {
+		return supplies[i].ID < supplies[j].ID
+	})
+
+	return supplies
+}
+
+func (s *supplyStore) SumOfSupplies(ctx context.Context, ctokenAssetID string) (decimal.Decimal, error) {
+	sum := decimal.Zero
+	for _, supply := range s.filter(func(supply *core.Supply) bool {
+		return supply.CTokenAssetID == ctokenAssetID
+	}) {
+		sum = sum.Add(supply.Collaterals)
+	}
+
+	return sum, nil
+}
+
+func (s *supplyStore) CountOfSuppliers(ctx context.Context, ctokenAssetID string) (int64, error) {
+	supplies := s.filter(func(supply *core.Supply) bool {
+		return supply.CTokenAssetID == ctokenAssetID
+	})
+
+	return int64(len(supplies)), nil
+}
+
+func (s *supplyStore) Update(ctx context.Context, supply *core.Supply, version int64) error {
+	if version <= supply.Version {
+		return nil
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	v, ok := s.supplies[supplyKey(supply.UserID, supply.CTokenAssetID)]
+	if !ok || v.Version != supply.Version {
+		return db.ErrOptimisticLock
+	}
+
+	supply.Version = version
+	supply.UpdatedAt = time.Now()
+	*v = *supply
+	return nil
+}
+
+func (s *supplyStore) Users(ctx context.Context) ([]string, error) {
+	supplies, _ := s.All(ctx)
+
+	var (
+		users []string
+		seen  = make(map[string]bool, len(supplies))
+	)
+
+	for _, supply := range supplies {
+		if !seen[supply.UserID] {
+			seen[supply.UserID] = true
+			users = append(users, supply.UserID)
+		}
+	}
+
+	return users, nil
+}
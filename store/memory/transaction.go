@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type transactionStore struct {
+	mux          sync.RWMutex
+	transactions []*core.Transaction
+	traces       map[string]*core.Transaction
+}
+
+// NewTransactionStore new in-memory transaction store
+func NewTransactionStore() core.TransactionStore {
+	return &transactionStore{
+		traces: make(map[string]*core.Transaction),
+	}
+}
+
+func (s *transactionStore) Create(ctx context.Context, transaction *core.Transaction) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if tx, ok := s.traces[transaction.TraceID]; ok {
+		*transaction = *tx
+		return nil
+	}
+
+	transaction.ID = int64(len(s.transactions) + 1)
+	now := time.Now()
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = now
+	}
+	transaction.UpdatedAt = now
+
+	tx := *transaction
+	s.transactions = append(s.transactions, &tx)
+	s.traces[tx.TraceID] = &tx
+	return nil
+}
+
+func (s *transactionStore) FindByTraceID(ctx context.Context, traceID string) (*core.Transaction, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	if tx, ok := s.traces[traceID]; ok {
+		transaction := *tx
+		return &transaction, nil
+	}
+
+	return &core.Transaction{}, nil
+}
+
+func (s *transactionStore) Update(ctx context.Context, transaction *core.Transaction) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	tx, ok := s.traces[transaction.TraceID]
+	if !ok {
+		return nil
+	}
+
+	id, createdAt := tx.ID, tx.CreatedAt
+	*tx = *transaction
+	tx.ID, tx.CreatedAt, tx.UpdatedAt = id, createdAt, time.Now()
+	return nil
+}
+
+func (s *transactionStore) List(ctx context.Context, offset time.Time, limit int) ([]*core.Transaction, error) {
+	if limit <= 0 {
+		limit = 500
+	}
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var transactions []*core.Transaction
+	for _, tx := range s.transactions {
+		if !tx.CreatedAt.Before(offset) {
+			transaction := *tx
+			transactions = append(transactions, &transaction)
+		}
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.Before(transactions[j].CreatedAt)
+	})
+
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+
+	return transactions, nil
+}
+
+func (s *transactionStore) ListFrom(ctx context.Context, fromID int64, limit int) ([]*core.Transaction, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var transactions []*core.Transaction
+	for _, tx := range s.transactions {
+		if limit > 0 && len(transactions) >= limit {
+			break
+		}
+
+		if tx.ID > fromID {
+			transaction := *tx
+			transactions = append(transactions, &transaction)
+		}
+	}
+
+	return transactions, nil
+}
+
+func (s *transactionStore) LastID(ctx context.Context) (int64, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return int64(len(s.transactions)), nil
+}
+
+func (s *transactionStore) ListByUser(ctx context.Context, userID string, filter core.TransactionFilter) ([]*core.Transaction, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 500
+	}
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var transactions []*core.Transaction
+	for idx := len(s.transactions) - 1; idx >= 0; idx-- {
+		if len(transactions) >= filter.Limit {
+			break
+		}
+
+		tx := s.transactions[idx]
+		if tx.UserID != userID || (filter.Cursor > 0 && tx.ID >= filter.Cursor) || !filter.Match(tx) {
+			continue
+		}
+
+		transaction := *tx
+		transactions = append(transactions, &transaction)
+	}
+
+	return transactions, nil
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"sync"
+
+	"github.com/fox-one/pkg/store/db"
+)
+
+type userStore struct {
+	mux   sync.RWMutex
+	users []*core.User
+}
+
+// NewUserStore new in-memory user store
+func NewUserStore() core.UserStore {
+	return &userStore{}
+}
+
+func (s *userStore) List(ctx context.Context, from uint64, limit int) ([]*core.User, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var users []*core.User
+	for _, u := range s.users {
+		if u.ID <= from {
+			continue
+		}
+
+		if limit > 0 && len(users) >= limit {
+			break
+		}
+
+		user := *u
+		users = append(users, &user)
+	}
+
+	return users, nil
+}
+
+func (s *userStore) Create(ctx context.Context, user *core.User) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, u := range s.users {
+		if u.UserID == user.UserID {
+			*user = *u
+			return nil
+		}
+	}
+
+	user.ID = uint64(len(s.users) + 1)
+	u := *user
+	s.users = append(s.users, &u)
+	return nil
+}
+
+func (s *userStore) MigrateToV1(ctx context.Context, users []*core.User) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	// check all the users first, the db store migrates them in one transaction
+	targets := make([]*core.User, 0, len(users))
+	for _, user := range users {
+		var target *core.User
+		for _, u := range s.users {
+			if u.ID == user.ID && u.AddressV0 == "" {
+				target = u
+				break
+			}
+		}
+
+		if target == nil {
+			return db.ErrOptimisticLock
+		}
+
+		targets = append(targets, target)
+	}
+
+	for idx, target := range targets {
+		target.Address = users[idx].Address
+		target.AddressV0 = users[idx].AddressV0
+	}
+
+	return nil
+}
+
+func (s *userStore) Find(ctx context.Context, mixinUserID string) (*core.User, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, u := range s.users {
+		if u.UserID == mixinUserID {
+			user := *u
+			return &user, nil
+		}
+	}
+
+	return &core.User{}, nil
+}
+
+func (s *userStore) FindByAddress(ctx context.Context, address string) (*core.User, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, u := range s.users {
+		if u.Address == address {
+			user := *u
+			return &user, nil
+		}
+	}
+
+	// fail back to v0 address
+	for _, u := range s.users {
+		if u.AddressV0 != "" && u.AddressV0 == address {
+			user := *u
+			return &user, nil
+		}
+	}
+
+	return &core.User{}, nil
+}
@@ -0,0 +1,406 @@
+package memory
+
+import (
+	"compound/core"
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fox-one/mixin-sdk-go"
+	"github.com/jinzhu/gorm"
+	"github.com/shopspring/decimal"
+)
+
+type walletStore struct {
+	mux             sync.RWMutex
+	outputs         []*core.Output
+	transfers       []*core.Transfer
+	rawTransactions []*core.RawTransaction
+	lastRawID       int64
+}
+
+// NewWalletStore new in-memory wallet store,
+// the saved outputs are listed immediately in the order they are saved
+func NewWalletStore() core.WalletStore {
+	return &walletStore{}
+}
+
+func afterFindOutput(output *core.Output) *core.Output {
+	var utxo mixin.MultisigUTXO
+	if err := json.Unmarshal(output.Data, &utxo); err == nil {
+		output.UTXO = &utxo
+	}
+
+	return output
+}
+
+func (s *walletStore) findOutput(traceID string) *core.Output {
+	for _, output := range s.outputs {
+		if output.TraceID == traceID {
+			return output
+		}
+	}
+
+	return nil
+}
+
+func (s *walletStore) Save(ctx context.Context, outputs []*core.Output, end bool) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	now := time.Now()
+	for _, utxo := range outputs {
+		if output := s.findOutput(utxo.TraceID); output != nil {
+			output.Data = utxo.Data
+			output.State = utxo.State
+			output.Version++
+			output.UpdatedAt = now
+			continue
+		}
+
+		output := *utxo
+		output.ID = int64(len(s.outputs) + 1)
+		output.UTXO = nil
+		if output.CreatedAt.IsZero() {
+			output.CreatedAt = now
+		}
+		output.UpdatedAt = now
+		s.outputs = append(s.outputs, &output)
+	}
+
+	return nil
+}
+
+func (s *walletStore) listOutputs(match func(output *core.Output) bool, limit int) []*core.Output {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var outputs []*core.Output
+	for _, o := range s.outputs {
+		if limit > 0 && len(outputs) >= limit {
+			break
+		}
+
+		if match(o) {
+			output := *o
+			outputs = append(outputs, afterFindOutput(&output))
+		}
+	}
+
+	return outputs
+}
+
+func (s *walletStore) List(_ context.Context, fromID int64, limit int) ([]*core.Output, error) {
+	return s.listOutputs(func(output *core.Output) bool {
+		return output.ID > fromID
+	}, limit), nil
+}
+
+func (s *walletStore) ListUnspent(_ context.Context, assetID string, limit int) ([]*core.Output, error) {
+	return s.listOutputs(func(output *core.Output) bool {
+		return output.AssetID == assetID && output.SpentBy == ""
+	}, limit), nil
+}
+
+func (s *walletStore) FindSpentBy(ctx context.Context, assetID, spentBy string) (*core.Output, error) {
+	outputs := s.listOutputs(func(output *core.Output) bool {
+		return output.AssetID == assetID && output.SpentBy == spentBy
+	}, 1)
+
+	if len(outputs) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return outputs[0], nil
+}
+
+func (s *walletStore) ListSpentBy(ctx context.Context, assetID string, spentBy string) ([]*core.Output, error) {
+	return s.listOutputs(func(output *core.Output) bool {
+		return output.AssetID == assetID && output.SpentBy == spentBy
+	}, 0), nil
+}
+
+func (s *walletStore) findTransfer(traceID string) *core.Transfer {
+	for _, transfer := range s.transfers {
+		if transfer.TraceID == traceID {
+			return transfer
+		}
+	}
+
+	return nil
+}
+
+func (s *walletStore) createTransfer(transfer *core.Transfer) {
+	if t := s.findTransfer(transfer.TraceID); t != nil {
+		*transfer = *t
+		return
+	}
+
+	transfer.ID = int64(len(s.transfers) + 1)
+	now := time.Now()
+	transfer.CreatedAt, transfer.UpdatedAt = now, now
+
+	t := *transfer
+	s.transfers = append(s.transfers, &t)
+}
+
+func (s *walletStore) updateTransfer(transfer *core.Transfer) {
+	for _, t := range s.transfers {
+		if t.ID == transfer.ID {
+			t.Amount = transfer.Amount
+			t.Assigned = transfer.Assigned
+			t.Handled = transfer.Handled
+			t.Passed = transfer.Passed
+			t.UpdatedAt = time.Now()
+			return
+		}
+	}
+}
+
+func (s *walletStore) CreateTransfers(_ context.Context, transfers []*core.Transfer) error {
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].TraceID < transfers[j].TraceID
+	})
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, transfer := range transfers {
+		s.createTransfer(transfer)
+	}
+
+	return nil
+}
+
+func (s *walletStore) UpdateTransfer(ctx context.Context, transfer *core.Transfer) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.updateTransfer(transfer)
+	return nil
+}
+
+func (s *walletStore) ListTransfers(ctx context.Context, status core.TransferStatus, limit int) ([]*core.Transfer, error) {
+	match := func(t *core.Transfer) bool {
+		switch status {
+		case core.TransferStatusPending:
+			return !bool(t.Handled) && !bool(t.Assigned)
+		case core.TransferStatusAssigned:
+			return !bool(t.Handled) && bool(t.Assigned)
+		case core.TransferStatusHandled:
+			return bool(t.Handled) && !bool(t.Passed)
+		default:
+			return bool(t.Handled) && bool(t.Passed)
+		}
+	}
+
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var transfers []*core.Transfer
+	for _, t := range s.transfers {
+		if limit > 0 && len(transfers) >= limit {
+			break
+		}
+
+		if match(t) {
+			transfer := *t
+			if transfer.Threshold == 0 {
+				transfer.Threshold = uint8(len(transfer.Opponents))
+			}
+			transfers = append(transfers, &transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+func (s *walletStore) FindTransfer(ctx context.Context, traceID string) (*core.Transfer, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	t := s.findTransfer(traceID)
+	if t == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	transfer := *t
+	if transfer.Threshold == 0 {
+		transfer.Threshold = uint8(len(transfer.Opponents))
+	}
+
+	return &transfer, nil
+}
+
+func (s *walletStore) Assign(_ context.Context, outputs []*core.Output, transfer *core.Transfer) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	ids := make(map[int64]bool, len(outputs))
+	for _, output := range outputs {
+		ids[output.ID] = true
+	}
+
+	for _, output := range s.outputs {
+		if ids[output.ID] {
+			output.SpentBy = transfer.TraceID
+		}
+	}
+
+	transfer.Assigned = true
+	if transfer.ID > 0 {
+		s.updateTransfer(transfer)
+	} else {
+		s.createTransfer(transfer)
+	}
+
+	return nil
+}
+
+func (s *walletStore) CreateRawTransaction(_ context.Context, tx *core.RawTransaction) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, raw := range s.rawTransactions {
+		if raw.TraceID == tx.TraceID {
+			*tx = *raw
+			return nil
+		}
+	}
+
+	s.lastRawID++
+	tx.ID = s.lastRawID
+	if tx.CreatedAt.IsZero() {
+		tx.CreatedAt = time.Now()
+	}
+
+	raw := *tx
+	s.rawTransactions = append(s.rawTransactions, &raw)
+	return nil
+}
+
+func (s *walletStore) ListPendingRawTransactions(_ context.Context, limit int) ([]*core.RawTransaction, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var txs []*core.RawTransaction
+	for _, raw := range s.rawTransactions {
+		if limit > 0 && len(txs) >= limit {
+			break
+		}
+
+		tx := *raw
+		txs = append(txs, &tx)
+	}
+
+	return txs, nil
+}
+
+func (s *walletStore) FindRawTransaction(_ context.Context, traceID string) (*core.RawTransaction, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, raw := range s.rawTransactions {
+		if raw.TraceID == traceID {
+			tx := *raw
+			return &tx, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *walletStore) ExpireRawTransaction(_ context.Context, tx *core.RawTransaction) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for idx, raw := range s.rawTransactions {
+		if raw.ID == tx.ID {
+			s.rawTransactions = append(s.rawTransactions[:idx:idx], s.rawTransactions[idx+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *walletStore) CountOutputs(ctx context.Context) (int64, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return int64(len(s.outputs)), nil
+}
+
+func (s *walletStore) CountUnhandledTransfers(ctx context.Context) (int64, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var count int64
+	for _, t := range s.transfers {
+		if !t.Handled {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// sumAmounts sum the amounts by asset in the order the assets show up
+type sumAmounts struct {
+	list    []*core.AssetAmount
+	byAsset map[string]*core.AssetAmount
+}
+
+func (s *sumAmounts) add(assetID string, amount decimal.Decimal) {
+	if s.byAsset == nil {
+		s.byAsset = map[string]*core.AssetAmount{}
+	}
+
+	sum, ok := s.byAsset[assetID]
+	if !ok {
+		sum = &core.AssetAmount{AssetID: assetID}
+		s.byAsset[assetID] = sum
+		s.list = append(s.list, sum)
+	}
+
+	sum.Count++
+	sum.Amount = sum.Amount.Add(amount)
+}
+
+func (s *walletStore) SumUnspent(ctx context.Context) ([]*core.AssetAmount, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var sum sumAmounts
+	for _, output := range s.outputs {
+		if output.SpentBy == "" {
+			sum.add(output.AssetID, output.Amount)
+		}
+	}
+
+	return sum.list, nil
+}
+
+func (s *walletStore) SumUnpassed(ctx context.Context) ([]*core.AssetAmount, []*core.AssetAmount, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	var transfers, outputs sumAmounts
+	unpassed := map[string]bool{}
+	for _, t := range s.transfers {
+		if !t.Passed {
+			transfers.add(t.AssetID, t.Amount)
+			unpassed[t.TraceID] = bool(t.Assigned)
+		}
+	}
+
+	for _, output := range s.outputs {
+		if output.SpentBy != "" && unpassed[output.SpentBy] {
+			outputs.add(output.AssetID, output.Amount)
+		}
+	}
+
+	return transfers.list, outputs.list, nil
+}
@@ -0,0 +1,66 @@
+package oracle
+
+import (
+	"compound/core"
+	"context"
+
+	"github.com/fox-one/pkg/store/db"
+)
+
+type priceAccumulatorStore struct {
+	db *db.DB
+}
+
+func init() {
+	db.RegisterMigrate(func(db *db.DB) error {
+		tx := db.Update().Model(core.PriceAccumulator{})
+		if err := tx.AutoMigrate(core.PriceAccumulator{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// NewPriceAccumulatorStore new price accumulator store
+func NewPriceAccumulatorStore(db *db.DB) core.PriceAccumulatorStore {
+	return &priceAccumulatorStore{db: db}
+}
+
+func (s *priceAccumulatorStore) Save(ctx context.Context, accumulator *core.PriceAccumulator) error {
+	return s.db.Tx(func(tx *db.DB) error {
+		var last core.PriceAccumulator
+		if err := tx.Update().Where("asset_id = ? AND block = ?", accumulator.AssetID, accumulator.Block).First(&last).Error; err != nil {
+			if db.IsErrorNotFound(err) {
+				return tx.Update().Create(accumulator).Error
+			}
+
+			return err
+		}
+
+		if accumulator.Version <= last.Version {
+			return nil
+		}
+
+		return tx.Update().Model(&last).Updates(map[string]interface{}{
+			"version": accumulator.Version,
+			"price":   accumulator.Price,
+		}).Error
+	})
+}
+
+func (s *priceAccumulatorStore) Find(ctx context.Context, assetID string, block int64) (*core.PriceAccumulator, error) {
+	var accumulator core.PriceAccumulator
+	if err := s.db.View().
+		Where("asset_id = ? AND block <= ?", assetID, block).
+		Order("block DESC").
+		First(&accumulator).Error; err != nil {
+		if db.IsErrorNotFound(err) {
+			return &core.PriceAccumulator{}, nil
+		}
+
+		return nil, err
+	}
+
+	return &accumulator, nil
+}
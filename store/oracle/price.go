@@ -0,0 +1,69 @@
+package oracle
+
+import (
+	"compound/core"
+	"context"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+)
+
+type priceTickStore struct {
+	db *db.DB
+}
+
+func init() {
+	db.RegisterMigrate(func(db *db.DB) error {
+		tx := db.Update().Model(core.PriceTick{})
+		if err := tx.AutoMigrate(core.PriceTick{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// NewPriceTickStore new oracle price store
+func NewPriceTickStore(db *db.DB) core.PriceTickStore {
+	return &priceTickStore{db: db}
+}
+
+func (s *priceTickStore) Create(ctx context.Context, tick *core.PriceTick) error {
+	return s.db.Update().Where("asset_id = ? AND version = ?", tick.AssetID, tick.Version).FirstOrCreate(tick).Error
+}
+
+func (s *priceTickStore) ListAccepted(ctx context.Context, assetID string, before int64, limit int) ([]*core.PriceTick, error) {
+	var ticks []*core.PriceTick
+	if err := s.db.View().
+		Where("asset_id = ? AND status = ? AND version < ?", assetID, core.PriceTickStatusAccepted, before).
+		Order("version DESC").
+		Limit(limit).
+		Find(&ticks).Error; err != nil {
+		return nil, err
+	}
+
+	return ticks, nil
+}
+
+func (s *priceTickStore) List(ctx context.Context, assetID string, status core.PriceTickStatus, from, to time.Time) ([]*core.PriceTick, error) {
+	query := s.db.View().Where("asset_id = ? AND created_at >= ? AND created_at < ?", assetID, from, to)
+	if status > 0 {
+		query = query.Where("status = ?", status)
+	}
+
+	var ticks []*core.PriceTick
+	if err := query.Order("version ASC").Find(&ticks).Error; err != nil {
+		return nil, err
+	}
+
+	return ticks, nil
+}
+
+func (s *priceTickStore) ListFrom(ctx context.Context, from int64, limit int) ([]*core.PriceTick, error) {
+	var ticks []*core.PriceTick
+	if err := s.db.View().Where("version > ?", from).Order("version ASC, id ASC").Limit(limit).Find(&ticks).Error; err != nil {
+		return nil, err
+	}
+
+	return ticks, nil
+}
@@ -3,8 +3,11 @@ package oracle
 import (
 	"compound/core"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/fox-one/pkg/store/db"
+	"github.com/jinzhu/gorm"
 )
 
 type oracleSignerStore struct {
@@ -17,32 +20,132 @@ func init() {
 		if err := tx.AutoMigrate(core.OracleSigner{}).Error; err != nil {
 			return err
 		}
-		return nil
+
+		return migrateSignerIndexes(db)
 	})
 }
 
+// migrateSignerIndexes assign the indexes to the signers created before the indexes are persisted,
+// they were the positions of the signers ordered by id
+func migrateSignerIndexes(db *db.DB) error {
+	var signers []*core.OracleSigner
+	if err := db.View().Order("id").Find(&signers).Error; err != nil {
+		return err
+	}
+
+	for _, signer := range signers {
+		if signer.SignerIndex > 0 {
+			return nil
+		}
+	}
+
+	return reindexSigners(db, signers)
+}
+
+// reindexSigners assign the indexes by the positions of the signers ordered by id
+func reindexSigners(db *db.DB, signers []*core.OracleSigner) error {
+	for idx, signer := range signers {
+		if signer.SignerIndex == int64(idx+1) {
+			continue
+		}
+
+		if err := db.Update().Model(signer).Update("signer_index", idx+1).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func NewSignerStore(db *db.DB) core.OracleSignerStore {
 	return &oracleSignerStore{db: db}
 }
 
 func (s *oracleSignerStore) Save(ctx context.Context, userID, publicKey string) error {
-	signer := core.OracleSigner{
-		UserID:    userID,
-		PublicKey: publicKey,
-	}
+	return s.db.Tx(func(tx *db.DB) error {
+		var signer core.OracleSigner
+		if err := tx.Update().Where("user_id = ?", userID).First(&signer).Error; err == nil {
+			return tx.Update().Model(&signer).Update("public_key", publicKey).Error
+		} else if !db.IsErrorNotFound(err) {
+			return err
+		}
+
+		var signers []*core.OracleSigner
+		if err := tx.Update().Find(&signers).Error; err != nil {
+			return err
+		}
 
-	return s.db.Update().Where("user_id = ?", userID).Assign(core.OracleSigner{UserID: userID, PublicKey: publicKey}).FirstOrCreate(&signer).Error
+		index := core.FreeSignerIndex(signers)
+		if index == 0 {
+			return errors.New("oracle signers full")
+		}
+
+		signer = core.OracleSigner{
+			UserID:      userID,
+			PublicKey:   publicKey,
+			SignerIndex: index,
+		}
+
+		return tx.Update().Create(&signer).Error
+	})
 }
 
 func (s *oracleSignerStore) Delete(ctx context.Context, userID string) error {
 	return s.db.Update().Where("user_id = ?", userID).Delete(core.OracleSigner{}).Error
 }
 
+func (s *oracleSignerStore) Reindex(ctx context.Context) error {
+	return s.db.Tx(func(tx *db.DB) error {
+		var signers []*core.OracleSigner
+		if err := tx.Update().Order("id").Find(&signers).Error; err != nil {
+			return err
+		}
+
+		return reindexSigners(tx, signers)
+	})
+}
+
+func (s *oracleSignerStore) Find(ctx context.Context, userID string) (*core.OracleSigner, error) {
+	var signer core.OracleSigner
+	if err := s.db.View().Where("user_id = ?", userID).First(&signer).Error; err != nil {
+		if db.IsErrorNotFound(err) {
+			return &signer, nil
+		}
+
+		return nil, err
+	}
+
+	return &signer, nil
+}
+
 func (s *oracleSignerStore) FindAll(ctx context.Context) ([]*core.OracleSigner, error) {
 	var signers []*core.OracleSigner
-	if err := s.db.View().Find(&signers).Error; err != nil {
+	if err := s.db.View().Order("signer_index").Find(&signers).Error; err != nil {
 		return nil, err
 	}
 
 	return signers, nil
 }
+
+func (s *oracleSignerStore) Participate(ctx context.Context, version int64, indexes []int64, at time.Time) error {
+	return s.db.Tx(func(tx *db.DB) error {
+		if len(indexes) > 0 {
+			if err := tx.Update().Model(core.OracleSigner{}).
+				Where("version < ? AND signer_index IN (?)", version, indexes).
+				Updates(map[string]interface{}{
+					"signed":         gorm.Expr("signed + 1"),
+					"last_signed_at": at,
+					"version":        version,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Update().Model(core.OracleSigner{}).
+			Where("version < ?", version).
+			Updates(map[string]interface{}{
+				"missed":  gorm.Expr("missed + 1"),
+				"version": version,
+			}).Error
+	})
+}
@@ -2,6 +2,7 @@ package proposal
 
 import (
 	"context"
+	"time"
 
 	"compound/core"
 
@@ -20,10 +21,25 @@ func init() {
 			return err
 		}
 
-		return nil
+		return migrateStatus(db)
 	})
 }
 
+// migrateStatus the status of the proposals created before the status column, they were pending or passed
+func migrateStatus(db *db.DB) error {
+	tx := db.Update().Model(core.Proposal{}).Where("status = ? AND passed_at IS NOT NULL", core.ProposalStatusPending)
+
+	if err := tx.Where("canceled_at IS NOT NULL").Update("status", core.ProposalStatusRejected).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("executed_at IS NULL AND executable_at IS NOT NULL").Update("status", core.ProposalStatusPassed).Error; err != nil {
+		return err
+	}
+
+	return tx.Update("status", core.ProposalStatusExecuted).Error
+}
+
 // New new proposal store
 func New(db *db.DB) core.ProposalStore {
 	return &proposalStore{db: db}
@@ -51,8 +67,13 @@ func (s *proposalStore) Find(ctx context.Context, trace string) (*core.Proposal,
 
 func toUpdateParams(proposal *core.Proposal) map[string]interface{} {
 	return map[string]interface{}{
-		"passed_at": proposal.PassedAt,
-		"votes":     proposal.Votes,
+		"passed_at":     proposal.PassedAt,
+		"votes":         proposal.Votes,
+		"status":        proposal.Status,
+		"rejections":    proposal.Rejections,
+		"executable_at": proposal.ExecutableAt,
+		"executed_at":   proposal.ExecutedAt,
+		"canceled_at":   proposal.CanceledAt,
 	}
 }
 
@@ -80,3 +101,54 @@ func (s *proposalStore) List(ctx context.Context, fromID int64, limit int) ([]*c
 
 	return proposals, nil
 }
+
+func (s *proposalStore) ListQueued(ctx context.Context, at time.Time) ([]*core.Proposal, error) {
+	var proposals []*core.Proposal
+	if err := s.db.View().
+		Where("executable_at IS NOT NULL AND executable_at <= ?", at).
+		Where("executed_at IS NULL AND canceled_at IS NULL").
+		Order("executable_at, id").
+		Find(&proposals).Error; err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
+func (s *proposalStore) ListPending(ctx context.Context, before time.Time) ([]*core.Proposal, error) {
+	var proposals []*core.Proposal
+	if err := s.db.View().
+		Where("status = ? AND created_at < ?", core.ProposalStatusPending, before).
+		Order("id").
+		Find(&proposals).Error; err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
+func (s *proposalStore) ListByStatus(ctx context.Context, status core.ProposalStatus, fromID int64, limit int) ([]*core.Proposal, error) {
+	var proposals []*core.Proposal
+	if err := s.db.View().
+		Where("status = ? AND id > ?", status, fromID).
+		Order("id").
+		Limit(limit).
+		Find(&proposals).Error; err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
+func (s *proposalStore) ListUpdated(ctx context.Context, from int64, limit int) ([]*core.Proposal, error) {
+	var proposals []*core.Proposal
+	if err := s.db.View().
+		Where("version > ?", from).
+		Order("version, id").
+		Limit(limit).
+		Find(&proposals).Error; err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
@@ -0,0 +1,62 @@
+package reconciliation
+
+import (
+	"compound/core"
+	"context"
+
+	"github.com/fox-one/pkg/store/db"
+	"github.com/jinzhu/gorm"
+)
+
+type reconciliationStore struct {
+	db *db.DB
+}
+
+// New new reconciliation store
+func New(db *db.DB) core.ReconciliationStore {
+	return &reconciliationStore{
+		db: db,
+	}
+}
+
+func init() {
+	db.RegisterMigrate(func(db *db.DB) error {
+		tx := db.Update().Model(core.Reconciliation{})
+
+		if err := tx.AutoMigrate(core.Reconciliation{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *reconciliationStore) Create(ctx context.Context, reports []*core.Reconciliation) error {
+	return s.db.Tx(func(tx *db.DB) error {
+		for _, report := range reports {
+			if err := tx.Update().Create(report).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *reconciliationStore) ListLatest(ctx context.Context) ([]*core.Reconciliation, error) {
+	var last core.Reconciliation
+	if err := s.db.View().Order("id DESC").First(&last).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var reports []*core.Reconciliation
+	if err := s.db.View().Where("created_at = ?", last.CreatedAt).Order("id").Find(&reports).Error; err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,53 @@
+package reserve
+
+import (
+	"compound/core"
+	"context"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+)
+
+type reserveStore struct {
+	db *db.DB
+}
+
+// New new reserve entry store
+func New(db *db.DB) core.ReserveStore {
+	return &reserveStore{
+		db: db,
+	}
+}
+
+func init() {
+	db.RegisterMigrate(func(db *db.DB) error {
+		tx := db.Update().Model(core.ReserveEntry{})
+
+		if err := tx.AutoMigrate(core.ReserveEntry{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *reserveStore) Create(ctx context.Context, entry *core.ReserveEntry) error {
+	return s.db.Update().Where(
+		"asset_id = ? AND source = ? AND version = ?",
+		entry.AssetID,
+		entry.Source,
+		entry.Version,
+	).FirstOrCreate(entry).Error
+}
+
+func (s *reserveStore) List(ctx context.Context, assetID string, from, to time.Time) ([]*core.ReserveEntry, error) {
+	var entries []*core.ReserveEntry
+	if err := s.db.View().
+		Where("asset_id = ? AND created_at >= ? AND created_at < ?", assetID, from, to).
+		Order("created_at ASC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
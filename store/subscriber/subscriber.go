@@ -0,0 +1,87 @@
+package subscriber
+
+import (
+	"compound/core"
+	"context"
+	"time"
+
+	"github.com/fox-one/pkg/store/db"
+	"github.com/jinzhu/gorm"
+)
+
+type subscriberStore struct {
+	db *db.DB
+}
+
+// New new subscriber store
+func New(db *db.DB) core.SubscriberStore {
+	return &subscriberStore{
+		db: db,
+	}
+}
+
+func init() {
+	db.RegisterMigrate(func(db *db.DB) error {
+		tx := db.Update().Model(core.Subscriber{})
+
+		if err := tx.AutoMigrate(core.Subscriber{}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *subscriberStore) Save(ctx context.Context, subscriber *core.Subscriber) error {
+	return s.db.Tx(func(tx *db.DB) error {
+		var old core.Subscriber
+		if err := tx.Update().Where("user_id = ?", subscriber.UserID).First(&old).Error; err != nil {
+			if gorm.IsRecordNotFoundError(err) {
+				return tx.Update().Create(subscriber).Error
+			}
+
+			return err
+		}
+
+		return tx.Update().Model(old).Updates(map[string]interface{}{
+			"health_factor": subscriber.HealthFactor,
+			"version":       subscriber.Version,
+		}).Error
+	})
+}
+
+func (s *subscriberStore) Find(ctx context.Context, userID string) (*core.Subscriber, error) {
+	var subscriber core.Subscriber
+	if err := s.db.View().Where("user_id = ?", userID).First(&subscriber).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return &core.Subscriber{}, nil
+		}
+
+		return nil, err
+	}
+
+	return &subscriber, nil
+}
+
+func (s *subscriberStore) Delete(ctx context.Context, userID string) error {
+	return s.db.Update().Where("user_id = ?", userID).Delete(core.Subscriber{}).Error
+}
+
+func (s *subscriberStore) List(ctx context.Context, fromID int64, limit int) ([]*core.Subscriber, error) {
+	var subscribers []*core.Subscriber
+	if err := s.db.View().
+		Where("id > ?", fromID).
+		Order("id").
+		Limit(limit).
+		Find(&subscribers).Error; err != nil {
+		return nil, err
+	}
+
+	return subscribers, nil
+}
+
+func (s *subscriberStore) Warned(ctx context.Context, userID string, at time.Time) error {
+	return s.db.Update().Model(core.Subscriber{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+		"warned_at": at,
+	}).Error
+}
@@ -64,3 +64,64 @@ func (s *transactionStore) List(ctx context.Context, offset time.Time, limit int
 
 	return transactions, nil
 }
+
+func (s *transactionStore) ListFrom(ctx context.Context, fromID int64, limit int) ([]*core.Transaction, error) {
+	var transactions []*core.Transaction
+	if err := s.db.View().Where("id > ?", fromID).Order("id ASC").Limit(limit).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (s *transactionStore) LastID(ctx context.Context) (int64, error) {
+	var transaction core.Transaction
+	if err := s.db.View().Select("id").Order("id DESC").First(&transaction).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return transaction.ID, nil
+}
+
+func (s *transactionStore) ListByUser(ctx context.Context, userID string, filter core.TransactionFilter) ([]*core.Transaction, error) {
+	tx := s.db.View().Where("user_id = ?", userID)
+
+	if len(filter.Actions) > 0 {
+		tx = tx.Where("action IN (?)", filter.Actions)
+	}
+
+	if filter.AssetID != "" {
+		tx = tx.Where("asset_id = ?", filter.AssetID)
+	}
+
+	if filter.FollowID != "" {
+		tx = tx.Where("follow_id = ?", filter.FollowID)
+	}
+
+	if !filter.From.IsZero() {
+		tx = tx.Where("created_at >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		tx = tx.Where("created_at < ?", filter.To)
+	}
+
+	if filter.Cursor > 0 {
+		tx = tx.Where("id < ?", filter.Cursor)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 500
+	}
+
+	var transactions []*core.Transaction
+	if err := tx.Order("id DESC").Limit(limit).Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
@@ -221,6 +221,8 @@ func (s *walletStore) CreateTransfers(_ context.Context, transfers []*core.Trans
 
 func updateTransfer(db *db.DB, transfer *core.Transfer) error {
 	return db.Update().Model(transfer).Updates(map[string]interface{}{
+		// filled by the assigner for the consolidations
+		"amount":   transfer.Amount,
 		"assigned": transfer.Assigned,
 		"handled":  transfer.Handled,
 		"passed":   transfer.Passed,
@@ -257,6 +259,16 @@ func (s *walletStore) ListTransfers(ctx context.Context, status core.TransferSta
 	return transfers, nil
 }
 
+func (s *walletStore) FindTransfer(ctx context.Context, traceID string) (*core.Transfer, error) {
+	var transfer core.Transfer
+	if err := s.db.View().Where("trace_id = ?", traceID).Take(&transfer).Error; err != nil {
+		return nil, err
+	}
+
+	afterFindTransfer(&transfer)
+	return &transfer, nil
+}
+
 func (s *walletStore) Assign(_ context.Context, outputs []*core.Output, transfer *core.Transfer) error {
 	ids := make([]int64, 0, len(outputs))
 	for _, output := range outputs {
@@ -297,6 +309,15 @@ func (s *walletStore) ListPendingRawTransactions(_ context.Context, limit int) (
 	return txs, nil
 }
 
+func (s *walletStore) FindRawTransaction(_ context.Context, traceID string) (*core.RawTransaction, error) {
+	var tx core.RawTransaction
+	if err := s.db.View().Where("trace_id = ?", traceID).Take(&tx).Error; err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
 func (s *walletStore) ExpireRawTransaction(_ context.Context, tx *core.RawTransaction) error {
 	return s.db.Update().Model(tx).Where("id = ?", tx.ID).Delete(tx).Error
 }
@@ -318,3 +339,40 @@ func (s *walletStore) CountUnhandledTransfers(ctx context.Context) (int64, error
 
 	return count, nil
 }
+
+func (s *walletStore) SumUnspent(ctx context.Context) ([]*core.AssetAmount, error) {
+	var amounts []*core.AssetAmount
+	if err := s.db.View().Model(core.Output{}).
+		Select("asset_id, COUNT(*) AS count, SUM(amount) AS amount").
+		Where("spent_by = ?", "").
+		Group("asset_id").
+		Scan(&amounts).Error; err != nil {
+		return nil, err
+	}
+
+	return amounts, nil
+}
+
+func (s *walletStore) SumUnpassed(ctx context.Context) ([]*core.AssetAmount, []*core.AssetAmount, error) {
+	var transfers []*core.AssetAmount
+	if err := s.db.View().Model(core.Transfer{}).
+		Select("asset_id, COUNT(*) AS count, SUM(amount) AS amount").
+		Where("passed = ?", 0).
+		Group("asset_id").
+		Scan(&transfers).Error; err != nil {
+		return nil, nil, err
+	}
+
+	unpassed := s.db.View().Model(core.Transfer{}).Select("trace_id").Where("assigned = ? AND passed = ?", 1, 0)
+
+	var outputs []*core.AssetAmount
+	if err := s.db.View().Model(core.Output{}).
+		Select("asset_id, COUNT(*) AS count, SUM(amount) AS amount").
+		Where("spent_by IN (?)", unpassed.SubQuery()).
+		Group("asset_id").
+		Scan(&outputs).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return transfers, outputs, nil
+}
@@ -2,6 +2,7 @@ package assigner
 
 import (
 	"compound/core"
+	"compound/metric"
 	"context"
 	"errors"
 	"fmt"
@@ -43,7 +44,7 @@ func (w *Assigner) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(dur):
-			if err := w.run(ctx); err == nil {
+			if err := metric.ObserveWorker("assigner", func() error { return w.run(ctx) }); err == nil {
 				dur = 300 * time.Millisecond
 			} else {
 				dur = 500 * time.Millisecond
@@ -81,6 +82,10 @@ func (w *Assigner) run(ctx context.Context) error {
 func (w *Assigner) handleTransfer(ctx context.Context, transfer *core.Transfer) error {
 	log := logger.FromContext(ctx).WithField("transfer", transfer.TraceID)
 
+	if threshold, batch, ok := transfer.Consolidation(); ok {
+		return w.consolidate(ctx, transfer, threshold, batch)
+	}
+
 	const limit = 32
 	outputs, err := w.wallets.ListUnspent(ctx, transfer.AssetID, limit)
 	if err != nil {
@@ -142,6 +147,57 @@ func (w *Assigner) handleTransfer(ctx context.Context, transfer *core.Transfer)
 	return w.commit(ctx, outputs, transfer)
 }
 
+// consolidate merge the oldest batch unspent outputs not newer than the consolidation recorded by the payee
+// if they exceed the threshold, the consolidation is closed without spending otherwise
+func (w *Assigner) consolidate(ctx context.Context, transfer *core.Transfer, threshold, batch int64) error {
+	log := logger.FromContext(ctx).WithField("transfer", transfer.TraceID)
+
+	limit := threshold + 1
+	if batch > limit {
+		limit = batch
+	}
+
+	outputs, err := w.wallets.ListUnspent(ctx, transfer.AssetID, int(limit))
+	if err != nil {
+		log.WithError(err).Errorln("wallets.ListUnspent")
+		return err
+	}
+
+	var idx int64
+	for _, output := range outputs {
+		if output.ID > transfer.Version {
+			break
+		}
+
+		idx += 1
+	}
+
+	if idx <= threshold {
+		idx = 0
+	} else if idx > batch {
+		idx = batch
+	}
+
+	var sum decimal.Decimal
+	for _, output := range outputs[:idx] {
+		sum = sum.Add(output.Amount)
+	}
+
+	if idx < 2 {
+		transfer.Assigned, transfer.Handled, transfer.Passed = true, true, true
+		if err := w.wallets.UpdateTransfer(ctx, transfer); err != nil {
+			log.WithError(err).Errorln("wallets.UpdateTransfer")
+			return err
+		}
+
+		return nil
+	}
+
+	transfer.Amount = sum
+	log.Infof("merge %d outputs, %s in total", idx, sum)
+	return w.commit(ctx, outputs[:idx], transfer)
+}
+
 func (w *Assigner) commit(ctx context.Context, outputs []*core.Output, transfer *core.Transfer) error {
 	if err := w.wallets.Assign(ctx, outputs, transfer); err != nil {
 		logger.FromContext(ctx).WithError(err).Errorln("wallets.Assign")
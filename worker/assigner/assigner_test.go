@@ -0,0 +1,94 @@
+package assigner
+
+import (
+	"compound/core"
+	"compound/store/memory"
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newID() string {
+	return uuid.Must(uuid.NewV4()).String()
+}
+
+func TestAssignConsolidation(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		wallets = memory.NewWalletStore()
+		system  = &core.System{MemberIDs: []string{newID(), newID()}, Threshold: 2}
+		w       = New(wallets, system)
+	)
+
+	btc, eth := newID(), newID()
+	var outputs []*core.Output
+	for i := 0; i < 3; i++ {
+		outputs = append(outputs, &core.Output{TraceID: newID(), AssetID: btc, Amount: decimal.NewFromInt(1)})
+	}
+	outputs = append(outputs, &core.Output{TraceID: newID(), AssetID: eth, Amount: decimal.NewFromInt(1)})
+	// handled by the payee after the consolidations
+	outputs = append(outputs, &core.Output{TraceID: newID(), AssetID: btc, Amount: decimal.NewFromInt(1)})
+	require.Nil(t, wallets.Save(ctx, outputs, true))
+
+	consolidation := func(assetID string, version, threshold, batch int64) *core.Transfer {
+		return &core.Transfer{
+			TraceID:   newID(),
+			Version:   version,
+			AssetID:   assetID,
+			Opponents: system.MemberIDs,
+			Threshold: system.Threshold,
+			Memo:      core.ConsolidationMemo(threshold, batch),
+		}
+	}
+
+	var (
+		// 3 btc outputs exceed the threshold, the oldest 2 are merged
+		merge = consolidation(btc, 4, 1, 2)
+		// only one eth output to merge
+		empty = consolidation(eth, 4, 0, 32)
+	)
+	require.Nil(t, wallets.CreateTransfers(ctx, []*core.Transfer{merge, empty}))
+	require.Nil(t, w.run(ctx))
+
+	transfers, err := wallets.ListTransfers(ctx, core.TransferStatusAssigned, 0)
+	require.Nil(t, err)
+	if assert.Len(t, transfers, 1) {
+		assert.Equal(t, merge.TraceID, transfers[0].TraceID)
+		assert.True(t, transfers[0].Amount.Equal(decimal.NewFromInt(2)), transfers[0].Amount.String())
+		_, _, ok := transfers[0].Consolidation()
+		assert.False(t, ok)
+
+		spent, err := wallets.ListSpentBy(ctx, btc, merge.TraceID)
+		require.Nil(t, err)
+		if assert.Len(t, spent, 2) {
+			assert.Equal(t, outputs[1].TraceID, spent[1].TraceID)
+		}
+	}
+
+	transfers, err = wallets.ListTransfers(ctx, core.TransferStatusPassed, 0)
+	require.Nil(t, err)
+	if assert.Len(t, transfers, 1) {
+		assert.Equal(t, empty.TraceID, transfers[0].TraceID)
+	}
+
+	// 2 btc outputs left, not more than the threshold
+	under := consolidation(btc, 5, 2, 32)
+	require.Nil(t, wallets.CreateTransfers(ctx, []*core.Transfer{under}))
+	require.Nil(t, w.run(ctx))
+
+	transfers, err = wallets.ListTransfers(ctx, core.TransferStatusPassed, 0)
+	require.Nil(t, err)
+	assert.Len(t, transfers, 2)
+
+	unspent, err := wallets.ListUnspent(ctx, eth, 0)
+	require.Nil(t, err)
+	assert.Len(t, unspent, 1)
+	unspent, err = wallets.ListUnspent(ctx, btc, 0)
+	require.Nil(t, err)
+	assert.Len(t, unspent, 2)
+}
@@ -2,6 +2,8 @@ package cashier
 
 import (
 	"compound/core"
+	"compound/metric"
+	"compound/worker"
 	"context"
 	"errors"
 	"time"
@@ -24,6 +26,7 @@ type Config struct {
 func New(
 	wallets core.WalletStore,
 	walletz core.WalletService,
+	divergences core.DivergenceStore,
 	system *core.System,
 	cfg Config,
 ) *Cashier {
@@ -32,20 +35,22 @@ func New(
 	}
 
 	w := &Cashier{
-		wallets: wallets,
-		walletz: walletz,
-		system:  system,
-		cfg:     cfg,
+		wallets:     wallets,
+		walletz:     walletz,
+		divergences: divergences,
+		system:      system,
+		cfg:         cfg,
 	}
 
 	return w
 }
 
 type Cashier struct {
-	wallets core.WalletStore
-	walletz core.WalletService
-	system  *core.System
-	cfg     Config
+	wallets     core.WalletStore
+	walletz     core.WalletService
+	divergences core.DivergenceStore
+	system      *core.System
+	cfg         Config
 }
 
 func (w *Cashier) Run(ctx context.Context) error {
@@ -66,7 +71,7 @@ func (w *Cashier) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(dur):
-			if err := w.run(ctx, f); err == nil {
+			if err := metric.ObserveWorker("cashier", func() error { return w.run(ctx, f) }); err == nil {
 				dur = 300 * time.Millisecond
 			} else {
 				dur = 500 * time.Millisecond
@@ -78,14 +83,41 @@ func (w *Cashier) Run(ctx context.Context) error {
 func (w *Cashier) run(ctx context.Context, f func(context.Context, []*core.Transfer) error) error {
 	log := logger.FromContext(ctx)
 
-	transfers, err := w.wallets.ListTransfers(ctx, core.TransferStatusAssigned, w.cfg.Batch)
+	// the members have different states, the diverged transfers wait for a human to resolve instead of retrying
+	unresolved, err := w.divergences.ListUnresolved(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("divergences.ListUnresolved")
+		return err
+	}
+
+	diverged := make(map[string]bool, len(unresolved))
+	for _, d := range unresolved {
+		diverged[d.TraceID] = true
+	}
+
+	// page past the diverged transfers so that they don't block the ones behind
+	transfers, err := w.wallets.ListTransfers(ctx, core.TransferStatusAssigned, w.cfg.Batch+len(diverged))
 	if err != nil {
 		log.WithError(err).Errorln("wallets.ListTransfers")
 		return err
 	}
 
+	idx := 0
+	for _, transfer := range transfers {
+		if diverged[transfer.TraceID] {
+			log.WithField("transfer", transfer.TraceID).Debugln("skip diverged transfer")
+			continue
+		}
+
+		transfers[idx] = transfer
+		if idx++; idx == w.cfg.Batch {
+			break
+		}
+	}
+	transfers = transfers[:idx]
+
 	if len(transfers) == 0 {
-		return errors.New("EOF")
+		return worker.ErrIdle
 	}
 
 	return f(ctx, transfers)
@@ -144,6 +176,17 @@ func (w *Cashier) handleTransfer(ctx context.Context, transfer *core.Transfer) e
 
 func (w *Cashier) spend(ctx context.Context, outputs []*core.Output, transfer *core.Transfer) error {
 	if tx, err := w.walletz.Spend(ctx, outputs, transfer); err != nil {
+		var divergence *core.DivergenceError
+		if errors.As(err, &divergence) {
+			logger.FromContext(ctx).WithError(err).Errorln("walletz.Spend diverged")
+			if err := w.divergences.Save(ctx, divergence.Divergence); err != nil {
+				logger.FromContext(ctx).WithError(err).Errorln("divergences.Save")
+				return err
+			}
+
+			return nil
+		}
+
 		logger.FromContext(ctx).WithError(err).Errorln("walletz.Spend")
 		return err
 	} else if tx != nil {
@@ -0,0 +1,129 @@
+package cashier
+
+import (
+	"compound/core"
+	"compound/store/memory"
+	"compound/worker"
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type divergedWalletService struct {
+	core.WalletService
+	spent int
+}
+
+func (s *divergedWalletService) Spend(ctx context.Context, outputs []*core.Output, transfer *core.Transfer) (*core.RawTransaction, error) {
+	s.spent++
+	return nil, &core.DivergenceError{Divergence: &core.Divergence{
+		TraceID:        transfer.TraceID,
+		AssetID:        transfer.AssetID,
+		Stage:          core.DivergenceStageMultisig,
+		Reason:         "memo not match",
+		Signers:        []string{"peer"},
+		ExpectedMemo:   transfer.Memo,
+		ObservedMemo:   "other",
+		ExpectedAmount: transfer.Amount,
+		ObservedAmount: transfer.Amount,
+	}}
+}
+
+func TestCashierDivergence(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		wallets     = memory.NewWalletStore()
+		divergences = memory.NewDivergenceStore()
+		walletz     = &divergedWalletService{}
+	)
+
+	w := New(wallets, walletz, divergences, &core.System{}, Config{Batch: 10, Capacity: 1})
+
+	assetID := uuid.Must(uuid.NewV4()).String()
+	require.Nil(t, wallets.Save(ctx, []*core.Output{
+		{TraceID: uuid.Must(uuid.NewV4()).String(), AssetID: assetID, Amount: decimal.NewFromInt(1)},
+	}, true))
+
+	outputs, err := wallets.ListUnspent(ctx, assetID, 0)
+	require.Nil(t, err)
+	transfer := &core.Transfer{
+		TraceID: uuid.Must(uuid.NewV4()).String(),
+		AssetID: assetID,
+		Amount:  decimal.NewFromInt(1),
+		Memo:    "local",
+	}
+	require.Nil(t, wallets.Assign(ctx, outputs, transfer))
+
+	require.Nil(t, w.run(ctx, w.sync))
+	assert.Equal(t, 1, walletz.spent)
+
+	d, err := divergences.Find(ctx, transfer.TraceID)
+	require.Nil(t, err)
+	assert.Equal(t, "other", d.ObservedMemo)
+	assert.False(t, d.Resolved)
+
+	// wait for a human instead of retrying
+	assert.Equal(t, worker.ErrIdle, w.run(ctx, w.sync))
+	assert.Equal(t, 1, walletz.spent)
+
+	transfers, err := wallets.ListTransfers(ctx, core.TransferStatusAssigned, 0)
+	require.Nil(t, err)
+	assert.Len(t, transfers, 1, "not handled")
+
+	// retry after resolved, diverged again
+	require.Nil(t, divergences.Resolve(ctx, transfer.TraceID))
+	require.Nil(t, w.run(ctx, w.sync))
+	assert.Equal(t, 2, walletz.spent)
+
+	unresolved, err := divergences.ListUnresolved(ctx)
+	require.Nil(t, err)
+	assert.Len(t, unresolved, 1)
+}
+
+// the diverged transfers at the head don't block the ones behind
+func TestCashierDivergenceHead(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		wallets     = memory.NewWalletStore()
+		divergences = memory.NewDivergenceStore()
+		walletz     = &divergedWalletService{}
+	)
+
+	w := New(wallets, walletz, divergences, &core.System{}, Config{Batch: 1, Capacity: 1})
+
+	assetID := uuid.Must(uuid.NewV4()).String()
+	var transfers []*core.Transfer
+	for i := 0; i < 3; i++ {
+		require.Nil(t, wallets.Save(ctx, []*core.Output{
+			{TraceID: uuid.Must(uuid.NewV4()).String(), AssetID: assetID, Amount: decimal.NewFromInt(1)},
+		}, true))
+
+		outputs, err := wallets.ListUnspent(ctx, assetID, 0)
+		require.Nil(t, err)
+		transfer := &core.Transfer{
+			TraceID: uuid.Must(uuid.NewV4()).String(),
+			AssetID: assetID,
+			Amount:  decimal.NewFromInt(1),
+		}
+		require.Nil(t, wallets.Assign(ctx, outputs, transfer))
+		transfers = append(transfers, transfer)
+	}
+
+	// every run diverges the next transfer
+	for i := range transfers {
+		require.Nil(t, w.run(ctx, w.sync))
+		assert.Equal(t, i+1, walletz.spent)
+
+		_, err := divergences.Find(ctx, transfers[i].TraceID)
+		require.Nil(t, err)
+	}
+
+	assert.Equal(t, worker.ErrIdle, w.run(ctx, w.sync))
+	assert.Equal(t, len(transfers), walletz.spent)
+}
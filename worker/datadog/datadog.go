@@ -6,6 +6,8 @@ import (
 	"compound/metric"
 	"context"
 	"encoding/base64"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/asaskevich/govalidator"
@@ -25,6 +27,7 @@ type Config struct {
 func New(
 	wallets core.WalletStore,
 	properties property.Store,
+	divergences core.DivergenceStore,
 	messagez core.MessageService,
 	cfg Config,
 ) *Datadog {
@@ -35,6 +38,7 @@ func New(
 	return &Datadog{
 		wallets:        wallets,
 		properties:     properties,
+		divergences:    divergences,
 		messagez:       messagez,
 		interval:       cfg.Interval,
 		launchAt:       time.Now(),
@@ -46,6 +50,7 @@ func New(
 type Datadog struct {
 	wallets        core.WalletStore
 	properties     property.Store
+	divergences    core.DivergenceStore
 	messagez       core.MessageService
 	interval       time.Duration
 	launchAt       time.Time
@@ -64,7 +69,7 @@ func (w *Datadog) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case t := <-time.After(dur):
-			if err := w.run(ctx); err != nil {
+			if err := metric.ObserveWorker("datadog", func() error { return w.run(ctx) }); err != nil {
 				dur = time.Second
 			} else {
 				dur = t.Truncate(w.interval).Add(w.interval).Sub(t)
@@ -111,6 +116,28 @@ func (w *Datadog) run(ctx context.Context) error {
 		report = unhandled > 0 || report
 	}
 
+	// divergences, the members have different states
+	{
+		divergences, err := w.divergences.ListUnresolved(ctx)
+		if err != nil {
+			log.WithError(err).Errorln("divergences.ListUnresolved")
+			return err
+		}
+
+		if len(divergences) > 0 {
+			group := metric.Group{Name: "divergences"}
+			for _, d := range divergences {
+				group.Entries = append(group.Entries, metric.Entry{
+					Name:  d.TraceID,
+					Value: fmt.Sprintf("%s: %s, signers %s", d.Stage, d.Reason, strings.Join(d.Signers, ",")),
+				})
+			}
+
+			groups = append(groups, group)
+			report = true
+		}
+	}
+
 	// properties
 	{
 		items, err := w.properties.List(ctx)
@@ -0,0 +1,236 @@
+package liquidator
+
+import (
+	"compound/core"
+	"compound/metric"
+	"compound/pkg/compound"
+	"compound/worker/payee"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/shopspring/decimal"
+)
+
+// Liquidator liquidator worker, scans the accounts with shortfall
+type Liquidator struct {
+	marketStore    core.IMarketStore
+	supplyStore    core.ISupplyStore
+	borrowStore    core.IBorrowStore
+	candidateStore core.LiquidationCandidateStore
+	accountService core.IAccountService
+	interval       time.Duration
+}
+
+// New new liquidator worker
+func New(
+	marketStr core.IMarketStore,
+	supplyStr core.ISupplyStore,
+	borrowStr core.IBorrowStore,
+	candidateStr core.LiquidationCandidateStore,
+	accountSrv core.IAccountService,
+	interval time.Duration,
+) *Liquidator {
+	return &Liquidator{
+		marketStore:    marketStr,
+		supplyStore:    supplyStr,
+		borrowStore:    borrowStr,
+		candidateStore: candidateStr,
+		accountService: accountSrv,
+		interval:       interval,
+	}
+}
+
+// Run run worker
+func (w *Liquidator) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx).WithField("worker", "liquidator")
+	ctx = logger.WithContext(ctx, log)
+
+	dur := time.Millisecond
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dur):
+			if err := metric.ObserveWorker("liquidator", func() error { return w.run(ctx) }); err == nil {
+				dur = w.interval
+			} else {
+				dur = time.Second
+			}
+		}
+	}
+}
+
+func (w *Liquidator) run(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	markets, err := w.marketStore.All(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("markets.All")
+		return err
+	}
+
+	now := time.Now()
+	for _, m := range markets {
+		payee.AccrueInterest(ctx, m, now)
+	}
+
+	users, err := w.users(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		candidates = make([]*core.LiquidationCandidate, 0)
+		skipped    int
+	)
+	for _, userID := range users {
+		candidate, err := w.scan(ctx, userID, markets)
+		if err != nil {
+			// logged by scan, one broken account shouldn't block the others
+			skipped++
+			continue
+		}
+
+		if candidate != nil {
+			candidate.ScannedAt = now
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Shortfall.GreaterThan(candidates[j].Shortfall)
+	})
+
+	if err := w.candidateStore.Save(ctx, candidates, now); err != nil {
+		log.WithError(err).Errorln("candidates.Save")
+		return err
+	}
+
+	log.Debugf("%d users scanned, %d skipped, %d candidates found", len(users), skipped, len(candidates))
+	return nil
+}
+
+func (w *Liquidator) users(ctx context.Context) ([]string, error) {
+	log := logger.FromContext(ctx)
+
+	suppliers, err := w.supplyStore.Users(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("supplies.Users")
+		return nil, err
+	}
+
+	borrowers, err := w.borrowStore.Users(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("borrows.Users")
+		return nil, err
+	}
+
+	var (
+		users = make([]string, 0, len(suppliers)+len(borrowers))
+		seen  = make(map[string]bool)
+	)
+	for _, user := range append(suppliers, borrowers...) {
+		if !seen[user] {
+			seen[user] = true
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
+// scan returns nil if the account has no shortfall
+//
+// 	max_seize = collaterals * close_factor
+// 	max_repay = min(borrow_balance, max(max_seize * exchange_rate * seized_price) / price)
+func (w *Liquidator) scan(ctx context.Context, userID string, markets []*core.Market) (*core.LiquidationCandidate, error) {
+	log := logger.FromContext(ctx).WithField("user", userID)
+
+	liquidity, err := w.accountService.CalculateAccountLiquidity(ctx, userID, markets...)
+	if err != nil {
+		log.WithError(err).Errorln("accountz.CalculateAccountLiquidity")
+		return nil, err
+	}
+
+	if !liquidity.IsNegative() {
+		return nil, nil
+	}
+
+	supplies, err := w.supplyStore.FindByUser(ctx, userID)
+	if err != nil {
+		log.WithError(err).Errorln("supplies.FindByUser")
+		return nil, err
+	}
+
+	borrows, err := w.borrowStore.FindByUser(ctx, userID)
+	if err != nil {
+		log.WithError(err).Errorln("borrows.FindByUser")
+		return nil, err
+	}
+
+	candidate := core.LiquidationCandidate{
+		UserID:    userID,
+		Shortfall: liquidity.Neg(),
+		Repays:    core.LiquidationRepays{},
+		Seizes:    core.LiquidationSeizes{},
+	}
+
+	maxSeizeValue := decimal.Zero
+	for _, supply := range supplies {
+		market := findMarket(markets, func(m *core.Market) bool { return m.CTokenAssetID == supply.CTokenAssetID })
+		if market == nil || !supply.Collaterals.IsPositive() {
+			continue
+		}
+
+		seizedPrice := market.Price.Sub(market.Price.Mul(market.LiquidationIncentive)).Truncate(compound.MaxPricision)
+		maxSeize := supply.Collaterals.Mul(market.CloseFactor).Truncate(8)
+		if value := maxSeize.Mul(market.CurExchangeRate()).Mul(seizedPrice); value.GreaterThan(maxSeizeValue) {
+			maxSeizeValue = value
+		}
+
+		candidate.Seizes = append(candidate.Seizes, core.LiquidationSeize{
+			AssetID:       market.AssetID,
+			CTokenAssetID: market.CTokenAssetID,
+			Symbol:        market.Symbol,
+			Collaterals:   supply.Collaterals,
+			SeizedPrice:   seizedPrice,
+			MaxSeize:      maxSeize,
+		})
+	}
+
+	for _, borrow := range borrows {
+		market := findMarket(markets, func(m *core.Market) bool { return m.AssetID == borrow.AssetID })
+		if market == nil || !market.Price.IsPositive() {
+			continue
+		}
+
+		balance := compound.BorrowBalance(ctx, borrow, market)
+		if !balance.IsPositive() {
+			continue
+		}
+
+		maxRepay := decimal.Min(balance, maxSeizeValue.Div(market.Price)).Truncate(8)
+		candidate.Repays = append(candidate.Repays, core.LiquidationRepay{
+			AssetID:  market.AssetID,
+			Symbol:   market.Symbol,
+			Balance:  balance,
+			Price:    market.Price,
+			MaxRepay: maxRepay,
+		})
+	}
+
+	return &candidate, nil
+}
+
+func findMarket(markets []*core.Market, match func(m *core.Market) bool) *core.Market {
+	for _, m := range markets {
+		if match(m) {
+			return m
+		}
+	}
+
+	return nil
+}
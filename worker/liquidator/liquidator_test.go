@@ -0,0 +1,96 @@
+package liquidator
+
+import (
+	"compound/core"
+	"compound/pkg/compound"
+	"compound/service/account"
+	"compound/store/memory"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiquidatorScan(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		markets    = memory.NewMarketStore()
+		supplies   = memory.NewSupplyStore()
+		borrows    = memory.NewBorrowStore()
+		candidates = memory.NewLiquidationCandidateStore()
+		w          = New(markets, supplies, borrows, candidates, account.New(markets, supplies, borrows), time.Minute)
+	)
+
+	block, err := compound.GetBlockByTime(ctx, time.Now())
+	require.Nil(t, err)
+
+	newMarket := func(symbol string, price decimal.Decimal) *core.Market {
+		market := &core.Market{
+			Symbol:               symbol,
+			AssetID:              uuid.Must(uuid.NewV4()).String(),
+			CTokenAssetID:        uuid.Must(uuid.NewV4()).String(),
+			TotalCash:            decimal.NewFromInt(100000),
+			InitExchangeRate:     decimal.New(1, 0),
+			ExchangeRate:         decimal.New(1, 0),
+			BorrowIndex:          decimal.New(1, 0),
+			CollateralFactor:     decimal.NewFromFloat(0.75),
+			CloseFactor:          decimal.NewFromFloat(0.5),
+			LiquidationIncentive: decimal.NewFromFloat(0.05),
+			BlockNumber:          block,
+			Price:                price,
+			Status:               core.MarketStatusOpen,
+		}
+		require.Nil(t, markets.Create(ctx, market))
+		return market
+	}
+
+	btc := newMarket("BTC", decimal.NewFromInt(10000))
+	usdt := newMarket("USDT", decimal.New(1, 0))
+
+	var (
+		short   = uuid.Must(uuid.NewV4()).String()
+		healthy = uuid.Must(uuid.NewV4()).String()
+		broken  = uuid.Must(uuid.NewV4()).String()
+	)
+
+	for _, userID := range []string{short, healthy} {
+		require.Nil(t, supplies.Create(ctx, &core.Supply{UserID: userID, CTokenAssetID: btc.CTokenAssetID, Collaterals: decimal.New(1, 0)}))
+	}
+	require.Nil(t, borrows.Create(ctx, &core.Borrow{UserID: short, AssetID: usdt.AssetID, Principal: decimal.NewFromInt(8000), InterestIndex: decimal.New(1, 0)}))
+	require.Nil(t, borrows.Create(ctx, &core.Borrow{UserID: healthy, AssetID: usdt.AssetID, Principal: decimal.NewFromInt(1000), InterestIndex: decimal.New(1, 0)}))
+	// the market of the collateral is missing, the liquidity can't be calculated
+	require.Nil(t, supplies.Create(ctx, &core.Supply{UserID: broken, CTokenAssetID: uuid.Must(uuid.NewV4()).String(), Collaterals: decimal.New(1, 0)}))
+
+	require.Nil(t, w.run(ctx))
+
+	list, err := candidates.List(ctx)
+	require.Nil(t, err)
+	require.Len(t, list, 1, "the broken account is skipped")
+	assert.Equal(t, short, list[0].UserID)
+	assert.Equal(t, "500", list[0].Shortfall.String())
+	first := list[0]
+
+	// rescanned, the candidate is updated in place
+	require.Nil(t, w.run(ctx))
+	list, err = candidates.List(ctx)
+	require.Nil(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, first.ID, list[0].ID)
+	assert.False(t, list[0].ScannedAt.Before(first.ScannedAt))
+
+	// repaid, the candidate is deleted
+	borrow, err := borrows.Find(ctx, short, usdt.AssetID)
+	require.Nil(t, err)
+	borrow.Principal = decimal.NewFromInt(1000)
+	require.Nil(t, borrows.Update(ctx, borrow, borrow.Version+1))
+
+	require.Nil(t, w.run(ctx))
+	list, err = candidates.List(ctx)
+	require.Nil(t, err)
+	assert.Empty(t, list)
+}
@@ -2,8 +2,9 @@ package messenger
 
 import (
 	"compound/core"
+	"compound/metric"
+	"compound/worker"
 	"context"
-	"errors"
 	"time"
 
 	"github.com/bluele/gcache"
@@ -36,7 +37,7 @@ func (w *Messenger) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(dur):
-			if err := w.run(ctx); err == nil {
+			if err := metric.ObserveWorker("messenger", func() error { return w.run(ctx) }); err == nil {
 				dur = 300 * time.Millisecond
 			} else {
 				dur = time.Second
@@ -57,7 +58,7 @@ func (w *Messenger) run(ctx context.Context) error {
 	}
 
 	if len(messages) == 0 {
-		return errors.New("list messages: EOF")
+		return worker.ErrIdle
 	}
 
 	filter := make(map[string]bool, 10)
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"compound/core"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/fox-one/pkg/uuid"
+)
+
+// checkHealthFactors warn the subscribers whose health factor falls below the subscribed one,
+// a subscriber is warned at most once every cooldown
+func (w *Notifier) checkHealthFactors(ctx context.Context, now time.Time) error {
+	log := logger.FromContext(ctx)
+
+	var fromID int64
+	for {
+		subscribers, err := w.subscribers.List(ctx, fromID, limit)
+		if err != nil {
+			log.WithError(err).Errorln("subscribers.List")
+			return err
+		}
+
+		for _, subscriber := range subscribers {
+			fromID = subscriber.ID
+
+			if !subscriber.HealthFactor.IsPositive() {
+				continue
+			}
+
+			if subscriber.WarnedAt.Valid && now.Sub(subscriber.WarnedAt.Time) < w.cooldown {
+				continue
+			}
+
+			snapshot, err := w.accountz.Snapshot(ctx, subscriber.UserID)
+			if err != nil {
+				log.WithError(err).Errorln("accountz.Snapshot", subscriber.UserID)
+				continue
+			}
+
+			// zero if no borrows
+			if !snapshot.HealthFactor.IsPositive() || snapshot.HealthFactor.GreaterThanOrEqual(subscriber.HealthFactor) {
+				continue
+			}
+
+			// one message id per cooldown bucket, a warning sent again by a retried check is deduplicated by mixin
+			bucket := now.Truncate(w.cooldown)
+			messageID := uuid.Modify(subscriber.UserID, fmt.Sprintf("health:%d", bucket.Unix()))
+			msg := w.buildMessage(subscriber.UserID, messageID, renderHealthWarning(snapshot, subscriber.HealthFactor))
+			if err := w.messages.Create(ctx, []*core.Message{msg}); err != nil {
+				log.WithError(err).Errorln("messages.Create")
+				return err
+			}
+
+			if err := w.subscribers.Warned(ctx, subscriber.UserID, now); err != nil {
+				log.WithError(err).Errorln("subscribers.Warned")
+				return err
+			}
+		}
+
+		if len(subscribers) < limit {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package notifier
+
+import (
+	"compound/core"
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// render the notification of the transaction, the user is empty if nobody to notify
+func (w *Notifier) render(ctx context.Context, tx *core.Transaction) (string, string) {
+	var extra struct {
+		AssetID       string           `json:"asset_id"`
+		Amount        decimal.Decimal  `json:"amount"`
+		RepayAmount   decimal.Decimal  `json:"repay_amount"`
+		CTokenAssetID string           `json:"ctoken_asset_id"`
+		ErrorCode     int              `json:"error_code"`
+		Message       string           `json:"message"`
+		Origin        core.ActionType  `json:"origin"`
+		Supply        core.ExtraSupply `json:"supply"`
+		Borrow        core.ExtraBorrow `json:"borrow"`
+	}
+
+	if err := tx.UnmarshalExtraData(&extra); err != nil {
+		return "", ""
+	}
+
+	switch tx.Action {
+	case core.ActionTypeSupply:
+		return tx.UserID, fmt.Sprintf("✅ Supplied %s %s", tx.Amount, w.assetSymbol(ctx, tx.AssetID))
+	case core.ActionTypeBorrow:
+		return tx.UserID, fmt.Sprintf("✅ Borrowed %s %s", extra.Amount, w.assetSymbol(ctx, extra.AssetID))
+	case core.ActionTypeRepay:
+		return tx.UserID, fmt.Sprintf("✅ Repaid %s %s", extra.RepayAmount, w.assetSymbol(ctx, tx.AssetID))
+	case core.ActionTypeRedeem:
+		return tx.UserID, fmt.Sprintf("✅ Redeemed %s %s", extra.Amount, w.assetSymbol(ctx, extra.AssetID))
+	case core.ActionTypeRefundTransfer:
+		reason := extra.Message
+		if extra.ErrorCode > 0 {
+			reason = core.ErrorCode(extra.ErrorCode).String()
+		}
+
+		text := fmt.Sprintf("↩️ %s %s refunded", tx.Amount, w.assetSymbol(ctx, tx.AssetID))
+		if extra.Origin != core.ActionTypeDefault {
+			text += fmt.Sprintf(", %s failed", extra.Origin)
+		}
+		if reason != "" {
+			text += fmt.Sprintf(" (error: %s)", reason)
+		}
+
+		return tx.UserID, text
+	case core.ActionTypeLiquidate:
+		// the liquidator is the user of the transaction, notify the seized account
+		if extra.Supply.UserID == "" {
+			return "", ""
+		}
+
+		return extra.Supply.UserID, fmt.Sprintf(
+			"⚠️ You were liquidated, %s ctokens of %s seized to repay %s %s",
+			extra.Amount,
+			w.ctokenSymbol(ctx, extra.CTokenAssetID),
+			extra.RepayAmount,
+			w.assetSymbol(ctx, extra.Borrow.AssetID),
+		)
+	default:
+		return "", ""
+	}
+}
+
+// renderHealthWarning the warning of the account whose health factor falls below the subscribed one
+func renderHealthWarning(snapshot *core.AccountSnapshot, threshold decimal.Decimal) string {
+	return fmt.Sprintf(
+		"⚠️ Your health factor dropped to %s, below %s. The account can be liquidated once it falls below 1, repay or pledge more to keep it safe.",
+		snapshot.HealthFactor.StringFixed(4),
+		threshold,
+	)
+}
+
+// assetSymbol the symbol of the market, or the mixin asset if it isn't a market
+func (w *Notifier) assetSymbol(ctx context.Context, assetID string) string {
+	if v, err := w.symbols.Get(assetID); err == nil {
+		return v.(string)
+	}
+
+	symbol := assetID
+	if market, err := w.markets.Find(ctx, assetID); err == nil && market.ID > 0 {
+		symbol = market.Symbol
+	} else if asset, err := w.client.ReadAsset(ctx, assetID); err == nil {
+		symbol = asset.Symbol
+	} else {
+		return symbol
+	}
+
+	_ = w.symbols.Set(assetID, symbol)
+	return symbol
+}
+
+// ctokenSymbol the symbol of the market the ctoken belongs to
+func (w *Notifier) ctokenSymbol(ctx context.Context, ctokenAssetID string) string {
+	market, err := w.markets.FindByCToken(ctx, ctokenAssetID)
+	if err != nil || market.ID == 0 {
+		return ctokenAssetID
+	}
+
+	return market.Symbol
+}
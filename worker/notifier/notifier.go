@@ -0,0 +1,201 @@
+package notifier
+
+import (
+	"compound/core"
+	"compound/metric"
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/bluele/gcache"
+	"github.com/fox-one/mixin-sdk-go"
+	"github.com/fox-one/pkg/logger"
+	"github.com/fox-one/pkg/property"
+	"github.com/fox-one/pkg/uuid"
+)
+
+const (
+	checkpointKey   = "notifier_checkpoint"
+	checkpointIDKey = "notifier_checkpoint_id"
+	limit           = 500
+)
+
+// Config notifier config
+type Config struct {
+	// Interval the interval of the health factor checks
+	Interval time.Duration
+	// Cooldown the min interval of the health factor warnings to the same user
+	Cooldown time.Duration
+}
+
+// Notifier notifier worker, sends the notifications to the subscribers
+// built from the transactions and the periodic health factor checks
+type Notifier struct {
+	system       *core.System
+	client       *mixin.Client
+	properties   property.Store
+	transactions core.TransactionStore
+	subscribers  core.SubscriberStore
+	markets      core.IMarketStore
+	messages     core.MessageStore
+	accountz     core.IAccountService
+	interval     time.Duration
+	cooldown     time.Duration
+
+	symbols   gcache.Cache
+	checkedAt time.Time
+}
+
+// New new notifier worker
+func New(
+	system *core.System,
+	client *mixin.Client,
+	properties property.Store,
+	transactions core.TransactionStore,
+	subscribers core.SubscriberStore,
+	markets core.IMarketStore,
+	messages core.MessageStore,
+	accountz core.IAccountService,
+	cfg Config,
+) *Notifier {
+	return &Notifier{
+		system:       system,
+		client:       client,
+		properties:   properties,
+		transactions: transactions,
+		subscribers:  subscribers,
+		markets:      markets,
+		messages:     messages,
+		accountz:     accountz,
+		interval:     cfg.Interval,
+		cooldown:     cfg.Cooldown,
+		symbols:      gcache.New(1024).LRU().Build(),
+	}
+}
+
+// Run run worker
+func (w *Notifier) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx).WithField("worker", "notifier")
+	ctx = logger.WithContext(ctx, log)
+
+	dur := time.Millisecond
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dur):
+			if err := metric.ObserveWorker("notifier", func() error { return w.run(ctx) }); err == nil {
+				dur = time.Second
+			} else {
+				dur = 5 * time.Second
+			}
+		}
+	}
+}
+
+func (w *Notifier) run(ctx context.Context) error {
+	if err := w.notifyTransactions(ctx); err != nil {
+		return err
+	}
+
+	if now := time.Now(); now.Sub(w.checkedAt) >= w.interval {
+		if err := w.checkHealthFactors(ctx, now); err != nil {
+			return err
+		}
+
+		w.checkedAt = now
+	}
+
+	return nil
+}
+
+// notifyTransactions notify the subscribers of the transactions after the checkpoint
+func (w *Notifier) notifyTransactions(ctx context.Context) error {
+	log := logger.FromContext(ctx)
+
+	offset, lastID, err := w.readCheckpoint(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("read checkpoint")
+		return err
+	}
+
+	transactions, err := w.transactions.List(ctx, offset, limit)
+	if err != nil {
+		log.WithError(err).Errorln("transactions.List")
+		return err
+	}
+
+	var messages []*core.Message
+	for _, tx := range transactions {
+		// the transactions at the checkpoint time are listed again
+		if tx.CreatedAt.Equal(offset) && tx.ID <= lastID {
+			continue
+		}
+
+		offset, lastID = tx.CreatedAt, tx.ID
+
+		userID, text := w.render(ctx, tx)
+		if userID == "" || text == "" {
+			continue
+		}
+
+		subscriber, err := w.subscribers.Find(ctx, userID)
+		if err != nil {
+			log.WithError(err).Errorln("subscribers.Find")
+			return err
+		}
+
+		if subscriber.ID == 0 {
+			continue
+		}
+
+		messages = append(messages, w.buildMessage(userID, uuid.Modify(tx.TraceID, "notify:"+userID), text))
+	}
+
+	if len(messages) > 0 {
+		if err := w.messages.Create(ctx, messages); err != nil {
+			log.WithError(err).Errorln("messages.Create")
+			return err
+		}
+	}
+
+	return w.saveCheckpoint(ctx, offset, lastID)
+}
+
+func (w *Notifier) buildMessage(userID, messageID, text string) *core.Message {
+	return core.BuildMessage(&mixin.MessageRequest{
+		RecipientID:    userID,
+		ConversationID: mixin.UniqueConversationID(w.system.ClientID, userID),
+		MessageID:      messageID,
+		Category:       mixin.MessageCategoryPlainText,
+		Data:           base64.StdEncoding.EncodeToString([]byte(text)),
+	})
+}
+
+func (w *Notifier) readCheckpoint(ctx context.Context) (time.Time, int64, error) {
+	v, err := w.properties.Get(ctx, checkpointKey)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	// notify the new transactions only on the first launch
+	if v.String() == "" {
+		return time.Now(), 0, nil
+	}
+
+	id, err := w.properties.Get(ctx, checkpointIDKey)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return v.Time(), id.Int64(), nil
+}
+
+func (w *Notifier) saveCheckpoint(ctx context.Context, offset time.Time, id int64) error {
+	if err := w.properties.Save(ctx, checkpointIDKey, id); err != nil {
+		return err
+	}
+
+	return w.properties.Save(ctx, checkpointKey, offset)
+}
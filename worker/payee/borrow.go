@@ -68,6 +68,10 @@ func (w *Payee) handleBorrowEvent(ctx context.Context, output *core.Output, user
 	}
 
 	if tx.ID == 0 {
+		if err := w.requireFreshPrices(ctx, userID, output.CreatedAt, market); err != nil {
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeBorrow, core.ErrPriceStale)
+		}
+
 		liquidity, err := w.accountService.CalculateAccountLiquidity(ctx, userID, market)
 		if err != nil {
 			log.WithError(err).Errorln("CalculateAccountLiquidity")
@@ -83,7 +87,29 @@ func (w *Payee) handleBorrowEvent(ctx context.Context, output *core.Output, user
 			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeBorrow, core.ErrBorrowNotAllowed)
 		}
 
+		change := isolationChange{
+			Borrow: market,
+			Amount: borrowAmount,
+		}
+		isolationAllowed, err := w.isolationAllowed(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolationAllowed")
+			return err
+		}
+
+		if err := compound.Require(isolationAllowed, "payee/isolation-denied", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("borrow not allowed by isolated collaterals")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeBorrow, core.ErrIsolationNotAllowed)
+		}
+
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
 		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
 		extra.Put("asset_id", assetID)
 		extra.Put("amount", borrowAmount)
 		{
@@ -131,13 +157,17 @@ func (w *Payee) handleBorrowEvent(ctx context.Context, output *core.Output, user
 		return err
 	}
 
+	if err := w.applyIsolatedDebts(ctx, tx, output, market); err != nil {
+		return err
+	}
+
 	if output.ID > market.Version {
 		market.TotalCash = market.TotalCash.Sub(borrowAmount).Truncate(compound.MaxPricision)
 		market.TotalBorrows = market.TotalBorrows.Add(borrowAmount).Truncate(compound.MaxPricision)
 		//update interest
 		AccrueInterest(ctx, market, output.CreatedAt)
 		// update market
-		if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err := w.updateMarket(ctx, market, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
@@ -46,7 +46,17 @@ func (w *Payee) handleRepayEvent(ctx context.Context, output *core.Output, userI
 			repayAmount = borrowBalance
 		}
 
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, userID, isolationChange{
+			Borrow: market,
+			Amount: repayAmount.Neg(),
+		})
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
 		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
 		extra.Put("repay_amount", repayAmount)
 		{
 			// useless...
@@ -101,6 +111,10 @@ func (w *Payee) handleRepayEvent(ctx context.Context, output *core.Output, userI
 		}
 	}
 
+	if err := w.applyIsolatedDebts(ctx, tx, output, market); err != nil {
+		return err
+	}
+
 	if output.ID > market.Version {
 		market.TotalBorrows = market.TotalBorrows.Sub(extra.RepayAmount).Truncate(compound.MaxPricision)
 		market.TotalCash = market.TotalCash.Add(extra.RepayAmount).Truncate(compound.MaxPricision)
@@ -110,7 +124,7 @@ func (w *Payee) handleRepayEvent(ctx context.Context, output *core.Output, userI
 
 		//update interest
 		AccrueInterest(ctx, market, output.CreatedAt)
-		if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err := w.updateMarket(ctx, market, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
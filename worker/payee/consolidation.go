@@ -0,0 +1,119 @@
+package payee
+
+import (
+	"compound/core"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/fox-one/pkg/uuid"
+)
+
+// consolidationCountKey the property key of the outputs of the asset counted since the last consolidation,
+// saved as "<output id>:<count>:<output time>" so that an output handled again isn't counted twice
+func consolidationCountKey(assetID string) string {
+	return "consolidation_count:" + assetID
+}
+
+// recordConsolidation count the output of the asset, and record a consolidation to the members once
+// core.ConsolidationThresholdKey outputs are counted and the asset has been idle for core.ConsolidationIdleKey.
+//
+// The unspent outputs of the asset grow by at most one per output handled, so between two consolidations
+// no more than threshold new unspent outputs are left behind. The consolidation is versioned by the output,
+// the assigner counts the unspent outputs not newer than it in the transfers order and merges them only if
+// they exceed the threshold, so all the nodes check and merge the same outputs
+func (w *Payee) recordConsolidation(ctx context.Context, output *core.Output) error {
+	log := logger.FromContext(ctx)
+
+	if w.sysversion < 6 {
+		return nil
+	}
+
+	v, err := w.propertyStore.Get(ctx, core.ConsolidationThresholdKey)
+	if err != nil {
+		log.WithError(err).Errorln("property.Get")
+		return err
+	}
+
+	threshold := v.Int64()
+	if threshold <= 0 {
+		return nil
+	}
+
+	key := consolidationCountKey(output.AssetID)
+	v, err = w.propertyStore.Get(ctx, key)
+	if err != nil {
+		log.WithError(err).Errorln("property.Get", key)
+		return err
+	}
+
+	var version, count, at int64
+	_, _ = fmt.Sscanf(v.String(), "%d:%d:%d", &version, &count, &at)
+	if version >= output.ID {
+		return nil
+	}
+
+	idle, err := w.consolidationIdle(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count++; count >= threshold && output.CreatedAt.Sub(time.Unix(at, 0)) >= idle {
+		batch, err := w.consolidationBatch(ctx)
+		if err != nil {
+			return err
+		}
+
+		consolidation := &core.Transfer{
+			TraceID:   uuid.Modify(output.TraceID, core.TransferMemoConsolidation),
+			Version:   output.ID,
+			AssetID:   output.AssetID,
+			Opponents: w.system.MemberIDs,
+			Threshold: w.system.Threshold,
+			Memo:      core.ConsolidationMemo(threshold, batch),
+		}
+
+		if err := w.walletStore.CreateTransfers(ctx, []*core.Transfer{consolidation}); err != nil {
+			log.WithError(err).Errorln("wallets.CreateTransfers")
+			return err
+		}
+
+		count = 0
+	}
+
+	value := fmt.Sprintf("%d:%d:%d", output.ID, count, output.CreatedAt.Unix())
+	if err := w.propertyStore.Save(ctx, key, value); err != nil {
+		log.WithError(err).Errorln("property.Save", key)
+		return err
+	}
+
+	return nil
+}
+
+// consolidationBatch the outputs count merged by a consolidation
+func (w *Payee) consolidationBatch(ctx context.Context) (int64, error) {
+	v, err := w.propertyStore.Get(ctx, core.ConsolidationBatchKey)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Errorln("property.Get")
+		return 0, err
+	}
+
+	if batch := v.Int64(); batch >= 2 && batch <= core.MaxConsolidationBatch {
+		return batch, nil
+	}
+
+	return core.DefaultConsolidationBatch, nil
+}
+
+// consolidationIdle the idle duration of the asset before a consolidation, 0 if not set or invalid
+func (w *Payee) consolidationIdle(ctx context.Context) (time.Duration, error) {
+	v, err := w.propertyStore.Get(ctx, core.ConsolidationIdleKey)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Errorln("property.Get")
+		return 0, err
+	}
+
+	idle, _ := time.ParseDuration(v.String())
+	return idle, nil
+}
@@ -0,0 +1,87 @@
+package payee
+
+import (
+	"compound/core"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *scenario) consolidations() []*core.Transfer {
+	all, err := s.wallets.ListTransfers(s.ctx, core.TransferStatusPending, 0)
+	require.Nil(s.t, err)
+
+	var transfers []*core.Transfer
+	for _, t := range all {
+		if _, _, ok := t.Consolidation(); ok {
+			transfers = append(transfers, t)
+		}
+	}
+
+	return transfers
+}
+
+func TestScenarioConsolidation(t *testing.T) {
+	s := newScenario(t)
+	vote, amount := s.payee.system.VoteAsset, s.payee.system.VoteAmount
+
+	// disabled without the threshold
+	alice := newUserID()
+	s.send(alice, vote, amount, core.ActionTypeSubscribe)
+	assert.Empty(t, s.consolidations())
+
+	require.Nil(t, s.properties.Save(s.ctx, core.ConsolidationThresholdKey, 3))
+	require.Nil(t, s.properties.Save(s.ctx, core.ConsolidationBatchKey, 10))
+	var outputs []*core.Output
+	for i := 0; i < 7; i++ {
+		outputs = append(outputs, s.send(alice, vote, amount, core.ActionTypeSubscribe))
+	}
+
+	// an output handled again isn't counted twice
+	require.Nil(t, s.payee.handleOutput(s.ctx, outputs[6]))
+	require.Nil(t, s.payee.handleOutput(s.ctx, outputs[2]))
+
+	consolidations := s.consolidations()
+	if assert.Len(t, consolidations, 2) {
+		for idx, c := range consolidations {
+			output := outputs[3*idx+2]
+			assert.Equal(t, output.ID, c.Version)
+			assert.Equal(t, vote, c.AssetID)
+			assert.Equal(t, s.payee.system.MemberIDs, []string(c.Opponents))
+			assert.Equal(t, s.payee.system.Threshold, c.Threshold)
+
+			threshold, batch, _ := c.Consolidation()
+			assert.EqualValues(t, 3, threshold)
+			assert.EqualValues(t, 10, batch)
+		}
+	}
+
+	// out of range
+	require.Nil(t, s.properties.Save(s.ctx, core.ConsolidationBatchKey, core.MaxConsolidationBatch+1))
+	// idle for 5 minutes, the outputs are sent a minute apart
+	require.Nil(t, s.properties.Save(s.ctx, core.ConsolidationIdleKey, "5m"))
+	for i := 0; i < 5; i++ {
+		s.send(alice, vote, amount, core.ActionTypeSubscribe)
+	}
+	assert.Len(t, s.consolidations(), 2)
+
+	s.now = s.now.Add(5 * time.Minute)
+	output := s.send(alice, vote, amount, core.ActionTypeSubscribe)
+	consolidations = s.consolidations()
+	if assert.Len(t, consolidations, 3) {
+		c := consolidations[2]
+		assert.Equal(t, output.ID, c.Version)
+
+		_, batch, _ := c.Consolidation()
+		assert.EqualValues(t, core.DefaultConsolidationBatch, batch)
+	}
+
+	// not supported by the sysversion
+	s.payee.sysversion = 5
+	for i := 0; i < 3; i++ {
+		s.send(alice, vote, amount, core.ActionTypeSubscribe)
+	}
+	assert.Len(t, s.consolidations(), 3)
+}
@@ -0,0 +1,345 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/pkg/compound"
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/shopspring/decimal"
+)
+
+// isolationChange the collateral & borrow changes of the account to check
+type isolationChange struct {
+	// market of the pledged (positive) or unpledged (negative) ctokens
+	Collateral *core.Market
+	CTokens    decimal.Decimal
+	// market of the new borrow (positive) or the repayment (negative)
+	Borrow *core.Market
+	Amount decimal.Decimal
+}
+
+// isolationAllowed check the account after the change
+//
+// 	the isolated collaterals only back the borrows allowlisted by them, so the borrows not allowlisted by
+// 	any isolated collateral of the account must be backed by the cross collaterals:
+// 	cross_liquidity = cross_collateral_values - not_allowlisted_borrow_values >= 0
+//
+// 	the total borrow values of the accounts backed by the isolated collateral can't exceed its debt ceiling:
+// 	market.isolated_debt + new_borrow_value <= debt_ceiling
+func (w *Payee) isolationAllowed(ctx context.Context, userID string, change isolationChange) (bool, error) {
+	markets, err := w.isolationMarkets(ctx, change.Collateral, change.Borrow)
+	if err != nil {
+		return false, err
+	}
+
+	supplies, err := w.supplyStore.FindByUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	collaterals := make(map[string]decimal.Decimal, len(supplies)+1)
+	for _, supply := range supplies {
+		collaterals[supply.CTokenAssetID] = supply.Collaterals
+	}
+
+	if m := change.Collateral; m != nil {
+		collaterals[m.CTokenAssetID] = collaterals[m.CTokenAssetID].Add(change.CTokens)
+	}
+
+	var (
+		crossValue = decimal.Zero
+		isolated   []*core.Market
+	)
+
+	for ctokenAssetID, amount := range collaterals {
+		if !amount.IsPositive() {
+			continue
+		}
+
+		market, ok := markets.byCToken[ctokenAssetID]
+		if !ok {
+			return false, errors.New("no market")
+		}
+
+		if market.IsIsolated() {
+			isolated = append(isolated, market)
+			continue
+		}
+
+		crossValue = crossValue.Add(amount.Mul(market.ExchangeRate).Mul(market.CollateralFactor).Mul(market.CollateralPrice()))
+	}
+
+	if len(isolated) == 0 {
+		return true, nil
+	}
+
+	balances, err := w.borrowBalances(ctx, userID, markets)
+	if err != nil {
+		return false, err
+	}
+
+	if m := change.Borrow; m != nil {
+		balances[m.AssetID] = balances[m.AssetID].Add(change.Amount)
+	}
+
+	for assetID, balance := range balances {
+		if !balance.IsPositive() || isolatedBorrowAllowed(isolated, assetID) {
+			continue
+		}
+
+		crossValue = crossValue.Sub(balance.Mul(markets.byAsset[assetID].Price))
+	}
+
+	if crossValue.IsNegative() {
+		return false, nil
+	}
+
+	if change.Borrow == nil || !change.Amount.IsPositive() {
+		return true, nil
+	}
+
+	borrowValue := change.Amount.Mul(change.Borrow.Price)
+	for _, market := range isolated {
+		if !market.IsolatedBorrowAllowed(change.Borrow.AssetID) {
+			continue
+		}
+
+		if market.IsolatedDebt.Add(borrowValue).GreaterThan(market.DebtCeiling) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// isolatedDebtChanges the changes of the isolated debts made by the change of the account, by ctoken asset id
+//
+// 	the isolated debt is the allowlisted borrow values of the accounts pledging the isolated collateral,
+// 	the debts of the account before & after the change are valued by the current prices, the difference
+// 	is added to the running total kept by the market
+func (w *Payee) isolatedDebtChanges(ctx context.Context, userID string, change isolationChange) (map[string]decimal.Decimal, error) {
+	markets, err := w.isolationMarkets(ctx, change.Collateral, change.Borrow)
+	if err != nil {
+		return nil, err
+	}
+
+	supplies, err := w.supplyStore.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := make(map[string]decimal.Decimal, len(supplies)+1)
+	for _, supply := range supplies {
+		before[supply.CTokenAssetID] = supply.Collaterals
+	}
+
+	after := make(map[string]decimal.Decimal, len(before)+1)
+	for ctokenAssetID, amount := range before {
+		after[ctokenAssetID] = amount
+	}
+
+	if m := change.Collateral; m != nil {
+		after[m.CTokenAssetID] = after[m.CTokenAssetID].Add(change.CTokens)
+	}
+
+	var isolated []*core.Market
+	for ctokenAssetID, amount := range after {
+		market, ok := markets.byCToken[ctokenAssetID]
+		if ok && market.IsIsolated() && (amount.IsPositive() || before[ctokenAssetID].IsPositive()) {
+			isolated = append(isolated, market)
+		}
+	}
+
+	if len(isolated) == 0 {
+		return nil, nil
+	}
+
+	balances, err := w.borrowBalances(ctx, userID, markets)
+	if err != nil {
+		return nil, err
+	}
+
+	newBalances := make(map[string]decimal.Decimal, len(balances)+1)
+	for assetID, balance := range balances {
+		newBalances[assetID] = balance
+	}
+
+	if m := change.Borrow; m != nil {
+		newBalances[m.AssetID] = decimal.Max(newBalances[m.AssetID].Add(change.Amount), decimal.Zero)
+	}
+
+	debt := func(market *core.Market, collaterals, balances map[string]decimal.Decimal) decimal.Decimal {
+		value := decimal.Zero
+		if !collaterals[market.CTokenAssetID].IsPositive() {
+			return value
+		}
+
+		for assetID, balance := range balances {
+			if balance.IsPositive() && market.IsolatedBorrowAllowed(assetID) {
+				value = value.Add(balance.Mul(markets.byAsset[assetID].Price))
+			}
+		}
+
+		return value
+	}
+
+	changes := make(map[string]decimal.Decimal, len(isolated))
+	for _, market := range isolated {
+		diff := debt(market, after, newBalances).Sub(debt(market, before, balances)).Truncate(compound.MaxPricision)
+		if !diff.IsZero() {
+			changes[market.CTokenAssetID] = diff
+		}
+	}
+
+	return changes, nil
+}
+
+// applyIsolatedDebts add the isolated debt changes recorded by the transaction to the markets,
+// the markets updated later by the handler are changed in place, the others are updated here
+func (w *Payee) applyIsolatedDebts(ctx context.Context, tx *core.Transaction, output *core.Output, updating ...*core.Market) error {
+	log := logger.FromContext(ctx)
+
+	var extra struct {
+		IsolatedDebts map[string]decimal.Decimal `json:"isolated_debts"`
+	}
+	if err := tx.UnmarshalExtraData(&extra); err != nil {
+		log.WithError(err).Errorln("Unmarshal extra")
+		return err
+	}
+
+	ctokenAssetIDs := make([]string, 0, len(extra.IsolatedDebts))
+	for ctokenAssetID := range extra.IsolatedDebts {
+		ctokenAssetIDs = append(ctokenAssetIDs, ctokenAssetID)
+	}
+	sort.Strings(ctokenAssetIDs)
+
+	for _, ctokenAssetID := range ctokenAssetIDs {
+		change := extra.IsolatedDebts[ctokenAssetID]
+
+		var market *core.Market
+		for _, m := range updating {
+			if m.CTokenAssetID == ctokenAssetID {
+				market = m
+			}
+		}
+
+		if market != nil {
+			if output.ID > market.Version {
+				market.IsolatedDebt = decimal.Max(market.IsolatedDebt.Add(change), decimal.Zero)
+			}
+
+			continue
+		}
+
+		market, err := w.marketStore.FindByCToken(ctx, ctokenAssetID)
+		if err != nil {
+			log.WithError(err).Errorln("markets.FindByCToken")
+			return err
+		}
+
+		if market.ID == 0 || output.ID <= market.Version {
+			continue
+		}
+
+		AccrueInterest(ctx, market, output.CreatedAt)
+		market.IsolatedDebt = decimal.Max(market.IsolatedDebt.Add(change), decimal.Zero)
+		if err := w.updateMarket(ctx, market, output); err != nil {
+			log.WithError(err).Errorln("markets.Update")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isolatedDebt count the total borrow values backed by the isolated collateral,
+// that is the allowlisted borrows of the accounts pledging it, only when the isolation is set by proposal
+func (w *Payee) isolatedDebt(ctx context.Context, isolated *core.Market, markets *isolationMarkets) (decimal.Decimal, error) {
+	supplies, err := w.supplyStore.FindByCTokenAssetID(ctx, isolated.CTokenAssetID)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	debt := decimal.Zero
+	for _, supply := range supplies {
+		if !supply.Collaterals.IsPositive() {
+			continue
+		}
+
+		balances, err := w.borrowBalances(ctx, supply.UserID, markets)
+		if err != nil {
+			return decimal.Zero, err
+		}
+
+		for assetID, balance := range balances {
+			if !balance.IsPositive() || !isolated.IsolatedBorrowAllowed(assetID) {
+				continue
+			}
+
+			debt = debt.Add(balance.Mul(markets.byAsset[assetID].Price))
+		}
+	}
+
+	return debt.Truncate(compound.MaxPricision), nil
+}
+
+func (w *Payee) borrowBalances(ctx context.Context, userID string, markets *isolationMarkets) (map[string]decimal.Decimal, error) {
+	borrows, err := w.borrowStore.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]decimal.Decimal, len(borrows)+1)
+	for _, borrow := range borrows {
+		market, ok := markets.byAsset[borrow.AssetID]
+		if !ok {
+			return nil, errors.New("no market")
+		}
+
+		balances[borrow.AssetID] = compound.BorrowBalance(ctx, borrow, market)
+	}
+
+	return balances, nil
+}
+
+type isolationMarkets struct {
+	byAsset  map[string]*core.Market
+	byCToken map[string]*core.Market
+}
+
+// isolationMarkets load all the markets, prefer the accrued ones in memory
+func (w *Payee) isolationMarkets(ctx context.Context, accrued ...*core.Market) (*isolationMarkets, error) {
+	all, err := w.marketStore.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	markets := &isolationMarkets{
+		byAsset:  make(map[string]*core.Market, len(all)),
+		byCToken: make(map[string]*core.Market, len(all)),
+	}
+
+	for _, m := range append(all, accrued...) {
+		if m == nil {
+			continue
+		}
+
+		markets.byAsset[m.AssetID] = m
+		markets.byCToken[m.CTokenAssetID] = m
+	}
+
+	return markets, nil
+}
+
+func isolatedBorrowAllowed(isolated []*core.Market, assetID string) bool {
+	for _, m := range isolated {
+		if m.IsolatedBorrowAllowed(assetID) {
+			return true
+		}
+	}
+
+	return false
+}
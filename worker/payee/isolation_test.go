@@ -0,0 +1,138 @@
+package payee
+
+import (
+	"compound/core"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type isolationScenario struct {
+	*scenario
+	btc, gov, eth, usdt *core.Market
+	alice               string
+}
+
+// newIsolationScenario alice pledges 1 btc (22500 liquidity) and 10000 gov isolated to usdt (50000 liquidity),
+// then borrows 5 eth (10000), which only the btc can back
+func newIsolationScenario(t *testing.T) *isolationScenario {
+	s := &isolationScenario{scenario: newScenario(t)}
+	s.btc = s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	s.gov = s.market("GOV", decimal.NewFromInt(10), decimal.NewFromFloat(0.5))
+	s.eth = s.market("ETH", decimal.NewFromInt(2000), decimal.NewFromFloat(0.5))
+	s.usdt = s.market("USDT", decimal.NewFromInt(1), decimal.Zero)
+
+	gov := s.findMarket(s.gov.AssetID)
+	gov.CollateralMode = core.CollateralModeIsolated
+	gov.IsolatedBorrowAssets = []string{s.usdt.AssetID}
+	gov.DebtCeiling = decimal.NewFromInt(1000000)
+	s.outputID++
+	require.Nil(t, s.markets.Update(s.ctx, gov, s.outputID))
+
+	bob := newUserID()
+	s.send(bob, s.eth.AssetID, decimal.NewFromInt(100), core.ActionTypeSupply)
+	s.send(bob, s.btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+
+	s.alice = newUserID()
+	s.send(s.alice, s.btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	s.send(s.alice, s.btc.CTokenAssetID, decimal.NewFromInt(1), core.ActionTypePledge)
+	s.send(s.alice, s.gov.AssetID, decimal.NewFromInt(10000), core.ActionTypeSupply)
+	s.send(s.alice, s.gov.CTokenAssetID, decimal.NewFromInt(10000), core.ActionTypePledge)
+	s.send(s.alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(s.eth.AssetID), decimal.NewFromInt(5))
+
+	borrow, err := s.borrows.Find(s.ctx, s.alice, s.eth.AssetID)
+	require.Nil(t, err)
+	require.True(t, borrow.Principal.Equal(decimal.NewFromInt(5)), borrow.Principal.String())
+	require.Len(t, s.transfers(s.alice), 3)
+
+	return s
+}
+
+// requireIsolationRefund the output is refunded by the isolation check, the positions are unchanged
+func (s *isolationScenario) requireIsolationRefund(output *core.Output) {
+	transfers := s.transfers(s.alice)
+	if assert.Len(s.t, transfers, 4) {
+		refund := transfers[3]
+		assert.Equal(s.t, output.AssetID, refund.AssetID)
+		assert.True(s.t, refund.Amount.Equal(output.Amount), refund.Amount.String())
+
+		action := s.transferAction(refund)
+		assert.Equal(s.t, core.ActionTypeRefundTransfer, action.Source)
+		assert.Equal(s.t, "payee/isolation-denied", action.Message)
+	}
+
+	supply, err := s.supplies.Find(s.ctx, s.alice, s.btc.CTokenAssetID)
+	require.Nil(s.t, err)
+	assert.True(s.t, supply.Collaterals.Equal(decimal.NewFromInt(1)), supply.Collaterals.String())
+
+	borrow, err := s.borrows.Find(s.ctx, s.alice, s.eth.AssetID)
+	require.Nil(s.t, err)
+	assert.True(s.t, borrow.Principal.Equal(decimal.NewFromInt(5)), borrow.Principal.String())
+}
+
+func TestScenarioQuickRedeemIsolation(t *testing.T) {
+	s := newIsolationScenario(t)
+
+	// 0.8 btc is within the liquidity 62500, but the 0.2 btc left can't back the eth borrow
+	output := s.send(s.alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeQuickRedeem, uuid.FromStringOrNil(s.btc.CTokenAssetID), decimal.NewFromFloat(0.8))
+	s.requireIsolationRefund(output)
+
+	// 0.5 btc left backs it
+	s.send(s.alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeQuickRedeem, uuid.FromStringOrNil(s.btc.CTokenAssetID), decimal.NewFromFloat(0.5))
+	supply, err := s.supplies.Find(s.ctx, s.alice, s.btc.CTokenAssetID)
+	require.Nil(t, err)
+	assert.True(t, supply.Collaterals.Equal(decimal.NewFromFloat(0.5)), supply.Collaterals.String())
+}
+
+// the isolated debt is kept by the borrows & collaterals changes of the accounts pledging gov
+func TestScenarioIsolatedDebt(t *testing.T) {
+	s := newIsolationScenario(t)
+	s.send(newUserID(), s.usdt.AssetID, decimal.NewFromInt(100000), core.ActionTypeSupply)
+
+	assertDebt := func(step string, expect int64) {
+		debt := s.findMarket(s.gov.AssetID).IsolatedDebt
+		assert.True(t, debt.Equal(decimal.NewFromInt(expect)), "%s: isolated debt %s", step, debt)
+	}
+
+	// the eth borrow isn't allowlisted by gov
+	assertDebt("eth borrowed", 0)
+
+	s.send(s.alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(s.usdt.AssetID), decimal.NewFromInt(10000))
+	assertDebt("usdt borrowed", 10000)
+
+	s.send(s.alice, s.usdt.AssetID, decimal.NewFromInt(4000), core.ActionTypeRepay)
+	assertDebt("usdt repaid", 6000)
+
+	gov := s.findMarket(s.gov.AssetID)
+	gov.DebtCeiling = decimal.NewFromInt(7000)
+	s.outputID++
+	require.Nil(t, s.markets.Update(s.ctx, gov, s.outputID))
+
+	output := s.send(s.alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(s.usdt.AssetID), decimal.NewFromInt(2000))
+	transfers := s.transfers(s.alice)
+	if assert.Len(t, transfers, 5) {
+		refund := transfers[4]
+		assert.True(t, refund.Amount.Equal(output.Amount), refund.Amount.String())
+		assert.Equal(t, "payee/isolation-denied", s.transferAction(refund).Message)
+	}
+	assertDebt("ceiling exceeded", 6000)
+
+	s.send(s.alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(s.usdt.AssetID), decimal.NewFromInt(1000))
+	assertDebt("ceiling reached", 7000)
+
+	// the btc backs all the borrows, gov isn't pledged anymore
+	s.send(s.alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeUnpledge, uuid.FromStringOrNil(s.gov.CTokenAssetID), decimal.NewFromInt(10000))
+	supply, err := s.supplies.Find(s.ctx, s.alice, s.gov.CTokenAssetID)
+	require.Nil(t, err)
+	require.True(t, supply.Collaterals.IsZero(), supply.Collaterals.String())
+	assertDebt("gov unpledged", 0)
+
+	// pledged again, the borrows are counted with the interest accrued
+	s.send(s.alice, s.gov.CTokenAssetID, decimal.NewFromInt(5000), core.ActionTypePledge)
+	debt := s.findMarket(s.gov.AssetID).IsolatedDebt
+	assert.True(t, debt.GreaterThan(decimal.NewFromInt(7000)), debt.String())
+	assert.True(t, debt.LessThan(decimal.NewFromInt(7001)), debt.String())
+}
@@ -85,6 +85,10 @@ func (w *Payee) handleLiquidationEvent(ctx context.Context, output *core.Output,
 			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeLiquidate, core.ErrMarketClosed)
 		}
 
+		if err := w.requireFreshPrices(ctx, seizedUserID, output.CreatedAt, supplyMarket, borrowMarket); err != nil {
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeLiquidate, core.ErrPriceStale)
+		}
+
 		liquidity, err := w.accountService.CalculateAccountLiquidity(ctx, seizedUserID, borrowMarket, supplyMarket)
 		if err != nil {
 			log.WithError(err).Errorln("accountz.CalculateAccountLiquidity")
@@ -127,7 +131,19 @@ func (w *Payee) handleLiquidationEvent(ctx context.Context, output *core.Output,
 			repayAmount = borrowBalance
 		}
 
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, seizedUserID, isolationChange{
+			Collateral: supplyMarket,
+			CTokens:    seizedCTokens.Neg(),
+			Borrow:     borrowMarket,
+			Amount:     repayAmount.Neg(),
+		})
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
 		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
 		extra.Put("ctoken_asset_id", seizedCTokenAssetID)
 		extra.Put("amount", seizedCTokens)
 		extra.Put("repay_amount", repayAmount)
@@ -222,11 +238,15 @@ func (w *Payee) handleLiquidationEvent(ctx context.Context, output *core.Output,
 		}
 	}
 
+	if err := w.applyIsolatedDebts(ctx, tx, output, supplyMarket, borrowMarket); err != nil {
+		return err
+	}
+
 	//update supply market ctokens
 	if output.ID > supplyMarket.Version {
 		//supply market accrue interest
 		AccrueInterest(ctx, supplyMarket, output.CreatedAt)
-		if err := w.marketStore.Update(ctx, supplyMarket, output.ID); err != nil {
+		if err := w.updateMarket(ctx, supplyMarket, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
@@ -243,7 +263,7 @@ func (w *Payee) handleLiquidationEvent(ctx context.Context, output *core.Output,
 		}
 		//borrow market accrue interest
 		AccrueInterest(ctx, borrowMarket, output.CreatedAt)
-		if err := w.marketStore.Update(ctx, borrowMarket, output.ID); err != nil {
+		if err := w.updateMarket(ctx, borrowMarket, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
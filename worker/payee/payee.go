@@ -2,7 +2,9 @@ package payee
 
 import (
 	"compound/core"
+	"compound/metric"
 	"compound/pkg/compound"
+	"compound/worker"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -36,6 +38,10 @@ type (
 		proposalStore     core.ProposalStore
 		transactionStore  core.TransactionStore
 		oracleSignerStore core.OracleSignerStore
+		reserveStore      core.ReserveStore
+		priceTickStore    core.PriceTickStore
+		accumulatorStore  core.PriceAccumulatorStore
+		subscriberStore   core.SubscriberStore
 		walletz           core.WalletService
 		proposalService   core.ProposalService
 		accountService    core.IAccountService
@@ -57,6 +63,10 @@ func NewPayee(
 	proposalStore core.ProposalStore,
 	transactionStore core.TransactionStore,
 	oracleSignerStr core.OracleSignerStore,
+	reserveStore core.ReserveStore,
+	priceTickStore core.PriceTickStore,
+	accumulatorStore core.PriceAccumulatorStore,
+	subscriberStore core.SubscriberStore,
 	walletz core.WalletService,
 	proposalService core.ProposalService,
 	accountService core.IAccountService,
@@ -74,6 +84,10 @@ func NewPayee(
 		proposalStore:     proposalStore,
 		transactionStore:  transactionStore,
 		oracleSignerStore: oracleSignerStr,
+		reserveStore:      reserveStore,
+		priceTickStore:    priceTickStore,
+		accumulatorStore:  accumulatorStore,
+		subscriberStore:   subscriberStore,
 		walletz:           walletz,
 		proposalService:   proposalService,
 		accountService:    accountService,
@@ -93,7 +107,7 @@ func (w *Payee) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(dur):
-			if err := w.run(ctx); err == nil {
+			if err := metric.ObserveWorker("payee", func() error { return w.run(ctx) }); err == nil {
 				dur = 100 * time.Millisecond
 			} else {
 				dur = 500 * time.Millisecond
@@ -122,7 +136,7 @@ func (w *Payee) run(ctx context.Context) error {
 	}
 
 	if len(outputs) <= 0 {
-		return errors.New("no more outputs")
+		return worker.ErrIdle
 	}
 
 	for _, output := range outputs {
@@ -149,10 +163,19 @@ func (w *Payee) handleOutput(ctx context.Context, output *core.Output) error {
 	})
 	ctx = logger.WithContext(ctx, log)
 
+	if err := w.expireProposals(ctx, output); err != nil {
+		return err
+	}
+
+	if err := w.recordConsolidation(ctx, output); err != nil {
+		return err
+	}
+
 	// handle price provided by dirtoracle
 	{
 		var e compound.Error
 		if err := w.handlePriceEvent(ctx, output); err == nil {
+			metric.ObservePayeeOutput("Price", "")
 			return nil
 		} else if !errors.As(err, &e) {
 			return err
@@ -164,6 +187,7 @@ func (w *Payee) handleOutput(ctx context.Context, output *core.Output) error {
 	}
 
 	var (
+		action   core.ActionType
 		followID string
 		err      error
 	)
@@ -175,14 +199,20 @@ func (w *Payee) handleOutput(ctx context.Context, output *core.Output) error {
 		err = w.handleOutputV2(ctx, output)
 
 	default:
-		followID, err = w.handleOutputV3(ctx, output)
+		action, followID, err = w.handleOutputV3(ctx, output)
 	}
 
 	var e compound.Error
 	if !errors.As(err, &e) {
+		if err == nil {
+			metric.ObservePayeeOutput(action.String(), "")
+		}
+
 		return err
 	}
 
+	metric.ObservePayeeOutput(action.String(), e.Msg)
+
 	if compound.ShouldRefund(e.Flag) {
 		memo, err := json.Marshal(core.TransferAction{
 			Source:   core.ActionTypeRefundTransfer,
@@ -206,6 +236,8 @@ func (w *Payee) handleOutput(ctx context.Context, output *core.Output) error {
 			log.WithError(err).Errorln("wallets.CreateTransfers")
 			return err
 		}
+
+		metric.ObservePayeeRefund(action.String())
 	}
 	return nil
 }
@@ -12,10 +12,11 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (w *Payee) handleOutputV3(ctx context.Context, output *core.Output) (string, error) {
+// handleOutputV3 handle the output, returns the action & the follow id decoded from the memo
+func (w *Payee) handleOutputV3(ctx context.Context, output *core.Output) (core.ActionType, string, error) {
 	log := logger.FromContext(ctx)
 	if strings.ToLower(output.Memo) == "deposit" {
-		return "", nil
+		return core.ActionTypeDefault, "", nil
 	}
 
 	var (
@@ -39,16 +40,20 @@ func (w *Payee) handleOutputV3(ctx context.Context, output *core.Output) (string
 
 	switch action {
 	case core.ActionTypeProposalMake:
-		return followID, w.handleMakeProposal(ctx, output, message)
+		return action, followID, w.handleMakeProposal(ctx, output, message)
 	case core.ActionTypeProposalShout:
-		return followID, w.handleShoutProposal(ctx, output, message)
+		return action, followID, w.handleShoutProposal(ctx, output, message)
 	case core.ActionTypeProposalVote:
-		return followID, w.handleVoteProposal(ctx, output, message)
+		return action, followID, w.handleVoteProposal(ctx, output, message)
+	case core.ActionTypeProposalExecute:
+		return action, followID, w.handleExecuteProposal(ctx, output, message)
+	case core.ActionTypeProposalReject:
+		return action, followID, w.handleRejectProposal(ctx, output, message)
 	default:
 		user, err := w.userStore.Find(ctx, output.Sender)
 		if err != nil {
 			log.WithError(err).Errorln("users.Find")
-			return "", err
+			return action, "", err
 		}
 
 		if user.ID == 0 {
@@ -59,11 +64,11 @@ func (w *Payee) handleOutputV3(ctx context.Context, output *core.Output) (string
 				AddressV0: core.BuildUserAddressV0(output.Sender),
 			}
 			if err = w.userStore.Create(ctx, user); err != nil {
-				return "", err
+				return action, "", err
 			}
 		}
 
 		// handle user action
-		return followID, w.handleUserAction(ctx, output, action, followID, message)
+		return action, followID, w.handleUserAction(ctx, output, action, followID, message)
 	}
 }
@@ -5,10 +5,13 @@ import (
 	"compound/pkg/compound"
 	"context"
 	"encoding/base64"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/fox-one/pkg/logger"
 	"github.com/pandodao/blst"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
@@ -40,7 +43,6 @@ func (w *Payee) handlePriceEvent(ctx context.Context, output *core.Output) error
 		return nil
 	}
 
-	market.Price = priceData.Price
 	if w.sysversion < 3 {
 		market.PriceUpdatedAt = output.CreatedAt
 	} else {
@@ -51,36 +53,12 @@ func (w *Payee) handlePriceEvent(ctx context.Context, output *core.Output) error
 		market.PriceUpdatedAt = priceTime
 	}
 
-	ss, err := w.oracleSignerStore.FindAll(ctx)
+	signers, err := w.oracleSigners(ctx)
 	if err != nil {
 		log.WithError(err).Errorln("oracles.FindAll")
 		return err
 	}
 
-	signers := make([]*core.Signer, len(ss))
-	for idx, s := range ss {
-		bts, err := base64.StdEncoding.DecodeString(s.PublicKey)
-		if e := compound.Require(
-			err == nil,
-			"payee/invalid-oracle-signer",
-		); e != nil {
-			return e
-		}
-
-		pub := blst.PublicKey{}
-		if e := compound.Require(
-			pub.FromBytes(bts) == nil,
-			"payee/invalid-oracle-signer",
-		); e != nil {
-			return e
-		}
-
-		signers[idx] = &core.Signer{
-			Index:     uint64(idx) + 1,
-			VerifyKey: &pub,
-		}
-	}
-
 	if err := compound.Require(
 		verifyPriceData(&priceData, signers, market.PriceThreshold),
 		"payee/oracle-verify-failed",
@@ -89,8 +67,45 @@ func (w *Payee) handlePriceEvent(ctx context.Context, output *core.Output) error
 		return err
 	}
 
+	if err := w.oracleSignerStore.Participate(ctx, output.ID, signedIndexes(&priceData, signers), output.CreatedAt); err != nil {
+		log.WithError(err).Errorln("oracles.Participate")
+		return err
+	}
+
+	tick := &core.PriceTick{
+		AssetID:   market.AssetID,
+		Version:   output.ID,
+		TraceID:   output.TraceID,
+		Price:     priceData.Price,
+		Status:    core.PriceTickStatusAccepted,
+		PricedAt:  priceTime,
+		CreatedAt: output.CreatedAt,
+	}
+
+	price, err := w.aggregatePrice(ctx, market, tick)
+	if err != nil {
+		log.WithError(err).Errorln("aggregatePrice")
+		return err
+	}
+
+	if err := w.priceTickStore.Create(ctx, tick); err != nil {
+		log.WithError(err).Errorln("prices.Create")
+		return err
+	}
+
+	if tick.Status == core.PriceTickStatusRejected {
+		log.Infoln("skip: price rejected,", tick.Reason)
+		return nil
+	}
+
+	market.Price = price
+	if err := w.accumulatePrice(ctx, market, output); err != nil {
+		log.WithError(err).Errorln("accumulatePrice")
+		return err
+	}
+
 	AccrueInterest(ctx, market, output.CreatedAt)
-	if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+	if err := w.updateMarket(ctx, market, output); err != nil {
 		log.WithError(err).Errorln("update market price err")
 		return err
 	}
@@ -99,6 +114,117 @@ func (w *Payee) handlePriceEvent(ctx context.Context, output *core.Output) error
 	return nil
 }
 
+// aggregatePrice the median of the latest accepted prices in the window with the new one
+//
+// 	the new price is rejected if it deviates from the median of the window too much,
+// 	the window gets empty if no price is accepted during it, then any price is accepted
+func (w *Payee) aggregatePrice(ctx context.Context, market *core.Market, tick *core.PriceTick) (decimal.Decimal, error) {
+	if !market.PriceGuardEnabled() {
+		return tick.Price, nil
+	}
+
+	samples := market.PriceSamples
+	if samples < 1 {
+		samples = 1
+	}
+
+	ticks, err := w.priceTickStore.ListAccepted(ctx, market.AssetID, tick.Version, samples)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	window := time.Duration(market.PriceWindow) * time.Second
+	prices := make([]decimal.Decimal, 0, len(ticks)+1)
+	for _, t := range ticks {
+		if window > 0 && tick.PricedAt.Sub(t.PricedAt) > window {
+			continue
+		}
+
+		prices = append(prices, t.Price)
+	}
+
+	if len(prices) > 0 {
+		tick.Median = compound.Median(prices)
+		if max := market.MaxPriceDeviation; max.IsPositive() {
+			if deviation := compound.Deviation(tick.Price, tick.Median); deviation.GreaterThan(max) {
+				tick.Status = core.PriceTickStatusRejected
+				tick.Reason = fmt.Sprintf("deviation %s exceeds %s", deviation.StringFixed(4), max.String())
+				return tick.Median, nil
+			}
+		}
+	}
+
+	// the new price takes the place of the oldest sample
+	if len(prices) >= samples {
+		prices = prices[:samples-1]
+	}
+
+	return compound.Median(append(prices, tick.Price)), nil
+}
+
+// oracleSigners the signers indexed by the persistent signer indexes, the signers with invalid keys
+// are skipped and can't sign any price. Before sysversion 6 the signers are indexed by the positions
+// ordered by id, and an invalid key fails the price
+func (w *Payee) oracleSigners(ctx context.Context) ([]*core.Signer, error) {
+	log := logger.FromContext(ctx)
+
+	ss, err := w.oracleSignerStore.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.sysversion < 6 {
+		sort.Slice(ss, func(i, j int) bool {
+			return ss[i].ID < ss[j].ID
+		})
+
+		for idx, s := range ss {
+			s.SignerIndex = int64(idx + 1)
+		}
+	}
+
+	signers := make([]*core.Signer, 0, len(ss))
+	for _, s := range ss {
+		bts, err := base64.StdEncoding.DecodeString(s.PublicKey)
+		if err != nil {
+			if w.sysversion < 6 {
+				return nil, compound.Require(false, "payee/invalid-oracle-signer")
+			}
+
+			log.WithError(err).Warnln("skip: invalid oracle signer", s.UserID)
+			continue
+		}
+
+		pub := blst.PublicKey{}
+		if err := pub.FromBytes(bts); err != nil {
+			if w.sysversion < 6 {
+				return nil, compound.Require(false, "payee/invalid-oracle-signer")
+			}
+
+			log.WithError(err).Warnln("skip: invalid oracle signer", s.UserID)
+			continue
+		}
+
+		signers = append(signers, &core.Signer{
+			Index:     uint64(s.SignerIndex),
+			VerifyKey: &pub,
+		})
+	}
+
+	return signers, nil
+}
+
+func signedIndexes(p *core.PriceData, signers []*core.Signer) []int64 {
+	var indexes []int64
+	for _, signer := range signers {
+		if p.Signature.Mask&(0x1<<signer.Index) != 0 {
+			indexes = append(indexes, int64(signer.Index))
+		}
+	}
+
+	return indexes
+}
+
 func verifyPriceData(p *core.PriceData, signers []*core.Signer, threshold int) bool {
 	var pubs []*blst.PublicKey
 	for _, signer := range signers {
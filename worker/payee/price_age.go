@@ -0,0 +1,67 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/pkg/compound"
+	"context"
+	"time"
+
+	"github.com/fox-one/pkg/logger"
+)
+
+// requireFreshPrices require the prices of the markets the liquidity of the account depends on are not stale,
+// that are the markets of the collaterals & borrows of the account and the markets of the action
+func (w *Payee) requireFreshPrices(ctx context.Context, userID string, at time.Time, markets ...*core.Market) error {
+	log := logger.FromContext(ctx)
+
+	for _, market := range markets {
+		if err := compound.Require(!market.PriceStale(at), "payee/price-stale", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("failure: price stale", market.AssetID, market.PriceUpdatedAt)
+			return err
+		}
+	}
+
+	all, err := w.isolationMarkets(ctx, markets...)
+	if err != nil {
+		log.WithError(err).Errorln("markets.All")
+		return err
+	}
+
+	supplies, err := w.supplyStore.FindByUser(ctx, userID)
+	if err != nil {
+		log.WithError(err).Errorln("supplies.FindByUser")
+		return err
+	}
+
+	for _, supply := range supplies {
+		market, ok := all.byCToken[supply.CTokenAssetID]
+		if !ok || !supply.Collaterals.IsPositive() {
+			continue
+		}
+
+		if err := compound.Require(!market.PriceStale(at), "payee/price-stale", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("failure: collateral price stale", market.AssetID, market.PriceUpdatedAt)
+			return err
+		}
+	}
+
+	borrows, err := w.borrowStore.FindByUser(ctx, userID)
+	if err != nil {
+		log.WithError(err).Errorln("borrows.FindByUser")
+		return err
+	}
+
+	for _, borrow := range borrows {
+		market, ok := all.byAsset[borrow.AssetID]
+		if !ok || !borrow.Principal.IsPositive() {
+			continue
+		}
+
+		if err := compound.Require(!market.PriceStale(at), "payee/price-stale", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("failure: borrow price stale", market.AssetID, market.PriceUpdatedAt)
+			return err
+		}
+	}
+
+	return nil
+}
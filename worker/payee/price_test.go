@@ -0,0 +1,67 @@
+package payee
+
+import (
+	"compound/core"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregatePrice(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	btc.PriceSamples = 3
+	btc.PriceWindow = 600
+	btc.MaxPriceDeviation = decimal.NewFromFloat(0.1)
+
+	var version int64
+	feed := func(price int64, at time.Time) (*core.PriceTick, decimal.Decimal) {
+		version++
+		tick := &core.PriceTick{
+			AssetID:  btc.AssetID,
+			Version:  version,
+			Price:    decimal.NewFromInt(price),
+			Status:   core.PriceTickStatusAccepted,
+			PricedAt: at,
+		}
+
+		median, err := s.payee.aggregatePrice(s.ctx, btc, tick)
+		require.Nil(t, err)
+		require.Nil(t, s.payee.priceTickStore.Create(s.ctx, tick))
+		return tick, median
+	}
+
+	now := s.now
+	_, median := feed(30000, now)
+	assert.True(t, median.Equal(decimal.NewFromInt(30000)), median.String())
+
+	_, median = feed(31000, now.Add(time.Minute))
+	assert.True(t, median.Equal(decimal.NewFromInt(30500)), median.String())
+
+	_, median = feed(32000, now.Add(2*time.Minute))
+	assert.True(t, median.Equal(decimal.NewFromInt(31000)), median.String())
+
+	// deviates from the median 31000 more than 10%
+	tick, _ := feed(40000, now.Add(3*time.Minute))
+	assert.Equal(t, core.PriceTickStatusRejected, tick.Status)
+	assert.True(t, tick.Median.Equal(decimal.NewFromInt(31000)), tick.Median.String())
+	assert.NotEmpty(t, tick.Reason)
+
+	// only the latest 3 samples, the oldest 30000 is dropped
+	_, median = feed(33000, now.Add(4*time.Minute))
+	assert.True(t, median.Equal(decimal.NewFromInt(32000)), median.String())
+
+	// the window gets empty after 10 minutes
+	tick, median = feed(40000, now.Add(20*time.Minute))
+	assert.Equal(t, core.PriceTickStatusAccepted, tick.Status)
+	assert.True(t, median.Equal(decimal.NewFromInt(40000)), median.String())
+
+	// take the price as it is without the guard
+	btc.PriceSamples, btc.MaxPriceDeviation = 1, decimal.Zero
+	tick, median = feed(10000, now.Add(21*time.Minute))
+	assert.Equal(t, core.PriceTickStatusAccepted, tick.Status)
+	assert.True(t, median.Equal(decimal.NewFromInt(10000)), median.String())
+}
@@ -10,10 +10,13 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fox-one/pkg/logger"
 	"github.com/gofrs/uuid"
 	"github.com/pandodao/blst"
+	"github.com/shopspring/decimal"
 )
 
 func (w *Payee) handleShoutProposal(ctx context.Context, output *core.Output, message []byte) error {
@@ -73,6 +76,14 @@ func (w *Payee) validateProposal(ctx context.Context, p *core.Proposal) error {
 			return w.validateNewSysVersion(ctx, ver)
 		}
 
+		if strings.HasPrefix(content.Key, core.ProposalDelayKeyPrefix) || content.Key == core.ProposalTTLKey {
+			delay, e := time.ParseDuration(content.Value)
+			if err := compound.Require(e == nil && delay >= 0, "payee/invalid-proposal-delay"); err != nil {
+				log.WithError(err).Errorln("validate proposal delay failed", content.Value)
+				return err
+			}
+		}
+
 	case core.ActionTypeProposalAddOracleSigner:
 		var content proposal.AddOracleSignerReq
 		{
@@ -97,6 +108,152 @@ func (w *Payee) validateProposal(ctx context.Context, p *core.Proposal) error {
 		); err != nil {
 			return err
 		}
+
+	case core.ActionTypeProposalRotateOracleSigner:
+		var content proposal.RotateOracleSignerReq
+		{
+			if err := compound.Require(json.Unmarshal([]byte(p.Content), &content) == nil, "payee/invalid-action"); err != nil {
+				log.WithError(err).Errorln("unmarshal RotateOracleSignerReq failed")
+				return err
+			}
+		}
+
+		signer, err := w.oracleSignerStore.Find(ctx, content.UserID)
+		if err != nil {
+			log.WithError(err).Errorln("oracles.Find")
+			return err
+		}
+
+		if err := compound.Require(signer.ID > 0, "payee/oracle-signer-not-found"); err != nil {
+			return err
+		}
+
+		bts, err := base64.StdEncoding.DecodeString(content.PublicKey)
+		if e := compound.Require(
+			err == nil,
+			"payee/invalid-oracle-signer",
+		); e != nil {
+			return e
+		}
+
+		pub := blst.PublicKey{}
+		if err := compound.Require(
+			pub.FromBytes(bts) == nil,
+			"payee/invalid-oracle-signer",
+		); err != nil {
+			return err
+		}
+
+	case core.ActionTypeProposalCancel:
+		var content proposal.CancelReq
+		{
+			if err := compound.Require(json.Unmarshal([]byte(p.Content), &content) == nil, "payee/invalid-action"); err != nil {
+				log.WithError(err).Errorln("unmarshal CancelReq failed")
+				return err
+			}
+		}
+
+		target, err := w.proposalStore.Find(ctx, content.TraceID)
+		if err != nil {
+			log.WithError(err).Errorln("proposals.Find")
+			return err
+		}
+
+		if err := compound.Require(target.Queued(), "payee/proposal-not-queued"); err != nil {
+			return err
+		}
+
+	case core.ActionTypeProposalSetInterestRateModel:
+		var content proposal.InterestRateModelReq
+		{
+			if err := compound.Require(json.Unmarshal([]byte(p.Content), &content) == nil, "payee/invalid-action"); err != nil {
+				log.WithError(err).Errorln("unmarshal InterestRateModelReq failed")
+				return err
+			}
+		}
+
+		if err := compound.Require(content.Model.IsValid(), "payee/invalid-interest-rate-model"); err != nil {
+			return err
+		}
+
+		if err := compound.Require(
+			!content.FloorRate.IsNegative() && content.FloorRate.LessThan(decimal.New(1, 0)),
+			"payee/invalid-floor-rate",
+		); err != nil {
+			return err
+		}
+
+	case core.ActionTypeProposalSetIsolation:
+		var content proposal.IsolationReq
+		{
+			if err := compound.Require(json.Unmarshal([]byte(p.Content), &content) == nil, "payee/invalid-action"); err != nil {
+				log.WithError(err).Errorln("unmarshal IsolationReq failed")
+				return err
+			}
+		}
+
+		if err := compound.Require(content.Mode.IsValid(), "payee/invalid-collateral-mode"); err != nil {
+			return err
+		}
+
+		if content.Mode == core.CollateralModeIsolated {
+			if err := compound.Require(
+				content.DebtCeiling.IsPositive() && len(content.BorrowAssets) > 0,
+				"payee/invalid-isolation",
+			); err != nil {
+				return err
+			}
+		}
+
+	case core.ActionTypeProposalSetPriceGuard:
+		var content proposal.PriceGuardReq
+		{
+			if err := compound.Require(json.Unmarshal([]byte(p.Content), &content) == nil, "payee/invalid-action"); err != nil {
+				log.WithError(err).Errorln("unmarshal PriceGuardReq failed")
+				return err
+			}
+		}
+
+		if err := compound.Require(
+			content.Samples >= 1 && content.Samples <= maxPriceSamples &&
+				content.Window > 0 &&
+				!content.MaxDeviation.IsNegative() && content.MaxDeviation.LessThan(decimal.New(1, 0)),
+			"payee/invalid-price-guard",
+		); err != nil {
+			return err
+		}
+
+	case core.ActionTypeProposalSetMaxPriceAge:
+		var content proposal.MaxPriceAgeReq
+		{
+			if err := compound.Require(json.Unmarshal([]byte(p.Content), &content) == nil, "payee/invalid-action"); err != nil {
+				log.WithError(err).Errorln("unmarshal MaxPriceAgeReq failed")
+				return err
+			}
+		}
+
+		if err := compound.Require(content.MaxAge >= 0, "payee/invalid-max-price-age"); err != nil {
+			return err
+		}
+
+	case core.ActionTypeProposalSetTWAP:
+		var content proposal.TWAPReq
+		{
+			if err := compound.Require(json.Unmarshal([]byte(p.Content), &content) == nil, "payee/invalid-action"); err != nil {
+				log.WithError(err).Errorln("unmarshal TWAPReq failed")
+				return err
+			}
+		}
+
+		// the twap mode needs a window of one block at least
+		if err := compound.Require(
+			content.Mode.IsValid() &&
+				(content.Window == 0 || content.Window >= compound.SecondsPerBlock) &&
+				(content.Mode != core.ValuationModeTWAP || content.Window > 0),
+			"payee/invalid-twap",
+		); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,59 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+func (w *Payee) handleIsolationEvent(ctx context.Context, p *core.Proposal, req proposal.IsolationReq, output *core.Output) error {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"proposal": "isolation",
+		"asset":    req.AssetID,
+	})
+
+	market, err := w.mustGetMarket(ctx, req.AssetID)
+	if err != nil {
+		log.WithError(err).Errorln("requireMarket")
+		return err
+	}
+
+	if market.Version >= output.ID {
+		return nil
+	}
+
+	AccrueInterest(ctx, market, output.CreatedAt)
+
+	// keep the last allowlist and debt ceiling when switching back to cross mode,
+	// they only take effect in the isolated mode
+	market.CollateralMode = req.Mode
+	market.IsolatedDebt = decimal.Zero
+	if req.Mode == core.CollateralModeIsolated {
+		market.DebtCeiling = req.DebtCeiling
+		market.IsolatedBorrowAssets = req.BorrowAssets
+
+		// count the debt once, it's kept by the borrows & collaterals changes since then
+		markets, err := w.isolationMarkets(ctx, market)
+		if err != nil {
+			log.WithError(err).Errorln("isolationMarkets")
+			return err
+		}
+
+		if market.IsolatedDebt, err = w.isolatedDebt(ctx, market, markets); err != nil {
+			log.WithError(err).Errorln("isolatedDebt")
+			return err
+		}
+	}
+
+	if err := w.updateMarket(ctx, market, output); err != nil {
+		log.WithError(err).Errorln("markets.Update")
+		return err
+	}
+
+	log.Infoln("collateral mode updated", req.Mode)
+	return nil
+}
@@ -23,7 +23,7 @@ func (w *Payee) handleOpenMarketEvent(ctx context.Context, p *core.Proposal, req
 
 	AccrueInterest(ctx, market, output.CreatedAt)
 	market.Status = core.MarketStatusOpen
-	if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+	if err := w.updateMarket(ctx, market, output); err != nil {
 		log.WithError(err).Errorln("markets.Update")
 		return err
 	}
@@ -45,7 +45,7 @@ func (w *Payee) handleCloseMarketEvent(ctx context.Context, p *core.Proposal, re
 
 	AccrueInterest(ctx, market, output.CreatedAt)
 	market.Status = core.MarketStatusClose
-	if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+	if err := w.updateMarket(ctx, market, output); err != nil {
 		log.WithError(err).Errorln("markets.Update")
 		return err
 	}
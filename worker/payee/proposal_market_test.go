@@ -0,0 +1,46 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// a negative price threshold resets the threshold since sysversion 6
+func TestUpsertMarketPriceThreshold(t *testing.T) {
+	for _, c := range []struct {
+		sysversion int64
+		threshold  int
+	}{
+		{5, 3},
+		{6, 0},
+	} {
+		s := newScenario(t)
+		s.payee.sysversion = c.sysversion
+		btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+
+		market := s.findMarket(btc.AssetID)
+		market.PriceThreshold = 3
+		require.Nil(t, s.markets.Update(s.ctx, market, market.Version+1))
+
+		req := proposal.MarketReq{
+			Symbol:           btc.Symbol,
+			AssetID:          btc.AssetID,
+			CTokenAssetID:    btc.CTokenAssetID,
+			CollateralFactor: market.CollateralFactor,
+			PriceThreshold:   -1,
+			SupplyCap:        decimal.NewFromInt(-1),
+		}
+
+		output := &core.Output{ID: market.Version + 1, CreatedAt: s.now}
+		require.Nil(t, s.payee.handleMarketEvent(s.ctx, &core.Proposal{}, req, output))
+
+		market = s.findMarket(btc.AssetID)
+		assert.Equal(t, output.ID, market.Version)
+		assert.Equal(t, c.threshold, market.PriceThreshold, "sysversion %d", c.sysversion)
+	}
+}
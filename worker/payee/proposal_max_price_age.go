@@ -0,0 +1,38 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+func (w *Payee) handleMaxPriceAgeEvent(ctx context.Context, p *core.Proposal, req proposal.MaxPriceAgeReq, output *core.Output) error {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"proposal": "max-price-age",
+		"asset":    req.AssetID,
+	})
+
+	market, err := w.mustGetMarket(ctx, req.AssetID)
+	if err != nil {
+		log.WithError(err).Errorln("requireMarket")
+		return err
+	}
+
+	if market.Version >= output.ID {
+		return nil
+	}
+
+	AccrueInterest(ctx, market, output.CreatedAt)
+
+	market.MaxPriceAge = req.MaxAge
+	if err := w.updateMarket(ctx, market, output); err != nil {
+		log.WithError(err).Errorln("markets.Update")
+		return err
+	}
+
+	log.Infoln("max price age updated", req.MaxAge)
+	return nil
+}
@@ -35,6 +35,28 @@ func (w *Payee) handleAddOracleSignerEvent(ctx context.Context, p *core.Proposal
 		return e
 	}
 
+	signer, err := w.oracleSignerStore.Find(ctx, req.UserID)
+	if err != nil {
+		log.WithError(err).Errorln("oracles.Find")
+		return err
+	}
+
+	// no free signer index left for the new signer
+	if signer.ID == 0 {
+		signers, err := w.oracleSignerStore.FindAll(ctx)
+		if err != nil {
+			log.WithError(err).Errorln("oracles.FindAll")
+			return err
+		}
+
+		if e := compound.Require(
+			core.FreeSignerIndex(signers) > 0,
+			"payee/oracle-signers-full",
+		); e != nil {
+			return e
+		}
+	}
+
 	if err := w.oracleSignerStore.Save(ctx, req.UserID, req.PublicKey); err != nil {
 		log.WithError(err).Errorln("add oracle signer failed")
 		return err
@@ -52,6 +74,56 @@ func (w *Payee) handleRemoveOracleSignerEvent(ctx context.Context, p *core.Propo
 		log.WithError(err).Errorln("remove oracle signer failed")
 		return err
 	}
+
+	// the signers after the removed one move forward before sysversion 6, the indexes are frozen since
+	if w.sysversion < 6 {
+		if err := w.oracleSignerStore.Reindex(ctx); err != nil {
+			log.WithError(err).Errorln("oracles.Reindex")
+			return err
+		}
+	}
 	log.Infoln("oracle singer removed")
 	return nil
 }
+
+func (w *Payee) handleRotateOracleSignerEvent(ctx context.Context, p *core.Proposal, req proposal.RotateOracleSignerReq, output *core.Output) error {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"proposal":    "rotate-oracle-signer",
+		"signer":      req.UserID,
+		"publick_key": req.PublicKey,
+	})
+
+	signer, err := w.oracleSignerStore.Find(ctx, req.UserID)
+	if err != nil {
+		log.WithError(err).Errorln("oracles.Find")
+		return err
+	}
+
+	// removed after the proposal created
+	if e := compound.Require(signer.ID > 0, "payee/oracle-signer-not-found"); e != nil {
+		return e
+	}
+
+	bts, err := base64.StdEncoding.DecodeString(req.PublicKey)
+	if e := compound.Require(
+		err == nil,
+		"payee/invalid-oracle-signer",
+	); e != nil {
+		return e
+	}
+
+	pub := blst.PublicKey{}
+	if e := compound.Require(
+		pub.FromBytes(bts) == nil,
+		"payee/invalid-oracle-signer",
+	); e != nil {
+		return e
+	}
+
+	if err := w.oracleSignerStore.Save(ctx, req.UserID, req.PublicKey); err != nil {
+		log.WithError(err).Errorln("rotate oracle signer failed")
+		return err
+	}
+	log.Infoln("oracle signer rotated, index", signer.SignerIndex)
+	return nil
+}
@@ -0,0 +1,143 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/pandodao/blst"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOracleSignerIndexes(t *testing.T) {
+	s := newScenario(t)
+	output := &core.Output{}
+
+	keys := map[string]*blst.PrivateKey{}
+	add := func(userID string) {
+		key := blst.GenerateKey()
+		keys[userID] = key
+		req := proposal.AddOracleSignerReq{UserID: userID, PublicKey: base64.StdEncoding.EncodeToString(key.PublicKey().Bytes())}
+		require.Nil(t, s.payee.handleAddOracleSignerEvent(s.ctx, nil, req, output))
+	}
+
+	index := func(userID string) int64 {
+		signer, err := s.payee.oracleSignerStore.Find(s.ctx, userID)
+		require.Nil(t, err)
+		return signer.SignerIndex
+	}
+
+	alice, bob, carol, dave := newUserID(), newUserID(), newUserID(), newUserID()
+	add(alice)
+	add(bob)
+	add(carol)
+	assert.Equal(t, []int64{1, 2, 3}, []int64{index(alice), index(bob), index(carol)})
+
+	// removing a signer keeps the others, the free index is taken by the next new signer
+	require.Nil(t, s.payee.handleRemoveOracleSignerEvent(s.ctx, nil, proposal.RemoveOracleSignerReq{UserID: bob}, output))
+	assert.Equal(t, int64(3), index(carol))
+	add(dave)
+	assert.Equal(t, int64(2), index(dave))
+
+	// rotating the key keeps the index
+	key := blst.GenerateKey()
+	keys[carol] = key
+	rotate := proposal.RotateOracleSignerReq{UserID: carol, PublicKey: base64.StdEncoding.EncodeToString(key.PublicKey().Bytes())}
+	require.Nil(t, s.payee.handleRotateOracleSignerEvent(s.ctx, nil, rotate, output))
+	assert.Equal(t, int64(3), index(carol))
+
+	// signed by alice & carol with the new key
+	price := core.PriceData{
+		Timestamp: s.now.Unix(),
+		AssetID:   newUserID(),
+		Price:     decimal.NewFromInt(30000),
+	}
+
+	payload := price.Payload()
+	price.Signature = &core.CosiSignature{
+		Signature: *blst.AggregateSignatures([]*blst.Signature{keys[alice].Sign(payload), keys[carol].Sign(payload)}),
+		Mask:      0x1<<1 | 0x1<<3,
+	}
+
+	signers, err := s.payee.oracleSigners(s.ctx)
+	require.Nil(t, err)
+	assert.True(t, verifyPriceData(&price, signers, 2))
+	assert.Equal(t, []int64{1, 3}, signedIndexes(&price, signers))
+
+	require.Nil(t, s.payee.oracleSignerStore.Participate(s.ctx, 1, signedIndexes(&price, signers), s.now))
+	// counted once
+	require.Nil(t, s.payee.oracleSignerStore.Participate(s.ctx, 1, signedIndexes(&price, signers), s.now))
+	require.Nil(t, s.payee.oracleSignerStore.Participate(s.ctx, 2, []int64{2, 3}, s.now.Add(time.Minute)))
+
+	all, err := s.payee.oracleSignerStore.FindAll(s.ctx)
+	require.Nil(t, err)
+	if assert.Len(t, all, 3) {
+		assert.Equal(t, alice, all[0].UserID)
+		assert.Equal(t, []int64{1, 1}, []int64{all[0].Signed, all[0].Missed})
+		assert.Equal(t, []int64{1, 1}, []int64{all[1].Signed, all[1].Missed})
+		assert.Equal(t, []int64{2, 0}, []int64{all[2].Signed, all[2].Missed})
+		assert.True(t, all[2].LastSignedAt.Equal(s.now.Add(time.Minute)))
+	}
+}
+
+// the signers are indexed by the positions before sysversion 6, removing a signer moves the ones after it forward
+func TestOracleSignerIndexesSysVersion(t *testing.T) {
+	s := newScenario(t)
+	s.payee.sysversion = 5
+	output := &core.Output{}
+
+	keys := map[string]*blst.PrivateKey{}
+	add := func(userID string) {
+		key := blst.GenerateKey()
+		keys[userID] = key
+		req := proposal.AddOracleSignerReq{UserID: userID, PublicKey: base64.StdEncoding.EncodeToString(key.PublicKey().Bytes())}
+		require.Nil(t, s.payee.handleAddOracleSignerEvent(s.ctx, nil, req, output))
+	}
+
+	alice, bob, carol := newUserID(), newUserID(), newUserID()
+	add(alice)
+	add(bob)
+	add(carol)
+
+	require.Nil(t, s.payee.handleRemoveOracleSignerEvent(s.ctx, nil, proposal.RemoveOracleSignerReq{UserID: bob}, output))
+	signer, err := s.payee.oracleSignerStore.Find(s.ctx, carol)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), signer.SignerIndex)
+
+	price := core.PriceData{
+		Timestamp: s.now.Unix(),
+		AssetID:   newUserID(),
+		Price:     decimal.NewFromInt(30000),
+	}
+
+	payload := price.Payload()
+	price.Signature = &core.CosiSignature{
+		Signature: *blst.AggregateSignatures([]*blst.Signature{keys[alice].Sign(payload), keys[carol].Sign(payload)}),
+		Mask:      0x1<<1 | 0x1<<2,
+	}
+
+	signers, err := s.payee.oracleSigners(s.ctx)
+	require.Nil(t, err)
+	assert.True(t, verifyPriceData(&price, signers, 2))
+
+	// the indexes are frozen since sysversion 6
+	s.payee.sysversion = 6
+	require.Nil(t, s.payee.handleRemoveOracleSignerEvent(s.ctx, nil, proposal.RemoveOracleSignerReq{UserID: alice}, output))
+	signer, err = s.payee.oracleSignerStore.Find(s.ctx, carol)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), signer.SignerIndex)
+
+	// an invalid key fails the price before sysversion 6, and is skipped since
+	require.Nil(t, s.payee.oracleSignerStore.Save(s.ctx, newUserID(), "invalid"))
+	signers, err = s.payee.oracleSigners(s.ctx)
+	require.Nil(t, err)
+	assert.Len(t, signers, 1)
+
+	s.payee.sysversion = 5
+	_, err = s.payee.oracleSigners(s.ctx)
+	assert.NotNil(t, err)
+}
@@ -66,6 +66,55 @@ func (w *Payee) handlePassedProposalInternal(ctx context.Context, p *core.Propos
 			return err
 		}
 		return w.setProperty(ctx, output, p, req)
+
+	case core.ActionTypeProposalSetInterestRateModel:
+		var req proposal.InterestRateModelReq
+		if err := json.Unmarshal(p.Content, &req); err != nil {
+			return err
+		}
+		return w.handleInterestRateModelEvent(ctx, p, req, output)
+
+	case core.ActionTypeProposalSetIsolation:
+		var req proposal.IsolationReq
+		if err := json.Unmarshal(p.Content, &req); err != nil {
+			return err
+		}
+		return w.handleIsolationEvent(ctx, p, req, output)
+
+	case core.ActionTypeProposalSetPriceGuard:
+		var req proposal.PriceGuardReq
+		if err := json.Unmarshal(p.Content, &req); err != nil {
+			return err
+		}
+		return w.handlePriceGuardEvent(ctx, p, req, output)
+
+	case core.ActionTypeProposalSetMaxPriceAge:
+		var req proposal.MaxPriceAgeReq
+		if err := json.Unmarshal(p.Content, &req); err != nil {
+			return err
+		}
+		return w.handleMaxPriceAgeEvent(ctx, p, req, output)
+
+	case core.ActionTypeProposalSetTWAP:
+		var req proposal.TWAPReq
+		if err := json.Unmarshal(p.Content, &req); err != nil {
+			return err
+		}
+		return w.handleTWAPEvent(ctx, p, req, output)
+
+	case core.ActionTypeProposalRotateOracleSigner:
+		var req proposal.RotateOracleSignerReq
+		if err := json.Unmarshal(p.Content, &req); err != nil {
+			return err
+		}
+		return w.handleRotateOracleSignerEvent(ctx, p, req, output)
+
+	case core.ActionTypeProposalCancel:
+		var req proposal.CancelReq
+		if err := json.Unmarshal(p.Content, &req); err != nil {
+			return err
+		}
+		return w.handleCancelProposalEvent(ctx, p, req, output)
 	}
 
 	return nil
@@ -0,0 +1,44 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// maxPriceSamples the max count of prices the median is computed over
+const maxPriceSamples = 100
+
+func (w *Payee) handlePriceGuardEvent(ctx context.Context, p *core.Proposal, req proposal.PriceGuardReq, output *core.Output) error {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"proposal": "price-guard",
+		"asset":    req.AssetID,
+	})
+
+	market, err := w.mustGetMarket(ctx, req.AssetID)
+	if err != nil {
+		log.WithError(err).Errorln("requireMarket")
+		return err
+	}
+
+	if market.Version >= output.ID {
+		return nil
+	}
+
+	AccrueInterest(ctx, market, output.CreatedAt)
+
+	market.PriceSamples = req.Samples
+	market.PriceWindow = req.Window
+	market.MaxPriceDeviation = req.MaxDeviation
+
+	if err := w.updateMarket(ctx, market, output); err != nil {
+		log.WithError(err).Errorln("markets.Update")
+		return err
+	}
+
+	log.Infoln("price guard updated")
+	return nil
+}
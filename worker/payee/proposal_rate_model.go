@@ -0,0 +1,42 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+func (w *Payee) handleInterestRateModelEvent(ctx context.Context, p *core.Proposal, req proposal.InterestRateModelReq, output *core.Output) error {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"proposal": "interest-rate-model",
+		"asset":    req.AssetID,
+	})
+
+	market, err := w.mustGetMarket(ctx, req.AssetID)
+	if err != nil {
+		log.WithError(err).Errorln("requireMarket")
+		return err
+	}
+
+	if market.Version >= output.ID {
+		return nil
+	}
+
+	// accrue interest with the old model before switching
+	AccrueInterest(ctx, market, output.CreatedAt)
+
+	market.InterestRateModel = req.Model
+	market.FloorRate = req.FloorRate
+	market.SupplyRatePerBlock = curSupplyRatePerBlockInternal(market).Truncate(16)
+	market.BorrowRatePerBlock = curBorrowRatePerBlockInternal(market).Truncate(16)
+	if err := w.updateMarket(ctx, market, output); err != nil {
+		log.WithError(err).Errorln("markets.Update")
+		return err
+	}
+
+	log.Infoln("interest rate model updated", req.Model)
+	return nil
+}
@@ -0,0 +1,121 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/pkg/mtg"
+	"context"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/fox-one/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+func (w *Payee) handleRejectProposal(ctx context.Context, output *core.Output, message []byte) error {
+	log := logger.FromContext(ctx).WithField("handler", "proposal_reject")
+
+	if w.sysversion < 6 {
+		log.Infoln("skip: reject not supported")
+		return nil
+	}
+
+	var trace uuid.UUID
+	if _, err := mtg.Scan(message, &trace); err != nil {
+		log.WithError(err).Errorln("scan proposal trace failed")
+		return nil
+	}
+
+	proposal, err := w.mustGetProposal(ctx, trace.String())
+	if err != nil {
+		return err
+	}
+
+	if w.system.IsStaff(output.Sender) {
+		return w.forwardProposal(ctx, output, proposal, core.ActionTypeProposalReject)
+	}
+
+	if !w.system.IsMember(output.Sender) {
+		return nil
+	}
+
+	if handled := proposal.Status != core.ProposalStatusPending ||
+		govalidator.IsIn(output.Sender, proposal.Votes...) ||
+		govalidator.IsIn(output.Sender, proposal.Rejections...); handled {
+		return nil
+	}
+
+	proposal.Rejections = append(proposal.Rejections, output.Sender)
+
+	// the votes left can't reach the threshold
+	if len(w.system.MemberIDs)-len(proposal.Rejections) < int(w.system.Threshold) {
+		proposal.Status = core.ProposalStatusRejected
+
+		if err := w.proposalService.ProposalRejected(ctx, proposal, output.Sender, w.sysversion); err != nil {
+			log.WithError(err).Errorln("proposalService.ProposalRejected")
+			return err
+		}
+	}
+
+	if err := w.proposalStore.Update(ctx, proposal, output.ID); err != nil {
+		log.WithError(err).Errorln("proposals.Update")
+		return err
+	}
+
+	return nil
+}
+
+// proposalTTL the duration the pending proposals expire after, 0 never expires
+func (w *Payee) proposalTTL(ctx context.Context) (time.Duration, error) {
+	v, err := w.propertyStore.Get(ctx, core.ProposalTTLKey)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Errorln("property.Get")
+		return 0, err
+	}
+
+	if v.String() == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(v.String())
+	if err != nil {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+// expireProposals expire the pending proposals created before the ttl by the output time
+func (w *Payee) expireProposals(ctx context.Context, output *core.Output) error {
+	log := logger.FromContext(ctx)
+
+	if w.sysversion < 6 {
+		return nil
+	}
+
+	ttl, err := w.proposalTTL(ctx)
+	if err != nil || ttl <= 0 {
+		return err
+	}
+
+	proposals, err := w.proposalStore.ListPending(ctx, output.CreatedAt.Add(-ttl))
+	if err != nil {
+		log.WithError(err).Errorln("proposals.ListPending")
+		return err
+	}
+
+	for _, p := range proposals {
+		p.Status = core.ProposalStatusExpired
+
+		if err := w.proposalService.ProposalExpired(ctx, p, w.sysversion); err != nil {
+			log.WithError(err).Errorln("proposalService.ProposalExpired")
+			return err
+		}
+
+		if err := w.proposalStore.Update(ctx, p, output.ID); err != nil {
+			log.WithError(err).Errorln("proposals.Update")
+			return err
+		}
+	}
+
+	return nil
+}
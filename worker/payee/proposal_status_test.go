@@ -0,0 +1,116 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"compound/pkg/mtg"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposalRejectAndExpire(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+
+	alice, bob := newUserID(), newUserID()
+	s.payee.system.MemberIDs = []string{alice, bob}
+	s.payee.system.Threshold = 2
+	s.payee.proposalService = nopProposalService{}
+
+	propose := func() string {
+		body, err := mtg.Encode(core.ActionTypeProposalMake, core.ActionTypeProposalSetMaxPriceAge)
+		require.Nil(t, err)
+		content, err := proposal.MaxPriceAgeReq{AssetID: btc.AssetID, MaxAge: 600}.MarshalBinary()
+		require.Nil(t, err)
+
+		return s.sendBody(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, append(body, content...)).TraceID
+	}
+
+	vote := func(member string, action core.ActionType, trace string) {
+		s.send(member, s.payee.system.VoteAsset, s.payee.system.VoteAmount, action, uuid.FromStringOrNil(trace))
+	}
+
+	findProposal := func(trace string) *core.Proposal {
+		p, err := s.payee.proposalStore.Find(s.ctx, trace)
+		require.Nil(t, err)
+		return p
+	}
+
+	// one rejection of two members with threshold 2 can never pass
+	trace := propose()
+	assert.Equal(t, core.ProposalStatusPending, findProposal(trace).Status)
+
+	vote(alice, core.ActionTypeProposalVote, trace)
+	vote(bob, core.ActionTypeProposalReject, trace)
+	p := findProposal(trace)
+	assert.Equal(t, core.ProposalStatusRejected, p.Status)
+	assert.Equal(t, []string{bob}, []string(p.Rejections))
+
+	// the votes after rejected are ignored
+	vote(bob, core.ActionTypeProposalVote, trace)
+	assert.Equal(t, core.ProposalStatusRejected, findProposal(trace).Status)
+	assert.Zero(t, s.findMarket(btc.AssetID).MaxPriceAge)
+
+	// never expired without the ttl
+	trace = propose()
+	s.now = s.now.AddDate(1, 0, 0)
+	s.send(newUserID(), btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	assert.Equal(t, core.ProposalStatusPending, findProposal(trace).Status)
+
+	// expired by the output time after the ttl
+	require.Nil(t, s.properties.Save(s.ctx, core.ProposalTTLKey, "72h"))
+	trace = propose()
+	s.now = s.now.Add(72 * time.Hour)
+	s.send(newUserID(), btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	assert.Equal(t, core.ProposalStatusExpired, findProposal(trace).Status)
+
+	vote(alice, core.ActionTypeProposalVote, trace)
+	vote(bob, core.ActionTypeProposalVote, trace)
+	assert.Equal(t, core.ProposalStatusExpired, findProposal(trace).Status)
+	assert.Zero(t, s.findMarket(btc.AssetID).MaxPriceAge)
+
+	// passed by all the members
+	require.Nil(t, s.properties.Save(s.ctx, core.ProposalTTLKey, "1h"))
+	trace = propose()
+	vote(alice, core.ActionTypeProposalVote, trace)
+	vote(bob, core.ActionTypeProposalVote, trace)
+	assert.Equal(t, core.ProposalStatusExecuted, findProposal(trace).Status)
+	assert.Equal(t, int64(600), s.findMarket(btc.AssetID).MaxPriceAge)
+
+	s.now = s.now.Add(2 * time.Hour)
+	s.send(newUserID(), btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	assert.Equal(t, core.ProposalStatusExecuted, findProposal(trace).Status)
+}
+
+// the rejections & the expiration are ignored before sysversion 6
+func TestProposalRejectAndExpireSysVersion(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	s.payee.sysversion = 5
+
+	alice, bob := newUserID(), newUserID()
+	s.payee.system.MemberIDs = []string{alice, bob}
+	s.payee.system.Threshold = 2
+	s.payee.proposalService = nopProposalService{}
+	require.Nil(t, s.properties.Save(s.ctx, core.ProposalTTLKey, "1h"))
+
+	body, err := mtg.Encode(core.ActionTypeProposalMake, core.ActionTypeProposalSetMaxPriceAge)
+	require.Nil(t, err)
+	content, err := proposal.MaxPriceAgeReq{AssetID: btc.AssetID, MaxAge: 600}.MarshalBinary()
+	require.Nil(t, err)
+	trace := s.sendBody(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, append(body, content...)).TraceID
+
+	s.send(bob, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeProposalReject, uuid.FromStringOrNil(trace))
+	s.now = s.now.Add(2 * time.Hour)
+	s.send(newUserID(), btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+
+	p, err := s.payee.proposalStore.Find(s.ctx, trace)
+	require.Nil(t, err)
+	assert.Equal(t, core.ProposalStatusPending, p.Status)
+	assert.Empty(t, p.Rejections)
+}
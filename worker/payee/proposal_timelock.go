@@ -0,0 +1,139 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"compound/pkg/compound"
+	"compound/pkg/mtg"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// proposalDelay the timelock delay of the proposal action set by the property, 0 if not set
+func (w *Payee) proposalDelay(ctx context.Context, action core.ActionType) (time.Duration, error) {
+	// the cancel proposal must take effect before the canceled one
+	if w.sysversion < 6 || action == core.ActionTypeProposalCancel {
+		return 0, nil
+	}
+
+	v, err := w.propertyStore.Get(ctx, core.ProposalDelayKey(action))
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Errorln("property.Get")
+		return 0, err
+	}
+
+	if v.String() == "" {
+		return 0, nil
+	}
+
+	delay, err := time.ParseDuration(v.String())
+	if err != nil || delay < 0 {
+		return 0, nil
+	}
+
+	return delay, nil
+}
+
+// queueProposal queue the passed proposal until the timelock delay of the action, or execute it right now
+func (w *Payee) queueProposal(ctx context.Context, p *core.Proposal, output *core.Output) error {
+	delay, err := w.proposalDelay(ctx, p.Action)
+	if err != nil {
+		return err
+	}
+
+	if delay > 0 {
+		p.Status = core.ProposalStatusPassed
+		p.ExecutableAt = sql.NullTime{
+			Time:  output.CreatedAt.Add(delay),
+			Valid: true,
+		}
+		return nil
+	}
+
+	p.Status = core.ProposalStatusExecuted
+	p.ExecutedAt = sql.NullTime{
+		Time:  output.CreatedAt,
+		Valid: true,
+	}
+	return nil
+}
+
+func (w *Payee) handleExecuteProposal(ctx context.Context, output *core.Output, message []byte) error {
+	log := logger.FromContext(ctx).WithField("handler", "proposal_execute")
+
+	if err := compound.Require(w.system.IsMember(output.Sender), "payee/not-member"); err != nil {
+		log.WithError(err).Infoln("skip: not member")
+		return err
+	}
+
+	var trace uuid.UUID
+	if _, err := mtg.Scan(message, &trace); err != nil {
+		log.WithError(err).Errorln("scan proposal trace failed")
+		return nil
+	}
+
+	p, err := w.mustGetProposal(ctx, trace.String())
+	if err != nil {
+		return err
+	}
+
+	if p.Queued() && !output.CreatedAt.Before(p.ExecutableAt.Time) {
+		p.Status = core.ProposalStatusExecuted
+		p.ExecutedAt = sql.NullTime{
+			Time:  output.CreatedAt,
+			Valid: true,
+		}
+
+		if err := w.proposalStore.Update(ctx, p, output.ID); err != nil {
+			log.WithError(err).Errorln("proposals.Update")
+			return err
+		}
+	}
+
+	if p.ExecutableAt.Valid && p.ExecutedAt.Valid && p.Version == output.ID {
+		return w.handlePassedProposal(ctx, p, output)
+	}
+
+	return nil
+}
+
+func (w *Payee) handleCancelProposalEvent(ctx context.Context, p *core.Proposal, req proposal.CancelReq, output *core.Output) error {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"proposal": "cancel",
+		"target":   req.TraceID,
+	})
+
+	target, err := w.mustGetProposal(ctx, req.TraceID)
+	if err != nil {
+		return err
+	}
+
+	if target.Version >= output.ID {
+		return nil
+	}
+
+	// executed before the cancel proposal passed
+	if !target.Queued() {
+		log.Infoln("skip: proposal not queued")
+		return nil
+	}
+
+	target.Status = core.ProposalStatusRejected
+	target.CanceledAt = sql.NullTime{
+		Time:  output.CreatedAt,
+		Valid: true,
+	}
+
+	if err := w.proposalStore.Update(ctx, target, output.ID); err != nil {
+		log.WithError(err).Errorln("proposals.Update")
+		return err
+	}
+
+	log.Infoln("proposal canceled")
+	return nil
+}
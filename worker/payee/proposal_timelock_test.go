@@ -0,0 +1,130 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"compound/pkg/mtg"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopProposalService struct{}
+
+func (nopProposalService) ListItems(ctx context.Context, proposal *core.Proposal) ([]core.ProposalItem, error) {
+	return nil, nil
+}
+
+func (nopProposalService) ProposalCreated(ctx context.Context, proposal *core.Proposal, by string, sysver int64) error {
+	return nil
+}
+
+func (nopProposalService) ProposalApproved(ctx context.Context, proposal *core.Proposal, by string, sysver int64) error {
+	return nil
+}
+
+func (nopProposalService) ProposalPassed(ctx context.Context, proposal *core.Proposal, sysver int64) error {
+	return nil
+}
+
+func (nopProposalService) ProposalRejected(ctx context.Context, proposal *core.Proposal, by string, sysver int64) error {
+	return nil
+}
+
+func (nopProposalService) ProposalExpired(ctx context.Context, proposal *core.Proposal, sysver int64) error {
+	return nil
+}
+
+func TestProposalTimelock(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+
+	member := newUserID()
+	s.payee.system.MemberIDs = []string{member}
+	s.payee.system.Threshold = 1
+	s.payee.proposalService = nopProposalService{}
+
+	require.Nil(t, s.properties.Save(s.ctx, core.ProposalDelayKey(core.ActionTypeProposalSetMaxPriceAge), "1h"))
+
+	propose := func(action core.ActionType, req interface{ MarshalBinary() ([]byte, error) }) string {
+		body, err := mtg.Encode(core.ActionTypeProposalMake, action)
+		require.Nil(t, err)
+		content, err := req.MarshalBinary()
+		require.Nil(t, err)
+
+		output := s.sendBody(member, s.payee.system.VoteAsset, s.payee.system.VoteAmount, append(body, content...))
+		s.send(member, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeProposalVote, uuid.FromStringOrNil(output.TraceID))
+		return output.TraceID
+	}
+
+	execute := func(trace string) {
+		s.send(member, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeProposalExecute, uuid.FromStringOrNil(trace))
+	}
+
+	findProposal := func(trace string) *core.Proposal {
+		p, err := s.payee.proposalStore.Find(s.ctx, trace)
+		require.Nil(t, err)
+		return p
+	}
+
+	// queued by the delay
+	trace := propose(core.ActionTypeProposalSetMaxPriceAge, proposal.MaxPriceAgeReq{AssetID: btc.AssetID, MaxAge: 600})
+	p := findProposal(trace)
+	require.True(t, p.Queued())
+	assert.True(t, p.ExecutableAt.Time.Equal(p.PassedAt.Time.Add(time.Hour)))
+	assert.Zero(t, s.findMarket(btc.AssetID).MaxPriceAge)
+
+	// too early
+	execute(trace)
+	assert.True(t, findProposal(trace).Queued())
+
+	// executed by the output after the delay
+	s.now = s.now.Add(time.Hour)
+	execute(trace)
+	assert.True(t, findProposal(trace).ExecutedAt.Valid)
+	assert.Equal(t, int64(600), s.findMarket(btc.AssetID).MaxPriceAge)
+
+	// canceled before executed
+	trace = propose(core.ActionTypeProposalSetMaxPriceAge, proposal.MaxPriceAgeReq{AssetID: btc.AssetID, MaxAge: 60})
+	require.True(t, findProposal(trace).Queued())
+
+	cancel := propose(core.ActionTypeProposalCancel, proposal.CancelReq{TraceID: trace})
+	assert.True(t, findProposal(cancel).ExecutedAt.Valid)
+	assert.True(t, findProposal(trace).CanceledAt.Valid)
+
+	s.now = s.now.Add(2 * time.Hour)
+	execute(trace)
+	assert.False(t, findProposal(trace).ExecutedAt.Valid)
+	assert.Equal(t, int64(600), s.findMarket(btc.AssetID).MaxPriceAge)
+}
+
+// the proposals aren't queued before sysversion 6
+func TestProposalTimelockSysVersion(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	s.payee.sysversion = 5
+
+	member := newUserID()
+	s.payee.system.MemberIDs = []string{member}
+	s.payee.system.Threshold = 1
+	s.payee.proposalService = nopProposalService{}
+	require.Nil(t, s.properties.Save(s.ctx, core.ProposalDelayKey(core.ActionTypeProposalSetMaxPriceAge), "1h"))
+
+	body, err := mtg.Encode(core.ActionTypeProposalMake, core.ActionTypeProposalSetMaxPriceAge)
+	require.Nil(t, err)
+	content, err := proposal.MaxPriceAgeReq{AssetID: btc.AssetID, MaxAge: 600}.MarshalBinary()
+	require.Nil(t, err)
+	output := s.sendBody(member, s.payee.system.VoteAsset, s.payee.system.VoteAmount, append(body, content...))
+	s.send(member, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeProposalVote, uuid.FromStringOrNil(output.TraceID))
+
+	p, err := s.payee.proposalStore.Find(s.ctx, output.TraceID)
+	require.Nil(t, err)
+	assert.False(t, p.ExecutableAt.Valid)
+	assert.Equal(t, core.ProposalStatusExecuted, p.Status)
+	assert.Equal(t, int64(600), s.findMarket(btc.AssetID).MaxPriceAge)
+}
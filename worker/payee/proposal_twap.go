@@ -0,0 +1,44 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+func (w *Payee) handleTWAPEvent(ctx context.Context, p *core.Proposal, req proposal.TWAPReq, output *core.Output) error {
+	log := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"proposal": "twap",
+		"asset":    req.AssetID,
+	})
+
+	market, err := w.mustGetMarket(ctx, req.AssetID)
+	if err != nil {
+		log.WithError(err).Errorln("requireMarket")
+		return err
+	}
+
+	if market.Version >= output.ID {
+		return nil
+	}
+
+	AccrueInterest(ctx, market, output.CreatedAt)
+
+	// the twap price is updated by the next oracle price with the new window,
+	// switching back to spot keeps the window
+	market.ValuationMode = req.Mode
+	if req.Window > 0 {
+		market.TWAPWindow = req.Window
+	}
+
+	if err := w.updateMarket(ctx, market, output); err != nil {
+		log.WithError(err).Errorln("markets.Update")
+		return err
+	}
+
+	log.Infoln("valuation mode updated", req.Mode)
+	return nil
+}
@@ -54,6 +54,7 @@ func (w *Payee) handleVoteProposalEventV0(ctx context.Context, output *core.Outp
 				Time:  output.CreatedAt,
 				Valid: true,
 			}
+			p.Status = core.ProposalStatusExecuted
 
 			log.Infof("Proposal Approved")
 			if err := w.proposalService.ProposalPassed(ctx, p, w.sysversion); err != nil {
@@ -83,6 +84,7 @@ func (w *Payee) handleCreateProposalEventV0(ctx context.Context, output *core.Ou
 		AssetID:   output.AssetID,
 		Amount:    output.Amount,
 		Action:    action,
+		Status:    core.ProposalStatusPending,
 		CreatedAt: output.CreatedAt,
 		UpdatedAt: output.CreatedAt,
 	}
@@ -84,7 +84,10 @@ func (w *Payee) handleVoteProposalV1(ctx context.Context, output *core.Output, m
 			return err
 		}
 
-		if handled := proposal.PassedAt.Valid || govalidator.IsIn(output.Sender, proposal.Votes...); !handled {
+		if handled := proposal.Status != core.ProposalStatusPending ||
+			proposal.PassedAt.Valid ||
+			govalidator.IsIn(output.Sender, proposal.Votes...) ||
+			govalidator.IsIn(output.Sender, proposal.Rejections...); !handled {
 			proposal.Votes = append(proposal.Votes, output.Sender)
 
 			if err := w.proposalService.ProposalApproved(ctx, proposal, output.Sender, w.sysversion); err != nil {
@@ -98,6 +101,10 @@ func (w *Payee) handleVoteProposalV1(ctx context.Context, output *core.Output, m
 					Valid: true,
 				}
 
+				if err := w.queueProposal(ctx, proposal, output); err != nil {
+					return err
+				}
+
 				if err := w.proposalService.ProposalPassed(ctx, proposal, w.sysversion); err != nil {
 					logger.FromContext(ctx).WithError(err).Errorln("proposalService.ProposalPassed")
 					return err
@@ -110,7 +117,7 @@ func (w *Payee) handleVoteProposalV1(ctx context.Context, output *core.Output, m
 			}
 		}
 
-		if proposal.PassedAt.Valid && proposal.Version == output.ID {
+		if proposal.PassedAt.Valid && !proposal.ExecutableAt.Valid && proposal.Version == output.ID {
 			return w.handlePassedProposal(ctx, proposal, output)
 		}
 	}
@@ -129,6 +136,7 @@ func (w *Payee) buildProposalV1(ctx context.Context, output *core.Output, action
 		AssetID:   output.AssetID,
 		Amount:    output.Amount,
 		Action:    action,
+		Status:    core.ProposalStatusPending,
 		Version:   output.ID,
 	}
 
@@ -148,6 +156,20 @@ func (w *Payee) buildProposalV1(ctx context.Context, output *core.Output, action
 		content = &proposal.RemoveOracleSignerReq{}
 	case core.ActionTypeProposalSetProperty:
 		content = &proposal.SetProperty{}
+	case core.ActionTypeProposalSetInterestRateModel:
+		content = &proposal.InterestRateModelReq{}
+	case core.ActionTypeProposalSetIsolation:
+		content = &proposal.IsolationReq{}
+	case core.ActionTypeProposalSetPriceGuard:
+		content = &proposal.PriceGuardReq{}
+	case core.ActionTypeProposalSetMaxPriceAge:
+		content = &proposal.MaxPriceAgeReq{}
+	case core.ActionTypeProposalSetTWAP:
+		content = &proposal.TWAPReq{}
+	case core.ActionTypeProposalRotateOracleSigner:
+		content = &proposal.RotateOracleSignerReq{}
+	case core.ActionTypeProposalCancel:
+		content = &proposal.CancelReq{}
 	default:
 		return nil, fmt.Errorf("unknown proposal action %d", p.Action)
 	}
@@ -72,7 +72,10 @@ func (w *Payee) handleVoteProposal(ctx context.Context, output *core.Output, mes
 			return err
 		}
 
-		if handled := proposal.PassedAt.Valid || govalidator.IsIn(output.Sender, proposal.Votes...); !handled {
+		if handled := proposal.Status != core.ProposalStatusPending ||
+			proposal.PassedAt.Valid ||
+			govalidator.IsIn(output.Sender, proposal.Votes...) ||
+			govalidator.IsIn(output.Sender, proposal.Rejections...); !handled {
 			proposal.Votes = append(proposal.Votes, output.Sender)
 
 			if err := w.proposalService.ProposalApproved(ctx, proposal, output.Sender, w.sysversion); err != nil {
@@ -86,6 +89,10 @@ func (w *Payee) handleVoteProposal(ctx context.Context, output *core.Output, mes
 					Valid: true,
 				}
 
+				if err := w.queueProposal(ctx, proposal, output); err != nil {
+					return err
+				}
+
 				if err := w.proposalService.ProposalPassed(ctx, proposal, w.sysversion); err != nil {
 					logger.FromContext(ctx).WithError(err).Errorln("proposalService.ProposalPassed")
 					return err
@@ -98,7 +105,7 @@ func (w *Payee) handleVoteProposal(ctx context.Context, output *core.Output, mes
 			}
 		}
 
-		if proposal.PassedAt.Valid && proposal.Version == output.ID {
+		if proposal.PassedAt.Valid && !proposal.ExecutableAt.Valid && proposal.Version == output.ID {
 			return w.handlePassedProposal(ctx, proposal, output)
 		}
 	}
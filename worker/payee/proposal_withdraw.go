@@ -51,7 +51,11 @@ func (w *Payee) handleWithdrawEvent(ctx context.Context, p *core.Proposal, req p
 		market.Reserves = market.Reserves.Sub(amount)
 		AccrueInterest(ctx, market, output.CreatedAt)
 
-		if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err := w.updateMarket(ctx, market, output, &core.ReserveEntry{
+			Source:    core.ReserveSourceWithdraw,
+			Amount:    amount.Neg(),
+			Recipient: req.Opponent,
+		}); err != nil {
 			log.WithError(err).Errorln("update market error")
 			return err
 		}
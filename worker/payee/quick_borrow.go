@@ -107,6 +107,10 @@ func (w *Payee) handleQuickBorrowEvent(ctx context.Context, output *core.Output,
 	}
 
 	if tx.ID == 0 {
+		if err := w.requireFreshPrices(ctx, userID, output.CreatedAt, supplyMarket, borrowMarket); err != nil {
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickBorrow, core.ErrPriceStale)
+		}
+
 		// check liquidity
 		liquidity, err := w.accountService.CalculateAccountLiquidity(ctx, userID, supplyMarket, borrowMarket)
 		if err != nil {
@@ -115,9 +119,9 @@ func (w *Payee) handleQuickBorrowEvent(ctx context.Context, output *core.Output,
 		}
 		// add the additional liquidity provided this time
 		if isSupplyCToken {
-			liquidity = liquidity.Add(output.Amount.Mul(supplyMarket.ExchangeRate).Mul(supplyMarket.CollateralFactor).Mul(supplyMarket.Price))
+			liquidity = liquidity.Add(output.Amount.Mul(supplyMarket.ExchangeRate).Mul(supplyMarket.CollateralFactor).Mul(supplyMarket.CollateralPrice()))
 		} else {
-			liquidity = liquidity.Add(output.Amount.Mul(supplyMarket.CollateralFactor).Mul(supplyMarket.Price))
+			liquidity = liquidity.Add(output.Amount.Mul(supplyMarket.CollateralFactor).Mul(supplyMarket.CollateralPrice()))
 		}
 
 		borrowableSupplies := borrowMarket.TotalCash.Sub(borrowMarket.Reserves)
@@ -165,7 +169,40 @@ func (w *Payee) handleQuickBorrowEvent(ctx context.Context, output *core.Output,
 			return err
 		}
 
+		if err := compound.Require(
+			isSupplyCToken || supplyMarket.SupplyAllowed(output.Amount),
+			"payee/supply-cap-exceeded",
+			compound.FlagRefund,
+		); err != nil {
+			log.WithError(err).Errorln("refund: supply cap exceeded")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickBorrow, core.ErrSupplyCapExceeded)
+		}
+
+		change := isolationChange{
+			Collateral: supplyMarket,
+			CTokens:    ctokens,
+			Borrow:     borrowMarket,
+			Amount:     borrowAmount,
+		}
+		isolationAllowed, err := w.isolationAllowed(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolationAllowed")
+			return err
+		}
+
+		if err := compound.Require(isolationAllowed, "payee/isolation-denied", compound.FlagRefund); err != nil {
+			log.WithError(err).Errorln("refund: borrow not allowed by isolated collaterals")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickBorrow, core.ErrIsolationNotAllowed)
+		}
+
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
 		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
 		extra.Put("asset_id", borrowAssetID)
 		extra.Put("amount", borrowAmount)
 		extra.Put("ctokens", ctokens)
@@ -238,6 +275,10 @@ func (w *Payee) handleQuickBorrowEvent(ctx context.Context, output *core.Output,
 		return err
 	}
 
+	if err := w.applyIsolatedDebts(ctx, tx, output, supplyMarket, borrowMarket); err != nil {
+		return err
+	}
+
 	// update supply market
 	if output.ID > supplyMarket.Version {
 		// Only update the ctokens and total_cash of market when the underlying assets are provided
@@ -246,7 +287,7 @@ func (w *Payee) handleQuickBorrowEvent(ctx context.Context, output *core.Output,
 			supplyMarket.TotalCash = supplyMarket.TotalCash.Add(output.Amount).Truncate(compound.MaxPricision)
 		}
 		AccrueInterest(ctx, supplyMarket, output.CreatedAt)
-		if err := w.marketStore.Update(ctx, supplyMarket, output.ID); err != nil {
+		if err := w.updateMarket(ctx, supplyMarket, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
@@ -258,7 +299,7 @@ func (w *Payee) handleQuickBorrowEvent(ctx context.Context, output *core.Output,
 		borrowMarket.TotalBorrows = borrowMarket.TotalBorrows.Add(borrowAmount).Truncate(compound.MaxPricision)
 		AccrueInterest(ctx, borrowMarket, output.CreatedAt)
 		// update market
-		if err := w.marketStore.Update(ctx, borrowMarket, output.ID); err != nil {
+		if err := w.updateMarket(ctx, borrowMarket, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
@@ -65,7 +65,22 @@ func (w *Payee) handleQuickPledgeEvent(ctx context.Context, output *core.Output,
 			return err
 		}
 
+		if err := compound.Require(market.SupplyAllowed(output.Amount), "payee/supply-cap-exceeded", compound.FlagRefund); err != nil {
+			log.WithError(err).Errorln("refund: supply cap exceeded")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickPledge, core.ErrSupplyCapExceeded)
+		}
+
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, userID, isolationChange{
+			Collateral: market,
+			CTokens:    ctokens,
+		})
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
 		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
 		extra.Put("ctoken_asset_id", market.CTokenAssetID)
 		extra.Put("amount", ctokens)
 		{
@@ -91,11 +106,15 @@ func (w *Payee) handleQuickPledgeEvent(ctx context.Context, output *core.Output,
 		}
 	}
 
+	if err := w.applyIsolatedDebts(ctx, tx, output, market); err != nil {
+		return err
+	}
+
 	//update maket
 	if output.ID > market.Version {
 		market.CTokens = market.CTokens.Add(ctokens).Truncate(compound.MaxPricision)
 		market.TotalCash = market.TotalCash.Add(output.Amount).Truncate(compound.MaxPricision)
-		if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err := w.updateMarket(ctx, market, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
@@ -80,6 +80,10 @@ func (w *Payee) handleQuickRedeemEvent(ctx context.Context, output *core.Output,
 			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRedeem, core.ErrInsufficientCollaterals)
 		}
 
+		if err := w.requireFreshPrices(ctx, userID, output.CreatedAt, market); err != nil {
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRedeem, core.ErrPriceStale)
+		}
+
 		// check liqudity
 		liquidity, err := w.accountService.CalculateAccountLiquidity(ctx, userID, market)
 		if err != nil {
@@ -87,13 +91,35 @@ func (w *Payee) handleQuickRedeemEvent(ctx context.Context, output *core.Output,
 			return err
 		}
 
-		unpledgedTokenLiquidity := redeemTokens.Mul(market.CurExchangeRate()).Mul(market.CollateralFactor).Mul(market.Price)
+		unpledgedTokenLiquidity := redeemTokens.Mul(market.CurExchangeRate()).Mul(market.CollateralFactor).Mul(market.CollateralPrice())
 		if unpledgedTokenLiquidity.GreaterThan(liquidity) {
 			log.Errorf("insufficient liquidity, liquidity:%v, changed_liquidity:%v", liquidity, unpledgedTokenLiquidity)
 			return w.handleRefundEventV0(ctx, output, userID, followID, core.ActionTypeQuickRedeem, core.ErrInsufficientLiquidity)
 		}
 
+		change := isolationChange{
+			Collateral: market,
+			CTokens:    redeemTokens.Neg(),
+		}
+		isolationAllowed, err := w.isolationAllowed(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolationAllowed")
+			return err
+		}
+
+		if err := compound.Require(isolationAllowed, "payee/isolation-denied", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("refund: redeem not allowed by isolated collaterals")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRedeem, core.ErrIsolationNotAllowed)
+		}
+
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
 		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
 		extra.Put("asset_id", market.AssetID)
 		extra.Put("amount", underlyingAmount)
 		extra.Put("ctoken_asset_id", ctokenAssetID)
@@ -138,11 +164,15 @@ func (w *Payee) handleQuickRedeemEvent(ctx context.Context, output *core.Output,
 		return err
 	}
 
+	if err := w.applyIsolatedDebts(ctx, tx, output, market); err != nil {
+		return err
+	}
+
 	// update market
 	if output.ID > market.Version {
 		market.TotalCash = market.TotalCash.Sub(underlyingAmount).Truncate(compound.MaxPricision)
 		market.CTokens = market.CTokens.Sub(redeemTokens).Truncate(compound.MaxPricision)
-		if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err := w.updateMarket(ctx, market, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
@@ -0,0 +1,297 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/pkg/compound"
+	"compound/pkg/mtg"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// handle quick repay redeem event, repay, then unpledge, and then redeem,
+// refunded as an unknown action before sysversion 6
+func (w *Payee) handleQuickRepayRedeemEvent(ctx context.Context, output *core.Output, userID, followID string, body []byte) error {
+	log := logger.FromContext(ctx).WithField("event", "quick_repay_redeem")
+
+	if w.sysversion < 6 {
+		return w.handleRefundEventV0(ctx, output, userID, followID, core.ActionTypeRefundTransfer, core.ErrUnknown)
+	}
+
+	var (
+		ctokenAssetID string
+		redeemTokens  decimal.Decimal
+	)
+	{
+		var asset uuid.UUID
+		_, e := mtg.Scan(body, &asset, &redeemTokens)
+		if err := compound.Require(e == nil, "payee/mtgscan", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("skip: scan memo failed")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrInvalidArgument)
+		}
+
+		redeemTokens = redeemTokens.Truncate(8)
+		ctokenAssetID = asset.String()
+		log = logger.FromContext(ctx).WithFields(logrus.Fields{
+			"ctoken_asset_id": ctokenAssetID,
+			"redeem_amount":   redeemTokens,
+		})
+		ctx = logger.WithContext(ctx, log)
+	}
+
+	borrowMarket, err := w.mustGetMarket(ctx, output.AssetID)
+	if err != nil {
+		return w.returnOrRefundError(ctx, compound.WithFlag(err, compound.FlagRefund), output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrMarketNotFound)
+	}
+	if borrowMarket.Version >= output.ID {
+		log.Infoln("skip: output.ID outdated")
+		return nil
+	}
+
+	supplyMarket, err := w.mustGetMarketWithCToken(ctx, ctokenAssetID)
+	if err != nil {
+		return w.returnOrRefundError(ctx, compound.WithFlag(err, compound.FlagRefund), output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrMarketNotFound)
+	}
+
+	if err := compound.Require(
+		supplyMarket.AssetID != borrowMarket.AssetID,
+		"payee/same-supply-and-borrow-asset",
+		compound.FlagRefund,
+	); err != nil {
+		log.WithError(err).Infoln("refund: supply/borrow asset is same")
+		return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrInvalidArgument)
+	}
+
+	if err := compound.Require(
+		!supplyMarket.IsMarketClosed() && !borrowMarket.IsMarketClosed(),
+		"payee/market-closed",
+		compound.FlagRefund,
+	); err != nil {
+		log.WithError(err).Infoln("refund: market closed")
+		return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrMarketClosed)
+	}
+
+	// supply market accrue interest
+	AccrueInterest(ctx, supplyMarket, output.CreatedAt)
+	//borrow market accrue interest
+	AccrueInterest(ctx, borrowMarket, output.CreatedAt)
+
+	borrow, err := w.mustGetBorrow(ctx, userID, borrowMarket.AssetID)
+	if err != nil {
+		return w.returnOrRefundError(ctx, compound.WithFlag(err, compound.FlagRefund), output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrBorrowNotFound)
+	}
+
+	supply, err := w.mustGetSupply(ctx, userID, ctokenAssetID)
+	if err != nil {
+		return w.returnOrRefundError(ctx, compound.WithFlag(err, compound.FlagRefund), output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrSupplyNotFound)
+	}
+
+	tx, err := w.transactionStore.FindByTraceID(ctx, output.TraceID)
+	if err != nil {
+		log.WithError(err).Errorln("transactions.FindByTraceID")
+		return err
+	}
+
+	if tx.ID == 0 {
+		if err := compound.Require(redeemTokens.IsPositive(), "payee/invalid-amount", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("skip: invalid redeem amount")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrInvalidAmount)
+		}
+
+		if err := compound.Require(
+			redeemTokens.LessThanOrEqual(supply.Collaterals),
+			"payee/insufficient-collaterals",
+			compound.FlagRefund,
+		); err != nil {
+			log.WithError(err).Infoln("skip: insufficient collaterals")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrInsufficientCollaterals)
+		}
+
+		if err := compound.Require(
+			redeemTokens.LessThanOrEqual(supplyMarket.CTokens) &&
+				supplyMarket.RedeemAllowed(redeemTokens),
+			"payee/redeem-disallowed",
+			compound.FlagRefund,
+		); err != nil {
+			log.WithError(err).Infoln("skip: redeem not allowed")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrRedeemNotAllowed)
+		}
+
+		borrowBalance := compound.BorrowBalance(ctx, borrow, borrowMarket)
+		repayAmount := output.Amount
+		if repayAmount.GreaterThan(borrowBalance) {
+			repayAmount = borrowBalance
+		}
+
+		if err := w.requireFreshPrices(ctx, userID, output.CreatedAt, supplyMarket, borrowMarket); err != nil {
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrPriceStale)
+		}
+
+		// check liquidity
+		liquidity, err := w.accountService.CalculateAccountLiquidity(ctx, userID, supplyMarket, borrowMarket)
+		if err != nil {
+			log.WithError(err).Errorln("accountz.CalculateAccountLiquidity")
+			return err
+		}
+
+		// add the liquidity released by the repayment
+		liquidity = liquidity.Add(repayAmount.Mul(borrowMarket.Price))
+		unpledgedTokenLiquidity := redeemTokens.Mul(supplyMarket.CurExchangeRate()).Mul(supplyMarket.CollateralFactor).Mul(supplyMarket.CollateralPrice())
+		if err := compound.Require(
+			unpledgedTokenLiquidity.LessThanOrEqual(liquidity),
+			"payee/insufficient-liquidity",
+			compound.FlagRefund,
+		); err != nil {
+			log.WithError(err).Infof("insufficient liquidity, liquidity:%v, changed_liquidity:%v", liquidity, unpledgedTokenLiquidity)
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrInsufficientLiquidity)
+		}
+
+		change := isolationChange{
+			Collateral: supplyMarket,
+			CTokens:    redeemTokens.Neg(),
+			Borrow:     borrowMarket,
+			Amount:     repayAmount.Neg(),
+		}
+		isolationAllowed, err := w.isolationAllowed(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolationAllowed")
+			return err
+		}
+
+		if err := compound.Require(isolationAllowed, "payee/isolation-denied", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("refund: redeem not allowed by isolated collaterals")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeQuickRepayRedeem, core.ErrIsolationNotAllowed)
+		}
+
+		underlyingAmount := redeemTokens.Mul(supplyMarket.CurExchangeRate()).Truncate(8)
+		newBalance := borrowBalance.Sub(repayAmount).Truncate(compound.MaxPricision)
+
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
+		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
+		extra.Put("repay_amount", repayAmount)
+		extra.Put("asset_id", supplyMarket.AssetID)
+		extra.Put("amount", underlyingAmount)
+		extra.Put("ctoken_asset_id", ctokenAssetID)
+		extra.Put("ctokens", redeemTokens)
+		extra.Put(core.TransactionKeySupply, core.ExtraSupply{
+			UserID:        supply.UserID,
+			CTokenAssetID: supply.CTokenAssetID,
+			Collaterals:   supply.Collaterals.Sub(redeemTokens),
+		})
+		extra.Put(core.TransactionKeyBorrow, core.ExtraBorrow{
+			UserID:        borrow.UserID,
+			AssetID:       borrow.AssetID,
+			Principal:     newBalance,
+			InterestIndex: borrowMarket.BorrowIndex,
+		})
+		tx = core.BuildTransactionFromOutput(ctx, userID, followID, core.ActionTypeQuickRepayRedeem, output, extra)
+		if err := w.transactionStore.Create(ctx, tx); err != nil {
+			log.WithError(err).Errorln("transactions.Create")
+			return err
+		}
+	}
+
+	var extra struct {
+		RepayAmount decimal.Decimal `json:"repay_amount"`
+		Amount      decimal.Decimal `json:"amount"`
+		CTokens     decimal.Decimal `json:"ctokens"`
+	}
+	if err := tx.UnmarshalExtraData(&extra); err != nil {
+		log.WithError(err).Errorln("Unmarshal extra")
+		return err
+	}
+
+	if refundAmount := output.Amount.Sub(extra.RepayAmount).Truncate(8); refundAmount.IsPositive() {
+		if err := w.transferOut(
+			ctx,
+			userID,
+			followID,
+			output.TraceID,
+			output.AssetID,
+			refundAmount,
+			&core.TransferAction{
+				Source:   core.ActionTypeRepayRefundTransfer,
+				FollowID: followID,
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	// update borrow
+	if output.ID > borrow.Version {
+		borrow.Principal = compound.BorrowBalance(ctx, borrow, borrowMarket).Sub(extra.RepayAmount).Truncate(compound.MaxPricision)
+		borrow.InterestIndex = borrowMarket.BorrowIndex
+		if err := w.borrowStore.Update(ctx, borrow, output.ID); err != nil {
+			log.WithError(err).Errorln("borrows.Update")
+			return err
+		}
+	}
+
+	// update supply
+	if output.ID > supply.Version {
+		supply.Collaterals = supply.Collaterals.Sub(extra.CTokens).Truncate(compound.MaxPricision)
+		if err := w.supplyStore.Update(ctx, supply, output.ID); err != nil {
+			log.WithError(err).Errorln("supplies.Update")
+			return err
+		}
+	}
+
+	// transfer underlying asset
+	if err := w.transferOut(
+		ctx,
+		userID,
+		followID,
+		output.TraceID,
+		supplyMarket.AssetID,
+		extra.Amount,
+		&core.TransferAction{
+			Source:   core.ActionTypeQuickRepayRedeemTransfer,
+			FollowID: followID,
+		},
+	); err != nil {
+		return err
+	}
+
+	if err := w.applyIsolatedDebts(ctx, tx, output, supplyMarket, borrowMarket); err != nil {
+		return err
+	}
+
+	// update supply market
+	if output.ID > supplyMarket.Version {
+		supplyMarket.TotalCash = supplyMarket.TotalCash.Sub(extra.Amount).Truncate(compound.MaxPricision)
+		supplyMarket.CTokens = supplyMarket.CTokens.Sub(extra.CTokens).Truncate(compound.MaxPricision)
+		AccrueInterest(ctx, supplyMarket, output.CreatedAt)
+		if err := w.updateMarket(ctx, supplyMarket, output); err != nil {
+			log.WithError(err).Errorln("markets.Update")
+			return err
+		}
+	}
+
+	// update borrow market
+	if output.ID > borrowMarket.Version {
+		borrowMarket.TotalBorrows = borrowMarket.TotalBorrows.Sub(extra.RepayAmount).Truncate(compound.MaxPricision)
+		borrowMarket.TotalCash = borrowMarket.TotalCash.Add(extra.RepayAmount).Truncate(compound.MaxPricision)
+		if borrowMarket.TotalBorrows.IsNegative() {
+			borrowMarket.TotalBorrows = decimal.Zero
+		}
+
+		AccrueInterest(ctx, borrowMarket, output.CreatedAt)
+		if err := w.updateMarket(ctx, borrowMarket, output); err != nil {
+			log.WithError(err).Errorln("markets.Update")
+			return err
+		}
+	}
+
+	log.Infoln("quick repay redeem completed")
+	return nil
+}
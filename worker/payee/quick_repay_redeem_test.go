@@ -0,0 +1,146 @@
+package payee
+
+import (
+	"compound/core"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRepayRedeemScenario alice pledges 1 btc and borrows 20000 usdt,
+// another 1 btc is supplied so that all of alice's can be redeemed
+func newRepayRedeemScenario(t *testing.T) (s *scenario, btc, usdt *core.Market, alice string) {
+	s = newScenario(t)
+	btc = s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	usdt = s.market("USDT", decimal.NewFromInt(1), decimal.Zero)
+
+	s.send(newUserID(), usdt.AssetID, decimal.NewFromInt(100000), core.ActionTypeSupply)
+	s.send(newUserID(), btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+
+	alice = newUserID()
+	s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	s.send(alice, btc.CTokenAssetID, decimal.NewFromInt(1), core.ActionTypePledge)
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(usdt.AssetID), decimal.NewFromInt(20000))
+	require.Len(t, s.transfers(alice), 2)
+
+	return s, btc, usdt, alice
+}
+
+func TestScenarioQuickRepayRedeem(t *testing.T) {
+	s, btc, usdt, alice := newRepayRedeemScenario(t)
+
+	// partial repay, the collaterals left back the borrow left
+	s.send(alice, usdt.AssetID, decimal.NewFromInt(10000), core.ActionTypeQuickRepayRedeem, uuid.FromStringOrNil(btc.CTokenAssetID), decimal.NewFromFloat(0.3))
+
+	borrow, err := s.borrows.Find(s.ctx, alice, usdt.AssetID)
+	require.Nil(t, err)
+	assert.True(t, borrow.Principal.GreaterThan(decimal.NewFromInt(10000)), "interest accrued")
+	assert.True(t, borrow.Principal.LessThan(decimal.NewFromInt(10001)), borrow.Principal.String())
+
+	supply, err := s.supplies.Find(s.ctx, alice, btc.CTokenAssetID)
+	require.Nil(t, err)
+	assert.True(t, supply.Collaterals.Equal(decimal.NewFromFloat(0.7)), supply.Collaterals.String())
+
+	transfers := s.transfers(alice)
+	if assert.Len(t, transfers, 3) {
+		assert.Equal(t, btc.AssetID, transfers[2].AssetID)
+		assert.True(t, transfers[2].Amount.Equal(decimal.NewFromFloat(0.3)), transfers[2].Amount.String())
+		assert.Equal(t, core.ActionTypeQuickRepayRedeemTransfer, s.transferAction(transfers[2]).Source)
+	}
+
+	market := s.findMarket(btc.AssetID)
+	assert.True(t, market.TotalCash.Equal(decimal.NewFromFloat(1.7)), market.TotalCash.String())
+	assert.True(t, market.CTokens.Equal(decimal.NewFromFloat(1.7)), market.CTokens.String())
+
+	// full repay, the excess is refunded and all the collaterals redeemed
+	balance := borrow.Principal
+	s.send(alice, usdt.AssetID, decimal.NewFromInt(11000), core.ActionTypeQuickRepayRedeem, uuid.FromStringOrNil(btc.CTokenAssetID), decimal.NewFromFloat(0.7))
+
+	borrow, err = s.borrows.Find(s.ctx, alice, usdt.AssetID)
+	require.Nil(t, err)
+	assert.True(t, borrow.Principal.IsZero(), borrow.Principal.String())
+
+	supply, err = s.supplies.Find(s.ctx, alice, btc.CTokenAssetID)
+	require.Nil(t, err)
+	assert.True(t, supply.Collaterals.IsZero(), supply.Collaterals.String())
+
+	transfers = s.transfers(alice)
+	if assert.Len(t, transfers, 5) {
+		refund := transfers[3]
+		assert.Equal(t, usdt.AssetID, refund.AssetID)
+		assert.Equal(t, core.ActionTypeRepayRefundTransfer, s.transferAction(refund).Source)
+		// the balance grew by the interest of one more minute
+		assert.True(t, refund.Amount.LessThanOrEqual(decimal.NewFromInt(11000).Sub(balance)), refund.Amount.String())
+		assert.True(t, refund.Amount.GreaterThan(decimal.NewFromInt(999)), refund.Amount.String())
+
+		assert.Equal(t, btc.AssetID, transfers[4].AssetID)
+		assert.True(t, transfers[4].Amount.Equal(decimal.NewFromFloat(0.7)), transfers[4].Amount.String())
+	}
+
+	market = s.findMarket(usdt.AssetID)
+	assert.True(t, market.TotalBorrows.LessThan(decimal.NewFromFloat(0.0001)), market.TotalBorrows.String())
+}
+
+func TestScenarioQuickRepayRedeemInsufficientLiquidity(t *testing.T) {
+	s, btc, usdt, alice := newRepayRedeemScenario(t)
+
+	// 22500 + 1000 - 20000 left, the 0.5 btc back 11250
+	output := s.send(alice, usdt.AssetID, decimal.NewFromInt(1000), core.ActionTypeQuickRepayRedeem, uuid.FromStringOrNil(btc.CTokenAssetID), decimal.NewFromFloat(0.5))
+
+	borrow, err := s.borrows.Find(s.ctx, alice, usdt.AssetID)
+	require.Nil(t, err)
+	assert.True(t, borrow.Principal.Equal(decimal.NewFromInt(20000)), borrow.Principal.String())
+
+	supply, err := s.supplies.Find(s.ctx, alice, btc.CTokenAssetID)
+	require.Nil(t, err)
+	assert.True(t, supply.Collaterals.Equal(decimal.NewFromInt(1)), supply.Collaterals.String())
+
+	transfers := s.transfers(alice)
+	if assert.Len(t, transfers, 3) {
+		refund := transfers[2]
+		assert.Equal(t, output.AssetID, refund.AssetID)
+		assert.True(t, refund.Amount.Equal(output.Amount), refund.Amount.String())
+
+		action := s.transferAction(refund)
+		assert.Equal(t, core.ActionTypeRefundTransfer, action.Source)
+		assert.Equal(t, "payee/insufficient-liquidity", action.Message)
+	}
+}
+
+func TestScenarioQuickRepayRedeemIsolation(t *testing.T) {
+	s := newIsolationScenario(t)
+
+	// repaying 1 eth, the 0.2 btc left still can't back the 4 eth left
+	output := s.send(s.alice, s.eth.AssetID, decimal.NewFromInt(1), core.ActionTypeQuickRepayRedeem, uuid.FromStringOrNil(s.btc.CTokenAssetID), decimal.NewFromFloat(0.8))
+	s.requireIsolationRefund(output)
+
+	// repaying 4 eth, the 0.2 btc left backs the 1 eth left
+	s.send(s.alice, s.eth.AssetID, decimal.NewFromInt(4), core.ActionTypeQuickRepayRedeem, uuid.FromStringOrNil(s.btc.CTokenAssetID), decimal.NewFromFloat(0.8))
+	supply, err := s.supplies.Find(s.ctx, s.alice, s.btc.CTokenAssetID)
+	require.Nil(t, err)
+	assert.True(t, supply.Collaterals.Equal(decimal.NewFromFloat(0.2)), supply.Collaterals.String())
+}
+
+// the action is unknown before sysversion 6
+func TestScenarioQuickRepayRedeemSysVersion(t *testing.T) {
+	s, btc, usdt, alice := newRepayRedeemScenario(t)
+	s.payee.sysversion = 5
+
+	before := len(s.transfers(alice))
+	s.send(alice, usdt.AssetID, decimal.NewFromInt(10000), core.ActionTypeQuickRepayRedeem, uuid.FromStringOrNil(btc.CTokenAssetID), decimal.NewFromFloat(0.3))
+
+	borrow, err := s.borrows.Find(s.ctx, alice, usdt.AssetID)
+	require.Nil(t, err)
+	assert.True(t, borrow.Principal.Equal(decimal.NewFromInt(20000)), borrow.Principal.String())
+
+	transfers := s.transfers(alice)
+	if assert.Len(t, transfers, before+1) {
+		refund := transfers[before]
+		assert.Equal(t, usdt.AssetID, refund.AssetID)
+		assert.True(t, refund.Amount.Equal(decimal.NewFromInt(10000)), refund.Amount.String())
+		assert.Equal(t, core.ActionTypeRefundTransfer, s.transferAction(refund).Source)
+	}
+}
@@ -0,0 +1,67 @@
+package payee
+
+import (
+	"compound/core"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/fox-one/pkg/property"
+)
+
+// ReadCheckpoint read the id of the last output handled by payee
+func ReadCheckpoint(ctx context.Context, propertyStore property.Store) (int64, error) {
+	v, err := propertyStore.Get(ctx, checkpointKey)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.Int64(), nil
+}
+
+// Replay handle the outputs listed from the source wallet store until the output `to`,
+// the sysversion is reloaded before every output so that the version changes take effect at once
+func (w *Payee) Replay(ctx context.Context, source core.WalletStore, to int64) error {
+	log := logger.FromContext(ctx).WithField("worker", "payee")
+	ctx = logger.WithContext(ctx, log)
+
+	for {
+		from, err := ReadCheckpoint(ctx, w.propertyStore)
+		if err != nil {
+			log.WithError(err).Errorln("property.Get error")
+			return err
+		}
+
+		if from >= to {
+			return nil
+		}
+
+		outputs, err := source.List(ctx, from, limit)
+		if err != nil {
+			log.WithError(err).Errorln("walletStore.List")
+			return err
+		}
+
+		if len(outputs) == 0 {
+			return nil
+		}
+
+		for _, output := range outputs {
+			if output.ID > to {
+				return nil
+			}
+
+			if err := w.loadSysVersion(ctx); err != nil {
+				return err
+			}
+
+			if err := w.handleOutput(ctx, output); err != nil {
+				return err
+			}
+
+			if err := w.propertyStore.Save(ctx, checkpointKey, output.ID); err != nil {
+				log.WithError(err).Errorln("property.Save", output.ID)
+				return err
+			}
+		}
+	}
+}
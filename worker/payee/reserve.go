@@ -0,0 +1,74 @@
+package payee
+
+import (
+	"compound/core"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/shopspring/decimal"
+)
+
+// updateMarket records the reserves accrued since the last update and the reserve changes made by the output,
+// then updates the market
+func (w *Payee) updateMarket(ctx context.Context, market *core.Market, output *core.Output, changes ...*core.ReserveEntry) error {
+	if output.ID <= market.Version {
+		return nil
+	}
+
+	changed := decimal.Zero
+	for _, entry := range changes {
+		changed = changed.Add(entry.Amount)
+	}
+
+	if err := w.recordReserveAccrual(ctx, market, output, changed); err != nil {
+		return err
+	}
+
+	for _, entry := range changes {
+		entry.AssetID = market.AssetID
+		if err := w.recordReserve(ctx, entry, output); err != nil {
+			return err
+		}
+	}
+
+	return w.marketStore.Update(ctx, market, output.ID)
+}
+
+// recordReserveAccrual must be called before the market updated
+//
+// 	accrued = market.reserves - stored_market.reserves - changed
+func (w *Payee) recordReserveAccrual(ctx context.Context, market *core.Market, output *core.Output, changed decimal.Decimal) error {
+	log := logger.FromContext(ctx)
+
+	prev, err := w.marketStore.Find(ctx, market.AssetID)
+	if err != nil {
+		log.WithError(err).Errorln("markets.Find")
+		return err
+	}
+
+	accrued := market.Reserves.Sub(prev.Reserves).Sub(changed)
+	if prev.ID == 0 || !accrued.IsPositive() {
+		return nil
+	}
+
+	return w.recordReserve(ctx, &core.ReserveEntry{
+		AssetID:   market.AssetID,
+		Source:    core.ReserveSourceAccrual,
+		Amount:    accrued,
+		FromBlock: prev.BlockNumber,
+		ToBlock:   market.BlockNumber,
+	}, output)
+}
+
+func (w *Payee) recordReserve(ctx context.Context, entry *core.ReserveEntry, output *core.Output) error {
+	entry.Version = output.ID
+	entry.TraceID = output.TraceID
+	entry.CreatedAt = output.CreatedAt
+
+	if err := w.reserveStore.Create(ctx, entry); err != nil {
+		logger.FromContext(ctx).WithError(err).Errorln("reserves.Create")
+		return err
+	}
+
+	return nil
+}
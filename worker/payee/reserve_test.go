@@ -0,0 +1,83 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/core/proposal"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the reserve entries of the market sum up to the market reserves
+func TestScenarioReserveLedger(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	usdt := s.market("USDT", decimal.NewFromInt(1), decimal.Zero)
+
+	assertLedger := func(step string) {
+		for _, assetID := range []string{btc.AssetID, usdt.AssetID} {
+			entries, err := s.payee.reserveStore.List(s.ctx, assetID, time.Time{}, s.now.Add(time.Second))
+			require.Nil(t, err)
+
+			sum := decimal.Zero
+			for _, entry := range entries {
+				sum = sum.Add(entry.Amount)
+			}
+
+			market := s.findMarket(assetID)
+			assert.True(t, sum.Equal(market.Reserves), "%s %s: ledger %s, reserves %s", step, market.Symbol, sum, market.Reserves)
+		}
+	}
+
+	alice, bob, carol := newUserID(), newUserID(), newUserID()
+	aliceAddress := uuid.Must(uuid.NewV4()).String()
+	require.Nil(t, s.payee.userStore.Create(s.ctx, &core.User{UserID: alice, Address: aliceAddress}))
+
+	s.send(bob, usdt.AssetID, decimal.NewFromInt(100000), core.ActionTypeSupply)
+	assertLedger("supply")
+
+	s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	s.send(alice, btc.CTokenAssetID, decimal.NewFromInt(1), core.ActionTypePledge)
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(usdt.AssetID), decimal.NewFromInt(20000))
+	assertLedger("borrow")
+
+	// a month of interest accrued by the next supply
+	s.now = s.now.AddDate(0, 1, 0)
+	s.send(bob, usdt.AssetID, decimal.NewFromInt(10), core.ActionTypeSupply)
+	assert.True(t, s.findMarket(usdt.AssetID).Reserves.IsPositive())
+	assertLedger("accrual")
+
+	// the btc price drops, alice is liquidated by carol
+	market := s.findMarket(btc.AssetID)
+	market.Price = decimal.NewFromInt(25000)
+	s.outputID++
+	require.Nil(t, s.markets.Update(s.ctx, market, s.outputID))
+
+	s.now = s.now.AddDate(0, 0, 1)
+	s.send(carol, usdt.AssetID, decimal.NewFromInt(1000), core.ActionTypeLiquidate, uuid.FromStringOrNil(aliceAddress), uuid.FromStringOrNil(btc.CTokenAssetID))
+	borrow, err := s.borrows.Find(s.ctx, alice, usdt.AssetID)
+	require.Nil(t, err)
+	require.True(t, borrow.Principal.LessThan(decimal.NewFromInt(20000)), "liquidated")
+	assertLedger("liquidation")
+
+	// withdraw half of the reserves
+	reserves := s.findMarket(usdt.AssetID).Reserves
+	amount := reserves.Div(decimal.NewFromInt(2)).Truncate(8)
+	s.outputID++
+	s.now = s.now.AddDate(0, 0, 1)
+	output := &core.Output{ID: s.outputID, CreatedAt: s.now, TraceID: uuid.Must(uuid.NewV4()).String()}
+	require.Nil(t, s.payee.handleWithdrawEvent(s.ctx, &core.Proposal{TraceID: output.TraceID}, proposal.WithdrawReq{
+		Opponent: newUserID(),
+		Asset:    usdt.AssetID,
+		Amount:   amount,
+	}, output))
+
+	market = s.findMarket(usdt.AssetID)
+	assert.Equal(t, output.ID, market.Version)
+	assert.True(t, market.Reserves.LessThan(reserves))
+	assertLedger("withdraw")
+}
@@ -0,0 +1,343 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/pkg/mtg"
+	"compound/pkg/sysversion"
+	"compound/service/account"
+	"compound/store/memory"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fox-one/pkg/property"
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scenario feeds synthetic outputs into the payee backed by the in-memory stores
+type scenario struct {
+	t   *testing.T
+	ctx context.Context
+
+	payee        *Payee
+	properties   property.Store
+	markets      core.IMarketStore
+	supplies     core.ISupplyStore
+	borrows      core.IBorrowStore
+	transactions core.TransactionStore
+	wallets      core.WalletStore
+
+	outputID int64
+	now      time.Time
+}
+
+func newScenario(t *testing.T) *scenario {
+	ctx := context.Background()
+
+	s := &scenario{
+		t:            t,
+		ctx:          ctx,
+		properties:   memory.NewPropertyStore(),
+		markets:      memory.NewMarketStore(),
+		supplies:     memory.NewSupplyStore(),
+		borrows:      memory.NewBorrowStore(),
+		transactions: memory.NewTransactionStore(),
+		wallets:      memory.NewWalletStore(),
+		now:          time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	require.Nil(t, s.properties.Save(ctx, sysversion.SysVersionKey, core.SysVersion))
+
+	s.payee = NewPayee(
+		&core.System{
+			VoteAsset:  uuid.Must(uuid.NewV4()).String(),
+			VoteAmount: decimal.NewFromFloat(0.00000001),
+		},
+		&core.Wallet{},
+		s.properties,
+		memory.NewUserStore(),
+		s.wallets,
+		s.markets,
+		s.supplies,
+		s.borrows,
+		memory.NewProposalStore(),
+		s.transactions,
+		memory.NewOracleSignerStore(),
+		memory.NewReserveStore(),
+		memory.NewPriceTickStore(),
+		memory.NewPriceAccumulatorStore(),
+		memory.NewSubscriberStore(),
+		nil,
+		nil,
+		account.New(s.markets, s.supplies, s.borrows),
+	)
+	require.Nil(t, s.payee.loadSysVersion(ctx))
+
+	return s
+}
+
+// market create an open market with a price
+func (s *scenario) market(symbol string, price decimal.Decimal, collateralFactor decimal.Decimal) *core.Market {
+	market := &core.Market{
+		Symbol:               symbol,
+		AssetID:              uuid.Must(uuid.NewV4()).String(),
+		CTokenAssetID:        uuid.Must(uuid.NewV4()).String(),
+		InitExchangeRate:     decimal.New(1, 0),
+		ExchangeRate:         decimal.New(1, 0),
+		ReserveFactor:        decimal.NewFromFloat(0.1),
+		LiquidationIncentive: decimal.NewFromFloat(0.05),
+		BorrowIndex:          decimal.New(1, 0),
+		CollateralFactor:     collateralFactor,
+		CloseFactor:          decimal.NewFromFloat(0.5),
+		BaseRate:             decimal.NewFromFloat(0.025),
+		Multiplier:           decimal.NewFromFloat(0.1),
+		JumpMultiplier:       decimal.NewFromFloat(0.5),
+		Kink:                 decimal.NewFromFloat(0.8),
+		Price:                price,
+		PriceUpdatedAt:       s.now,
+		Status:               core.MarketStatusOpen,
+	}
+
+	require.Nil(s.t, s.markets.Create(s.ctx, market))
+	return market
+}
+
+// send handle an output of the user action, the time goes one minute forward for every output
+func (s *scenario) send(sender, assetID string, amount decimal.Decimal, action core.ActionType, values ...interface{}) *core.Output {
+	body, err := mtg.Encode(append([]interface{}{action}, values...)...)
+	require.Nil(s.t, err)
+
+	return s.sendBody(sender, assetID, amount, body)
+}
+
+// sendBody handle an output with the action body
+func (s *scenario) sendBody(sender, assetID string, amount decimal.Decimal, body []byte) *core.Output {
+	memo, err := core.TransactionAction{Body: body}.Encode()
+	require.Nil(s.t, err)
+
+	s.outputID++
+	s.now = s.now.Add(time.Minute)
+	output := &core.Output{
+		ID:        s.outputID,
+		CreatedAt: s.now,
+		TraceID:   uuid.Must(uuid.NewV4()).String(),
+		AssetID:   assetID,
+		Sender:    sender,
+		Amount:    amount,
+		Memo:      base64.StdEncoding.EncodeToString(memo),
+	}
+
+	require.Nil(s.t, s.wallets.Save(s.ctx, []*core.Output{output}, true))
+	require.Nil(s.t, s.payee.handleOutput(s.ctx, output))
+	return output
+}
+
+// transfers list the pending transfers to the user
+func (s *scenario) transfers(userID string) []*core.Transfer {
+	all, err := s.wallets.ListTransfers(s.ctx, core.TransferStatusPending, 0)
+	require.Nil(s.t, err)
+
+	var transfers []*core.Transfer
+	for _, t := range all {
+		if len(t.Opponents) == 1 && t.Opponents[0] == userID {
+			transfers = append(transfers, t)
+		}
+	}
+
+	return transfers
+}
+
+func (s *scenario) transferAction(transfer *core.Transfer) core.TransferAction {
+	data, err := base64.StdEncoding.DecodeString(transfer.Memo)
+	require.Nil(s.t, err)
+
+	var action core.TransferAction
+	require.Nil(s.t, json.Unmarshal(data, &action))
+	return action
+}
+
+func (s *scenario) findMarket(assetID string) *core.Market {
+	market, err := s.markets.Find(s.ctx, assetID)
+	require.Nil(s.t, err)
+	return market
+}
+
+func newUserID() string {
+	return uuid.Must(uuid.NewV4()).String()
+}
+
+func TestScenarioSupply(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+
+	alice := newUserID()
+	output := s.send(alice, btc.AssetID, decimal.NewFromInt(2), core.ActionTypeSupply)
+
+	market := s.findMarket(btc.AssetID)
+	assert.Equal(t, output.ID, market.Version)
+	assert.True(t, market.TotalCash.Equal(decimal.NewFromInt(2)), market.TotalCash.String())
+	assert.True(t, market.CTokens.Equal(decimal.NewFromInt(2)), market.CTokens.String())
+
+	transfers := s.transfers(alice)
+	if assert.Len(t, transfers, 1) {
+		assert.Equal(t, btc.CTokenAssetID, transfers[0].AssetID)
+		assert.True(t, transfers[0].Amount.Equal(decimal.NewFromInt(2)))
+		assert.Equal(t, core.ActionTypeMint, s.transferAction(transfers[0]).Source)
+	}
+
+	tx, err := s.transactions.FindByTraceID(s.ctx, output.TraceID)
+	require.Nil(t, err)
+	assert.Equal(t, core.ActionTypeSupply, tx.Action)
+
+	// handling the same output again changes nothing
+	require.Nil(t, s.payee.handleOutput(s.ctx, output))
+	assert.True(t, s.findMarket(btc.AssetID).TotalCash.Equal(decimal.NewFromInt(2)))
+	assert.Len(t, s.transfers(alice), 1)
+}
+
+func TestScenarioBorrow(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	usdt := s.market("USDT", decimal.NewFromInt(1), decimal.Zero)
+
+	bob := newUserID()
+	s.send(bob, usdt.AssetID, decimal.NewFromInt(100000), core.ActionTypeSupply)
+
+	alice := newUserID()
+	s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	s.send(alice, btc.CTokenAssetID, decimal.NewFromInt(1), core.ActionTypePledge)
+
+	supply, err := s.supplies.Find(s.ctx, alice, btc.CTokenAssetID)
+	require.Nil(t, err)
+	assert.True(t, supply.Collaterals.Equal(decimal.NewFromInt(1)), supply.Collaterals.String())
+
+	// over the liquidity 30000 * 0.75, refunded
+	over := s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(usdt.AssetID), decimal.NewFromInt(22501))
+	refunds := s.transfers(alice)
+	if assert.Len(t, refunds, 2) {
+		refund := refunds[1]
+		assert.Equal(t, over.AssetID, refund.AssetID)
+		assert.Equal(t, core.ActionTypeRefundTransfer, s.transferAction(refund).Source)
+	}
+
+	borrowed := s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(usdt.AssetID), decimal.NewFromInt(10000))
+
+	borrow, err := s.borrows.Find(s.ctx, alice, usdt.AssetID)
+	require.Nil(t, err)
+	assert.True(t, borrow.Principal.Equal(decimal.NewFromInt(10000)), borrow.Principal.String())
+	assert.Equal(t, borrowed.ID, borrow.Version)
+
+	market := s.findMarket(usdt.AssetID)
+	assert.True(t, market.TotalBorrows.Equal(decimal.NewFromInt(10000)), market.TotalBorrows.String())
+	assert.True(t, market.TotalCash.Equal(decimal.NewFromInt(90000)), market.TotalCash.String())
+
+	transfers := s.transfers(alice)
+	if assert.Len(t, transfers, 3) {
+		assert.Equal(t, usdt.AssetID, transfers[2].AssetID)
+		assert.True(t, transfers[2].Amount.Equal(decimal.NewFromInt(10000)))
+	}
+}
+
+func TestScenarioOptimisticLock(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+
+	stale := s.findMarket(btc.AssetID)
+	s.send(newUserID(), btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+
+	stale.TotalCash = decimal.NewFromInt(100)
+	assert.NotNil(t, s.markets.Update(s.ctx, stale, s.outputID+1))
+}
+
+func TestScenarioStalePrice(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	usdt := s.market("USDT", decimal.NewFromInt(1), decimal.Zero)
+
+	// the btc price gets stale after 10 minutes
+	btc = s.findMarket(btc.AssetID)
+	btc.MaxPriceAge = 600
+	require.Nil(t, s.markets.Update(s.ctx, btc, btc.Version+1))
+
+	s.send(newUserID(), usdt.AssetID, decimal.NewFromInt(100000), core.ActionTypeSupply)
+
+	alice := newUserID()
+	s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	s.send(alice, btc.CTokenAssetID, decimal.NewFromInt(1), core.ActionTypePledge)
+
+	s.now = s.now.Add(time.Hour)
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(usdt.AssetID), decimal.NewFromInt(100))
+
+	borrow, err := s.borrows.Find(s.ctx, alice, usdt.AssetID)
+	require.Nil(t, err)
+	assert.True(t, borrow.Principal.IsZero(), borrow.Principal.String())
+
+	transfers := s.transfers(alice)
+	if assert.Len(t, transfers, 2) {
+		action := s.transferAction(transfers[1])
+		assert.Equal(t, core.ActionTypeRefundTransfer, action.Source)
+		assert.Equal(t, "payee/price-stale", action.Message)
+	}
+
+	// supply keeps working
+	s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	market := s.findMarket(btc.AssetID)
+	assert.True(t, market.TotalCash.Equal(decimal.NewFromInt(2)), market.TotalCash.String())
+}
+
+func TestScenarioUserTransactions(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	usdt := s.market("USDT", decimal.NewFromInt(1), decimal.Zero)
+
+	alice, bob := newUserID(), newUserID()
+	s.send(bob, usdt.AssetID, decimal.NewFromInt(100000), core.ActionTypeSupply)
+	s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply)
+	s.send(alice, btc.CTokenAssetID, decimal.NewFromInt(1), core.ActionTypePledge)
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeBorrow, uuid.FromStringOrNil(usdt.AssetID), decimal.NewFromInt(100))
+
+	all, err := s.transactions.ListByUser(s.ctx, alice, core.TransactionFilter{})
+	require.Nil(t, err)
+	if assert.Len(t, all, 3) {
+		assert.Equal(t, core.ActionTypeBorrow, all[0].Action, "newest first")
+		assert.Equal(t, core.ActionTypeSupply, all[2].Action)
+	}
+
+	list, err := s.transactions.ListByUser(s.ctx, alice, core.TransactionFilter{
+		Actions: []core.ActionType{core.ActionTypeSupply, core.ActionTypePledge},
+	})
+	require.Nil(t, err)
+	assert.Len(t, list, 2)
+
+	list, err = s.transactions.ListByUser(s.ctx, alice, core.TransactionFilter{AssetID: btc.CTokenAssetID})
+	require.Nil(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, core.ActionTypePledge, list[0].Action)
+	}
+
+	pledge := all[1]
+	list, err = s.transactions.ListByUser(s.ctx, alice, core.TransactionFilter{From: pledge.CreatedAt, To: pledge.CreatedAt.Add(time.Nanosecond)})
+	require.Nil(t, err)
+	if assert.NotEmpty(t, list) {
+		assert.Equal(t, pledge.ID, list[0].ID)
+	}
+
+	list, err = s.transactions.ListByUser(s.ctx, alice, core.TransactionFilter{To: all[2].CreatedAt})
+	require.Nil(t, err)
+	assert.Empty(t, list)
+
+	// paginated by the cursor
+	page, err := s.transactions.ListByUser(s.ctx, alice, core.TransactionFilter{Limit: 2})
+	require.Nil(t, err)
+	require.Len(t, page, 2)
+	page, err = s.transactions.ListByUser(s.ctx, alice, core.TransactionFilter{Cursor: page[1].ID, Limit: 2})
+	require.Nil(t, err)
+	if assert.Len(t, page, 1) {
+		assert.Equal(t, all[2].ID, page[0].ID)
+	}
+}
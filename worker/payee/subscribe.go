@@ -0,0 +1,75 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/pkg/mtg"
+	"context"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/shopspring/decimal"
+)
+
+// handleSubscribeEvent opt in the notifications, the health factor to warn below is optional,
+// refunded as an unknown action before sysversion 6
+func (w *Payee) handleSubscribeEvent(ctx context.Context, output *core.Output, userID, followID string, body []byte) error {
+	log := logger.FromContext(ctx).WithField("worker", "subscribe")
+
+	if w.sysversion < 6 {
+		return w.handleRefundEventV0(ctx, output, userID, followID, core.ActionTypeRefundTransfer, core.ErrUnknown)
+	}
+
+	var healthFactor decimal.Decimal
+	if _, err := mtg.Scan(body, &healthFactor); err != nil || healthFactor.IsNegative() {
+		healthFactor = decimal.Zero
+	}
+
+	subscriber, err := w.subscriberStore.Find(ctx, userID)
+	if err != nil {
+		log.WithError(err).Errorln("subscribers.Find")
+		return err
+	}
+
+	if subscriber.Version < output.ID {
+		subscriber.UserID = userID
+		subscriber.HealthFactor = healthFactor
+		subscriber.Version = output.ID
+		if err := w.subscriberStore.Save(ctx, subscriber); err != nil {
+			log.WithError(err).Errorln("subscribers.Save")
+			return err
+		}
+	}
+
+	return w.returnSubscribeOutput(ctx, output, userID, followID, core.ActionTypeSubscribe)
+}
+
+// handleUnsubscribeEvent opt out the notifications, refunded as an unknown action before sysversion 6
+func (w *Payee) handleUnsubscribeEvent(ctx context.Context, output *core.Output, userID, followID string, body []byte) error {
+	log := logger.FromContext(ctx).WithField("worker", "unsubscribe")
+
+	if w.sysversion < 6 {
+		return w.handleRefundEventV0(ctx, output, userID, followID, core.ActionTypeRefundTransfer, core.ErrUnknown)
+	}
+
+	if err := w.subscriberStore.Delete(ctx, userID); err != nil {
+		log.WithError(err).Errorln("subscribers.Delete")
+		return err
+	}
+
+	return w.returnSubscribeOutput(ctx, output, userID, followID, core.ActionTypeUnsubscribe)
+}
+
+// returnSubscribeOutput the subscription is free, return the paid asset to the user
+func (w *Payee) returnSubscribeOutput(ctx context.Context, output *core.Output, userID, followID string, source core.ActionType) error {
+	return w.transferOut(
+		ctx,
+		userID,
+		followID,
+		output.TraceID,
+		output.AssetID,
+		output.Amount,
+		&core.TransferAction{
+			Source:   source,
+			FollowID: followID,
+		},
+	)
+}
@@ -0,0 +1,61 @@
+package payee
+
+import (
+	"compound/core"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioSubscribe(t *testing.T) {
+	s := newScenario(t)
+
+	alice := newUserID()
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeSubscribe, decimal.NewFromFloat(1.2))
+
+	subscriber, err := s.payee.subscriberStore.Find(s.ctx, alice)
+	require.Nil(t, err)
+	assert.True(t, subscriber.HealthFactor.Equal(decimal.NewFromFloat(1.2)), subscriber.HealthFactor.String())
+
+	// the gas is returned
+	transfers := s.transfers(alice)
+	if assert.Len(t, transfers, 1) {
+		assert.Equal(t, s.payee.system.VoteAsset, transfers[0].AssetID)
+		assert.Equal(t, core.ActionTypeSubscribe, s.transferAction(transfers[0]).Source)
+	}
+
+	// the health factor is optional
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeSubscribe)
+	subscriber, err = s.payee.subscriberStore.Find(s.ctx, alice)
+	require.Nil(t, err)
+	assert.True(t, subscriber.HealthFactor.IsZero())
+
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeUnsubscribe)
+	subscriber, err = s.payee.subscriberStore.Find(s.ctx, alice)
+	require.Nil(t, err)
+	assert.Zero(t, subscriber.ID)
+	assert.Len(t, s.transfers(alice), 3)
+}
+
+// the subscriptions are unknown actions before sysversion 6
+func TestScenarioSubscribeSysVersion(t *testing.T) {
+	s := newScenario(t)
+	s.payee.sysversion = 5
+
+	alice := newUserID()
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeSubscribe, decimal.NewFromFloat(1.2))
+	s.send(alice, s.payee.system.VoteAsset, s.payee.system.VoteAmount, core.ActionTypeUnsubscribe)
+
+	subscriber, err := s.payee.subscriberStore.Find(s.ctx, alice)
+	require.Nil(t, err)
+	assert.Zero(t, subscriber.ID)
+
+	transfers := s.transfers(alice)
+	if assert.Len(t, transfers, 2) {
+		for _, transfer := range transfers {
+			assert.Equal(t, core.ActionTypeRefundTransfer, s.transferAction(transfer).Source)
+		}
+	}
+}
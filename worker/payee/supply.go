@@ -43,6 +43,11 @@ func (w *Payee) handleSupplyEvent(ctx context.Context, output *core.Output, user
 	}
 
 	if tx.ID == 0 {
+		if err := compound.Require(market.SupplyAllowed(output.Amount), "payee/supply-cap-exceeded", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("refund: supply cap exceeded")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeSupply, core.ErrSupplyCapExceeded)
+		}
+
 		extra := core.NewTransactionExtra()
 		extra.Put("ctoken_asset_id", market.CTokenAssetID)
 		extra.Put("amount", ctokens)
@@ -74,7 +79,7 @@ func (w *Payee) handleSupplyEvent(ctx context.Context, output *core.Output, user
 	if output.ID > market.Version {
 		market.CTokens = market.CTokens.Add(ctokens).Truncate(16)
 		market.TotalCash = market.TotalCash.Add(output.Amount).Truncate(16)
-		if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err := w.updateMarket(ctx, market, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
@@ -66,7 +66,17 @@ func (w *Payee) handlePledgeEvent(ctx context.Context, output *core.Output, user
 			return err
 		}
 
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, userID, isolationChange{
+			Collateral: market,
+			CTokens:    output.Amount,
+		})
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
 		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
 		extra.Put("ctoken_asset_id", output.AssetID)
 		extra.Put("amount", output.Amount)
 		{
@@ -94,8 +104,12 @@ func (w *Payee) handlePledgeEvent(ctx context.Context, output *core.Output, user
 		}
 	}
 
+	if err := w.applyIsolatedDebts(ctx, tx, output, market); err != nil {
+		return err
+	}
+
 	if output.ID > market.Version {
-		if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err := w.updateMarket(ctx, market, output); err != nil {
 			log.WithError(err).Errorln("update market error")
 			return err
 		}
@@ -77,7 +77,7 @@ func (w *Payee) handleRedeemEvent(ctx context.Context, output *core.Output, user
 	if output.ID > market.Version {
 		market.TotalCash = market.TotalCash.Sub(amount).Truncate(16)
 		market.CTokens = market.CTokens.Sub(output.Amount).Truncate(16)
-		if err := w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err := w.updateMarket(ctx, market, output); err != nil {
 			log.WithError(err).Errorln("markets.Update")
 			return err
 		}
@@ -0,0 +1,59 @@
+package payee
+
+import (
+	"compound/core"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the supplies over the supply cap are refunded by supply, quick pledge & quick borrow
+func TestScenarioSupplyCap(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(30000), decimal.NewFromFloat(0.75))
+	usdt := s.market("USDT", decimal.NewFromInt(1), decimal.Zero)
+	s.send(newUserID(), usdt.AssetID, decimal.NewFromInt(100000), core.ActionTypeSupply)
+
+	market := s.findMarket(btc.AssetID)
+	market.SupplyCap = decimal.NewFromInt(2)
+	s.outputID++
+	require.Nil(t, s.markets.Update(s.ctx, market, s.outputID))
+
+	alice := newUserID()
+	requireRefund := func(output *core.Output) {
+		transfers := s.transfers(alice)
+		require.NotEmpty(t, transfers)
+
+		refund := transfers[len(transfers)-1]
+		assert.Equal(t, output.AssetID, refund.AssetID)
+		assert.True(t, refund.Amount.Equal(output.Amount), refund.Amount.String())
+
+		action := s.transferAction(refund)
+		assert.Equal(t, core.ActionTypeRefundTransfer, action.Source)
+		assert.Equal(t, "payee/supply-cap-exceeded", action.Message)
+
+		market := s.findMarket(btc.AssetID)
+		assert.True(t, market.TotalCash.Equal(decimal.NewFromFloat(1.5)), market.TotalCash.String())
+	}
+
+	// the ctokens are sent back
+	s.send(alice, btc.AssetID, decimal.NewFromFloat(1.5), core.ActionTypeSupply)
+	require.Len(t, s.transfers(alice), 1)
+
+	requireRefund(s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeSupply))
+	requireRefund(s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeQuickPledge))
+	requireRefund(s.send(alice, btc.AssetID, decimal.NewFromInt(1), core.ActionTypeQuickBorrow, uuid.FromStringOrNil(usdt.AssetID), decimal.NewFromInt(1000)))
+	require.Len(t, s.transfers(alice), 4)
+
+	// up to the cap
+	s.send(alice, btc.AssetID, decimal.NewFromFloat(0.5), core.ActionTypeQuickPledge)
+	require.Len(t, s.transfers(alice), 4)
+
+	supply, err := s.supplies.Find(s.ctx, alice, btc.CTokenAssetID)
+	require.Nil(t, err)
+	assert.True(t, supply.Collaterals.Equal(decimal.NewFromFloat(0.5)), supply.Collaterals.String())
+	assert.True(t, s.findMarket(btc.AssetID).TotalCash.Equal(decimal.NewFromInt(2)))
+}
@@ -77,6 +77,10 @@ func (w *Payee) handleUnpledgeEvent(ctx context.Context, output *core.Output, us
 			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeUnpledge, core.ErrInsufficientCollaterals)
 		}
 
+		if err := w.requireFreshPrices(ctx, userID, output.CreatedAt, market); err != nil {
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeUnpledge, core.ErrPriceStale)
+		}
+
 		// check liqudity
 		liquidity, err := w.accountService.CalculateAccountLiquidity(ctx, userID, market)
 		if err != nil {
@@ -85,7 +89,7 @@ func (w *Payee) handleUnpledgeEvent(ctx context.Context, output *core.Output, us
 		}
 
 		if err := compound.Require(
-			unpledgedAmount.Mul(market.ExchangeRate).Mul(market.CollateralFactor).Mul(market.Price).LessThanOrEqual(liquidity),
+			unpledgedAmount.Mul(market.ExchangeRate).Mul(market.CollateralFactor).Mul(market.CollateralPrice()).LessThanOrEqual(liquidity),
 			"payee/insufficient-borrow-balance",
 			compound.FlagRefund,
 		); err != nil {
@@ -93,7 +97,29 @@ func (w *Payee) handleUnpledgeEvent(ctx context.Context, output *core.Output, us
 			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeUnpledge, core.ErrInsufficientLiquidity)
 		}
 
+		change := isolationChange{
+			Collateral: market,
+			CTokens:    unpledgedAmount.Neg(),
+		}
+		isolationAllowed, err := w.isolationAllowed(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolationAllowed")
+			return err
+		}
+
+		if err := compound.Require(isolationAllowed, "payee/isolation-denied", compound.FlagRefund); err != nil {
+			log.WithError(err).Infoln("refund: unpledge not allowed by isolated collaterals")
+			return w.returnOrRefundError(ctx, err, output, userID, followID, core.ActionTypeUnpledge, core.ErrIsolationNotAllowed)
+		}
+
+		isolatedDebts, err := w.isolatedDebtChanges(ctx, userID, change)
+		if err != nil {
+			log.WithError(err).Errorln("isolatedDebtChanges")
+			return err
+		}
+
 		extra := core.NewTransactionExtra()
+		extra.Put(core.TransactionKeyIsolatedDebts, isolatedDebts)
 		extra.Put("ctoken_asset_id", ctokenAssetID)
 		extra.Put("amount", unpledgedAmount)
 		{
@@ -137,8 +163,12 @@ func (w *Payee) handleUnpledgeEvent(ctx context.Context, output *core.Output, us
 		return err
 	}
 
+	if err := w.applyIsolatedDebts(ctx, tx, output, market); err != nil {
+		return err
+	}
+
 	if output.ID > market.Version {
-		if err = w.marketStore.Update(ctx, market, output.ID); err != nil {
+		if err = w.updateMarket(ctx, market, output); err != nil {
 			log.WithError(err).Errorln("update market error")
 			return err
 		}
@@ -0,0 +1,67 @@
+package payee
+
+import (
+	"compound/core"
+	"compound/pkg/compound"
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// accumulatePrice fold the accepted price into the accumulator of the block and update the twap price
+//
+// 	twap = (cumulative(block) - cumulative(block - window)) / window
+//
+// 	the price of the current block is not counted until the next block, so moving the price within one block
+// 	doesn't change the twap; it falls back to the spot price if the accumulators don't cover the window
+func (w *Payee) accumulatePrice(ctx context.Context, market *core.Market, output *core.Output) error {
+	block, err := compound.GetBlockByTime(ctx, output.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	prev, err := w.accumulatorStore.Find(ctx, market.AssetID, block)
+	if err != nil {
+		return err
+	}
+
+	accumulator := &core.PriceAccumulator{
+		AssetID:    market.AssetID,
+		Block:      block,
+		Version:    output.ID,
+		Price:      market.Price,
+		Cumulative: decimal.Zero,
+		CreatedAt:  output.CreatedAt,
+	}
+
+	if prev.ID > 0 {
+		accumulator.Cumulative = prev.CumulativeAt(block)
+		if prev.Block == block {
+			accumulator.Cumulative = prev.Cumulative
+		}
+	}
+
+	if err := w.accumulatorStore.Save(ctx, accumulator); err != nil {
+		return err
+	}
+
+	market.TWAPPrice = market.Price
+	window := market.TWAPWindow / compound.SecondsPerBlock
+	if window <= 0 {
+		return nil
+	}
+
+	start, err := w.accumulatorStore.Find(ctx, market.AssetID, block-window)
+	if err != nil {
+		return err
+	}
+
+	if start.ID > 0 {
+		market.TWAPPrice = accumulator.Cumulative.
+			Sub(start.CumulativeAt(block - window)).
+			Div(decimal.NewFromInt(window)).
+			Truncate(compound.MaxPricision)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package payee
+
+import (
+	"compound/core"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccumulatePrice(t *testing.T) {
+	s := newScenario(t)
+	btc := s.market("BTC", decimal.NewFromInt(100), decimal.NewFromFloat(0.75))
+	btc.ValuationMode = core.ValuationModeTWAP
+	btc.TWAPWindow = 600
+
+	var version int64
+	feed := func(price int64, at time.Time) decimal.Decimal {
+		version++
+		btc.Price = decimal.NewFromInt(price)
+		require.Nil(t, s.payee.accumulatePrice(s.ctx, btc, &core.Output{ID: version, CreatedAt: at}))
+		return btc.TWAPPrice
+	}
+
+	now := s.now
+	// the prices don't cover the window yet, fall back to the spot price
+	twap := feed(100, now)
+	assert.True(t, twap.Equal(decimal.NewFromInt(100)), twap.String())
+	twap = feed(1000, now.Add(5*time.Second))
+	assert.True(t, twap.Equal(decimal.NewFromInt(1000)), twap.String())
+	twap = feed(100, now.Add(10*time.Second))
+	assert.True(t, twap.Equal(decimal.NewFromInt(100)), twap.String())
+
+	// only the last price of the block counts, and not until the next block
+	twap = feed(200, now.Add(10*time.Minute))
+	assert.True(t, twap.Equal(decimal.NewFromInt(100)), twap.String())
+
+	twap = feed(300, now.Add(15*time.Minute))
+	assert.True(t, twap.Equal(decimal.NewFromInt(150)), twap.String())
+
+	twap = feed(300, now.Add(20*time.Minute))
+	assert.True(t, twap.Equal(decimal.NewFromInt(250)), twap.String())
+
+	assert.True(t, btc.CollateralPrice().Equal(decimal.NewFromInt(250)))
+	btc.ValuationMode = core.ValuationModeSpot
+	assert.True(t, btc.CollateralPrice().Equal(decimal.NewFromInt(300)))
+}
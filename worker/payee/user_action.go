@@ -25,8 +25,14 @@ func (w *Payee) handleUserAction(ctx context.Context, output *core.Output, actio
 		return w.handleQuickBorrowEvent(ctx, output, output.Sender, followID, body)
 	case core.ActionTypeQuickRedeem:
 		return w.handleQuickRedeemEvent(ctx, output, output.Sender, followID, body)
+	case core.ActionTypeQuickRepayRedeem:
+		return w.handleQuickRepayRedeemEvent(ctx, output, output.Sender, followID, body)
 	case core.ActionTypeLiquidate:
 		return w.handleLiquidationEvent(ctx, output, output.Sender, followID, body)
+	case core.ActionTypeSubscribe:
+		return w.handleSubscribeEvent(ctx, output, output.Sender, followID, body)
+	case core.ActionTypeUnsubscribe:
+		return w.handleUnsubscribeEvent(ctx, output, output.Sender, followID, body)
 	default:
 		return w.handleRefundEventV0(ctx, output, output.Sender, followID, core.ActionTypeRefundTransfer, core.ErrUnknown)
 	}
@@ -0,0 +1,292 @@
+package reconciler
+
+import (
+	"bytes"
+	"compound/core"
+	"compound/metric"
+	"compound/worker"
+	"compound/worker/payee"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/fox-one/mixin-sdk-go"
+	"github.com/fox-one/pkg/logger"
+	"github.com/fox-one/pkg/property"
+	"github.com/fox-one/pkg/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// errBusy the payee is handling the outputs, the balances are moving
+var errBusy = fmt.Errorf("payee busy: %w", worker.ErrIdle)
+
+// dust the min drift to alert, the transfers are truncated to 8 decimals
+var dust = decimal.New(1, -8)
+
+// Config reconciler config
+type Config struct {
+	ConversationID string `valid:"uuid,required"`
+	Interval       time.Duration
+	// Tolerance the max drift relative to the expected balance
+	Tolerance decimal.Decimal
+}
+
+// Reconciler reconciler worker, checks the balances held by the multisig wallet against
+// the total cash of the markets and the pledged ctokens
+//
+// 	actual = unspent outputs + outputs assigned to the transfers not passed - transfers not passed
+//
+// 	the underlying asset is expected to be the total cash of the market; the ctokens are expected
+// 	to cover the collaterals at least, the rest are the ones injected for minting
+type Reconciler struct {
+	wallets         core.WalletStore
+	properties      property.Store
+	markets         core.IMarketStore
+	supplies        core.ISupplyStore
+	reconciliations core.ReconciliationStore
+	messagez        core.MessageService
+	conversationID  string
+	interval        time.Duration
+	tolerance       decimal.Decimal
+
+	// exceeded the assets exceeded the tolerance in the last round
+	exceeded map[string]bool
+}
+
+// New new reconciler worker
+func New(
+	wallets core.WalletStore,
+	properties property.Store,
+	markets core.IMarketStore,
+	supplies core.ISupplyStore,
+	reconciliations core.ReconciliationStore,
+	messagez core.MessageService,
+	cfg Config,
+) *Reconciler {
+	if _, err := govalidator.ValidateStruct(cfg); err != nil {
+		panic(err)
+	}
+
+	return &Reconciler{
+		wallets:         wallets,
+		properties:      properties,
+		markets:         markets,
+		supplies:        supplies,
+		reconciliations: reconciliations,
+		messagez:        messagez,
+		conversationID:  cfg.ConversationID,
+		interval:        cfg.Interval,
+		tolerance:       cfg.Tolerance,
+		exceeded:        map[string]bool{},
+	}
+}
+
+// Run run worker
+func (w *Reconciler) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx).WithField("worker", "reconciler")
+	ctx = logger.WithContext(ctx, log)
+
+	dur := time.Millisecond
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dur):
+			if err := metric.ObserveWorker("reconciler", func() error { return w.run(ctx, time.Now()) }); err == nil {
+				dur = w.interval
+			} else {
+				dur = 10 * time.Second
+			}
+		}
+	}
+}
+
+func (w *Reconciler) run(ctx context.Context, now time.Time) error {
+	log := logger.FromContext(ctx)
+
+	checkpoint, err := w.idleCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	reports, err := w.reconcile(ctx, checkpoint, now)
+	if err != nil {
+		return err
+	}
+
+	// the balances could be read in the middle of the assignment, rule it out by checking it twice
+	if next, err := w.idleCheckpoint(ctx); err != nil || next != checkpoint {
+		return errBusy
+	}
+
+	if err := w.reconciliations.Create(ctx, reports); err != nil {
+		log.WithError(err).Errorln("reconciliations.Create")
+		return err
+	}
+
+	// alert the drifts exceeded the tolerance in two rounds in a row,
+	// the transfers on the way may drift for a moment
+	var alerts []*core.Reconciliation
+	exceeded := make(map[string]bool, len(reports))
+	for _, r := range reports {
+		if r.Exceeded {
+			exceeded[r.AssetID] = true
+			if w.exceeded[r.AssetID] {
+				alerts = append(alerts, r)
+			}
+		}
+	}
+	w.exceeded = exceeded
+
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	log.Warnf("%d balances drift out of the tolerance", len(alerts))
+	return w.alert(ctx, checkpoint, alerts)
+}
+
+// idleCheckpoint the payee checkpoint, errBusy if the payee has outputs to handle
+func (w *Reconciler) idleCheckpoint(ctx context.Context) (int64, error) {
+	log := logger.FromContext(ctx)
+
+	lastOutputID, err := w.wallets.CountOutputs(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("wallets.CountOutputs")
+		return 0, err
+	}
+
+	checkpoint, err := payee.ReadCheckpoint(ctx, w.properties)
+	if err != nil {
+		log.WithError(err).Errorln("read checkpoint")
+		return 0, err
+	}
+
+	if checkpoint < lastOutputID {
+		log.Debugf("payee busy, checkpoint %d < %d", checkpoint, lastOutputID)
+		return 0, errBusy
+	}
+
+	return checkpoint, nil
+}
+
+func (w *Reconciler) reconcile(ctx context.Context, checkpoint int64, now time.Time) ([]*core.Reconciliation, error) {
+	log := logger.FromContext(ctx)
+
+	balances, err := w.balances(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("balances")
+		return nil, err
+	}
+
+	markets, err := w.markets.All(ctx)
+	if err != nil {
+		log.WithError(err).Errorln("markets.All")
+		return nil, err
+	}
+
+	var reports []*core.Reconciliation
+	for _, m := range markets {
+		cash := &core.Reconciliation{
+			Checkpoint: checkpoint,
+			AssetID:    m.AssetID,
+			Symbol:     m.Symbol,
+			Type:       core.ReconciliationTypeCash,
+			Expected:   m.TotalCash,
+			Actual:     balances[m.AssetID],
+			CreatedAt:  now,
+		}
+		cash.Drift = cash.Actual.Sub(cash.Expected)
+		cash.Exceeded = w.outOfTolerance(cash.Drift.Abs(), cash.Expected)
+
+		supplies, err := w.supplies.FindByCTokenAssetID(ctx, m.CTokenAssetID)
+		if err != nil {
+			log.WithError(err).Errorln("supplies.FindByCTokenAssetID")
+			return nil, err
+		}
+
+		collaterals := decimal.Zero
+		for _, supply := range supplies {
+			collaterals = collaterals.Add(supply.Collaterals)
+		}
+
+		collateral := &core.Reconciliation{
+			Checkpoint: checkpoint,
+			AssetID:    m.CTokenAssetID,
+			Symbol:     "c" + m.Symbol,
+			Type:       core.ReconciliationTypeCollateral,
+			Expected:   collaterals,
+			Actual:     balances[m.CTokenAssetID],
+			CreatedAt:  now,
+		}
+		collateral.Drift = collateral.Actual.Sub(collateral.Expected)
+		// holding more ctokens than the collaterals is fine
+		collateral.Exceeded = collateral.Drift.IsNegative() && w.outOfTolerance(collateral.Drift.Abs(), collateral.Expected)
+
+		reports = append(reports, cash, collateral)
+	}
+
+	return reports, nil
+}
+
+func (w *Reconciler) outOfTolerance(drift, expected decimal.Decimal) bool {
+	return drift.GreaterThan(decimal.Max(dust, expected.Abs().Mul(w.tolerance)))
+}
+
+// balances the assets held by the wallet, the change of the transfers on the way are included
+func (w *Reconciler) balances(ctx context.Context) (map[string]decimal.Decimal, error) {
+	unspent, err := w.wallets.SumUnspent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers, assigned, err := w.wallets.SumUnpassed(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := map[string]decimal.Decimal{}
+	for _, a := range unspent {
+		balances[a.AssetID] = balances[a.AssetID].Add(a.Amount)
+	}
+
+	for _, a := range assigned {
+		balances[a.AssetID] = balances[a.AssetID].Add(a.Amount)
+	}
+
+	for _, a := range transfers {
+		balances[a.AssetID] = balances[a.AssetID].Sub(a.Amount)
+	}
+
+	return balances, nil
+}
+
+func (w *Reconciler) alert(ctx context.Context, checkpoint int64, reports []*core.Reconciliation) error {
+	group := metric.Group{Name: fmt.Sprintf("reconciler (checkpoint %d)", checkpoint)}
+	for _, r := range reports {
+		group.Entries = append(group.Entries, metric.Entry{
+			Name:  fmt.Sprintf("%s %s", r.Symbol, r.Type),
+			Value: fmt.Sprintf("expected %s, actual %s, drift %s", r.Expected, r.Actual, r.Drift),
+		})
+	}
+
+	var b bytes.Buffer
+	metric.Render(&b, []metric.Group{group})
+
+	if err := w.messagez.Send(ctx, []*core.Message{
+		core.BuildMessage(&mixin.MessageRequest{
+			ConversationID: w.conversationID,
+			MessageID:      uuid.New(),
+			Category:       mixin.MessageCategoryPlainPost,
+			Data:           base64.StdEncoding.EncodeToString(b.Bytes()),
+		}),
+	}, false); err != nil {
+		logger.FromContext(ctx).WithError(err).Errorln("messagez.Send")
+		return err
+	}
+
+	return nil
+}
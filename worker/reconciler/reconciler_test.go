@@ -0,0 +1,127 @@
+package reconciler
+
+import (
+	"compound/core"
+	"compound/store/memory"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordMessageService struct {
+	messages []*core.Message
+}
+
+func (s *recordMessageService) Send(ctx context.Context, messages []*core.Message, batch bool) error {
+	s.messages = append(s.messages, messages...)
+	return nil
+}
+
+func (s *recordMessageService) Meet(ctx context.Context, userID string) error {
+	return nil
+}
+
+func newID() string {
+	return uuid.Must(uuid.NewV4()).String()
+}
+
+func TestReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		wallets         = memory.NewWalletStore()
+		properties      = memory.NewPropertyStore()
+		markets         = memory.NewMarketStore()
+		supplies        = memory.NewSupplyStore()
+		reconciliations = memory.NewReconciliationStore()
+		messagez        = &recordMessageService{}
+	)
+
+	w := New(wallets, properties, markets, supplies, reconciliations, messagez, Config{
+		ConversationID: newID(),
+		Interval:       time.Minute,
+		Tolerance:      decimal.NewFromFloat(0.001),
+	})
+
+	btc := &core.Market{
+		Symbol:        "BTC",
+		AssetID:       newID(),
+		CTokenAssetID: newID(),
+		TotalCash:     decimal.NewFromFloat(2.5),
+	}
+	require.Nil(t, markets.Create(ctx, btc))
+	require.Nil(t, supplies.Create(ctx, &core.Supply{
+		UserID:        newID(),
+		CTokenAssetID: btc.CTokenAssetID,
+		Collaterals:   decimal.NewFromInt(1),
+	}))
+
+	outputs := []*core.Output{
+		{TraceID: newID(), AssetID: btc.AssetID, Amount: decimal.NewFromInt(2)},
+		{TraceID: newID(), AssetID: btc.AssetID, Amount: decimal.NewFromInt(1)},
+		// injected for minting
+		{TraceID: newID(), AssetID: btc.CTokenAssetID, Amount: decimal.NewFromInt(10)},
+	}
+	require.Nil(t, wallets.Save(ctx, outputs, true))
+
+	// the mint transfer is pending
+	require.Nil(t, wallets.CreateTransfers(ctx, []*core.Transfer{
+		{TraceID: newID(), AssetID: btc.CTokenAssetID, Amount: decimal.NewFromInt(8)},
+	}))
+
+	// the borrow transfer is on the way, 0.5 btc change to come back
+	unspent, err := wallets.ListUnspent(ctx, btc.AssetID, 0)
+	require.Nil(t, err)
+	require.Nil(t, wallets.Assign(ctx, unspent[1:], &core.Transfer{
+		TraceID: newID(),
+		AssetID: btc.AssetID,
+		Amount:  decimal.NewFromFloat(0.5),
+	}))
+
+	// the payee has outputs to handle
+	require.Nil(t, properties.Save(ctx, "outputs_checkpoint", 2))
+	assert.Equal(t, errBusy, w.run(ctx, time.Now()))
+
+	require.Nil(t, properties.Save(ctx, "outputs_checkpoint", 3))
+	require.Nil(t, w.run(ctx, time.Now()))
+
+	reports, err := reconciliations.ListLatest(ctx)
+	require.Nil(t, err)
+	if assert.Len(t, reports, 2) {
+		cash := reports[0]
+		assert.Equal(t, core.ReconciliationTypeCash, cash.Type)
+		assert.Equal(t, int64(3), cash.Checkpoint)
+		assert.True(t, cash.Actual.Equal(decimal.NewFromFloat(2.5)), cash.Actual.String())
+		assert.True(t, cash.Drift.IsZero(), cash.Drift.String())
+		assert.False(t, cash.Exceeded)
+
+		collateral := reports[1]
+		assert.Equal(t, core.ReconciliationTypeCollateral, collateral.Type)
+		assert.True(t, collateral.Actual.Equal(decimal.NewFromInt(2)), collateral.Actual.String())
+		assert.True(t, collateral.Drift.Equal(decimal.NewFromInt(1)), collateral.Drift.String())
+		assert.False(t, collateral.Exceeded, "more ctokens than the collaterals")
+	}
+
+	// a handler forgot to move the cash
+	btc, err = markets.Find(ctx, btc.AssetID)
+	require.Nil(t, err)
+	btc.TotalCash = decimal.NewFromInt(3)
+	require.Nil(t, markets.Update(ctx, btc, 3))
+
+	require.Nil(t, w.run(ctx, time.Now().Add(time.Minute)))
+	reports, err = reconciliations.ListLatest(ctx)
+	require.Nil(t, err)
+	if assert.Len(t, reports, 2) {
+		assert.True(t, reports[0].Exceeded)
+		assert.True(t, reports[0].Drift.Equal(decimal.NewFromFloat(-0.5)), reports[0].Drift.String())
+	}
+	assert.Empty(t, messagez.messages, "alert in the second round")
+
+	require.Nil(t, w.run(ctx, time.Now().Add(2*time.Minute)))
+	assert.Len(t, messagez.messages, 1)
+}
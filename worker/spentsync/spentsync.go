@@ -2,9 +2,10 @@ package spentsync
 
 import (
 	"compound/core"
+	"compound/metric"
+	"compound/worker"
 	"context"
 	"crypto/md5"
-	"errors"
 	"io"
 	"math/big"
 	"time"
@@ -44,7 +45,7 @@ func (w *SpentSync) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(dur):
-			if err := w.run(ctx); err == nil {
+			if err := metric.ObserveWorker("spentsync", func() error { return w.run(ctx) }); err == nil {
 				dur = 500 * time.Millisecond
 			} else {
 				dur = time.Second
@@ -64,7 +65,7 @@ func (w *SpentSync) run(ctx context.Context) error {
 	}
 
 	if len(transfers) == 0 {
-		return errors.New("EOF")
+		return worker.ErrIdle
 	}
 
 	for _, transfer := range transfers {
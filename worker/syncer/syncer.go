@@ -2,8 +2,9 @@ package syncer
 
 import (
 	"compound/core"
+	"compound/metric"
+	"compound/worker"
 	"context"
-	"errors"
 	"time"
 
 	"github.com/fox-one/pkg/logger"
@@ -41,7 +42,7 @@ func (w *Syncer) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(dur):
-			if err := w.run(ctx); err == nil {
+			if err := metric.ObserveWorker("syncer", func() error { return w.run(ctx) }); err == nil {
 				dur = 100 * time.Millisecond
 			} else {
 				dur = 500 * time.Millisecond
@@ -69,7 +70,7 @@ func (w *Syncer) run(ctx context.Context) error {
 	}
 
 	if len(outputs) == 0 {
-		return errors.New("EOF")
+		return worker.ErrIdle
 	}
 
 	log.Debugln("walletz.Pull", len(outputs), "outputs")
@@ -0,0 +1,108 @@
+package timelock
+
+import (
+	"compound/core"
+	"compound/metric"
+	"compound/pkg/mtg"
+	"compound/pkg/sysversion"
+	"compound/worker"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/fox-one/pkg/logger"
+	"github.com/fox-one/pkg/property"
+	uuidutil "github.com/fox-one/pkg/uuid"
+)
+
+// retryInterval the execute action is sent again after the interval if the proposal is still queued,
+// the last one may be handled before the delay by the output time
+const retryInterval = 10 * time.Minute
+
+// Timelock timelock worker, sends the execute actions of the queued proposals after the delay,
+// the first one handled by the payee executes the proposal
+type Timelock struct {
+	proposalStore core.ProposalStore
+	propertyStore property.Store
+	walletz       core.WalletService
+	system        *core.System
+}
+
+// New new timelock worker
+func New(
+	proposalStr core.ProposalStore,
+	propertyStr property.Store,
+	walletz core.WalletService,
+	system *core.System,
+) *Timelock {
+	return &Timelock{
+		proposalStore: proposalStr,
+		propertyStore: propertyStr,
+		walletz:       walletz,
+		system:        system,
+	}
+}
+
+// Run run worker
+func (w *Timelock) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx).WithField("worker", "timelock")
+	ctx = logger.WithContext(ctx, log)
+
+	dur := time.Millisecond
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(dur):
+			if err := metric.ObserveWorker("timelock", func() error { return w.run(ctx, time.Now()) }); err == nil {
+				dur = time.Second
+			} else {
+				dur = 10 * time.Second
+			}
+		}
+	}
+}
+
+func (w *Timelock) run(ctx context.Context, now time.Time) error {
+	log := logger.FromContext(ctx)
+
+	sysver, err := sysversion.ReadSysVersion(ctx, w.propertyStore)
+	if err != nil {
+		log.WithError(err).Errorln("sysversion.ReadSysVersion")
+		return err
+	}
+
+	if sysver < 6 {
+		return worker.ErrIdle
+	}
+
+	proposals, err := w.proposalStore.ListQueued(ctx, now)
+	if err != nil {
+		log.WithError(err).Errorln("proposals.ListQueued")
+		return err
+	}
+
+	for _, p := range proposals {
+		pid, _ := uuidutil.FromString(p.TraceID)
+		data, _ := mtg.Encode(core.ActionTypeProposalExecute, pid)
+		data, _ = core.TransactionAction{Body: data}.Encode()
+
+		// one execute action per node for the proposal in every retry interval
+		retry := int64(now.Sub(p.ExecutableAt.Time) / retryInterval)
+		if err := w.walletz.HandleTransfer(ctx, &core.Transfer{
+			TraceID:   uuidutil.Modify(p.TraceID, fmt.Sprintf("execute:%s:%d", w.system.ClientID, retry)),
+			AssetID:   w.system.VoteAsset,
+			Amount:    w.system.VoteAmount,
+			Threshold: w.system.Threshold,
+			Opponents: w.system.MemberIDs,
+			Memo:      base64.StdEncoding.EncodeToString(data),
+		}); err != nil {
+			log.WithError(err).Errorln("wallets.HandleTransfer")
+			return err
+		}
+	}
+
+	return worker.ErrIdle
+}
@@ -0,0 +1,105 @@
+package timelock
+
+import (
+	"compound/core"
+	"compound/pkg/mtg"
+	"compound/pkg/sysversion"
+	"compound/store/memory"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordWalletService records the transfers sent by the node
+type recordWalletService struct {
+	core.WalletService
+	transfers []*core.Transfer
+}
+
+func (s *recordWalletService) HandleTransfer(ctx context.Context, transfer *core.Transfer) error {
+	s.transfers = append(s.transfers, transfer)
+	return nil
+}
+
+func TestTimelockRun(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		proposals  = memory.NewProposalStore()
+		properties = memory.NewPropertyStore()
+		wallet     = &recordWalletService{}
+		system     = &core.System{
+			ClientID:   uuid.Must(uuid.NewV4()).String(),
+			MemberIDs:  []string{uuid.Must(uuid.NewV4()).String()},
+			Threshold:  1,
+			VoteAsset:  uuid.Must(uuid.NewV4()).String(),
+			VoteAmount: decimal.New(1, -8),
+		}
+		w = New(proposals, properties, wallet, system)
+	)
+
+	now := time.Now()
+	p := &core.Proposal{TraceID: uuid.Must(uuid.NewV4()).String()}
+	require.Nil(t, proposals.Create(ctx, p))
+	p.Status = core.ProposalStatusPassed
+	p.PassedAt = sql.NullTime{Time: now, Valid: true}
+	p.ExecutableAt = sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+	require.Nil(t, proposals.Update(ctx, p, 1))
+
+	// not supported by the sysversion
+	require.Nil(t, properties.Save(ctx, sysversion.SysVersionKey, 5))
+	_ = w.run(ctx, now.Add(2*time.Hour))
+	assert.Empty(t, wallet.transfers)
+
+	require.Nil(t, properties.Save(ctx, sysversion.SysVersionKey, 6))
+
+	// too early
+	_ = w.run(ctx, now)
+	assert.Empty(t, wallet.transfers)
+
+	// the same execute action is sent within the retry interval
+	_ = w.run(ctx, now.Add(time.Hour))
+	_ = w.run(ctx, now.Add(time.Hour+time.Minute))
+	require.Len(t, wallet.transfers, 2)
+	assert.Equal(t, wallet.transfers[0].TraceID, wallet.transfers[1].TraceID)
+
+	transfer := wallet.transfers[0]
+	assert.Equal(t, system.VoteAsset, transfer.AssetID)
+	assert.Equal(t, system.MemberIDs, []string(transfer.Opponents))
+
+	data, err := base64.StdEncoding.DecodeString(transfer.Memo)
+	require.Nil(t, err)
+	action, err := core.DecodeTransactionAction(data)
+	require.Nil(t, err)
+
+	var (
+		actionType core.ActionType
+		trace      uuid.UUID
+	)
+	_, err = mtg.Scan(action.Body, &actionType, &trace)
+	require.Nil(t, err)
+	assert.Equal(t, core.ActionTypeProposalExecute, actionType)
+	assert.Equal(t, p.TraceID, trace.String())
+
+	// sent again after the retry interval
+	_ = w.run(ctx, now.Add(time.Hour+retryInterval))
+	require.Len(t, wallet.transfers, 3)
+	assert.NotEqual(t, transfer.TraceID, wallet.transfers[2].TraceID)
+
+	// not queued anymore
+	p, err = proposals.Find(ctx, p.TraceID)
+	require.Nil(t, err)
+	p.Status = core.ProposalStatusExecuted
+	p.ExecutedAt = sql.NullTime{Time: now.Add(2 * time.Hour), Valid: true}
+	require.Nil(t, proposals.Update(ctx, p, 2))
+
+	_ = w.run(ctx, now.Add(3*time.Hour))
+	assert.Len(t, wallet.transfers, 3)
+}
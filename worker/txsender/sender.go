@@ -2,6 +2,8 @@ package txsender
 
 import (
 	"compound/core"
+	"compound/metric"
+	"compound/worker"
 	"context"
 	"errors"
 	"time"
@@ -33,7 +35,7 @@ func (w *Sender) Run(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(dur):
-			if err := w.run(ctx); err == nil {
+			if err := metric.ObserveWorker("txsender", func() error { return w.run(ctx) }); err == nil {
 				dur = 100 * time.Millisecond
 			} else {
 				dur = 300 * time.Millisecond
@@ -53,7 +55,7 @@ func (w *Sender) run(ctx context.Context) error {
 	}
 
 	if len(txs) == 0 {
-		return errors.New("EOF")
+		return worker.ErrIdle
 	}
 
 	var g errgroup.Group
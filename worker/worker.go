@@ -2,8 +2,12 @@ package worker
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrIdle returned by a loop of the worker with nothing to do
+var ErrIdle = errors.New("EOF")
+
 type Worker interface {
 	Run(ctx context.Context) error
 }